@@ -5,9 +5,11 @@ go 1.24.0
 require (
 	github.com/goccy/go-yaml v1.11.3
 	github.com/google/go-github v17.0.0+incompatible
+	github.com/mattn/go-isatty v0.0.17
 	github.com/sirupsen/logrus v1.9.4
 	github.com/spf13/cobra v1.8.0
 	golang.org/x/oauth2 v0.34.0
+	golang.org/x/sys v0.15.0
 )
 
 require (
@@ -15,9 +17,7 @@ require (
 	github.com/google/go-querystring v1.2.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
-	github.com/mattn/go-isatty v0.0.17 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 	golang.org/x/crypto v0.16.0 // indirect
-	golang.org/x/sys v0.15.0 // indirect
 	golang.org/x/xerrors v0.0.0-20220907171357-04be3eba64a2 // indirect
 )
@@ -0,0 +1,53 @@
+package announce
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/env"
+)
+
+// skipError signals an intentional skip. It satisfies the pipe.IsSkip interface
+// checked by the pipeline runner, without importing pkg/pipe (which would cause
+// an import cycle through pkg/pipe/registry.go).
+type skipError string
+
+func (e skipError) Error() string { return string(e) }
+func (e skipError) IsSkip() bool  { return true }
+
+// skipReason returns why the check and execution pipes skip, or "" when
+// they run. It depends only on flags and configuration.
+func skipReason(ctx *context.Context) string {
+	if ctx.SkipPublish {
+		return "publishing skipped"
+	}
+	if ctx.Config.Announce.Feed.Path == "" {
+		return "no announce.feed.path configured"
+	}
+	return ""
+}
+
+// CheckPipe validates announce configuration
+type CheckPipe struct{}
+
+func (CheckPipe) String() string { return "validating announce configuration" }
+
+func (CheckPipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
+func (CheckPipe) Run(ctx *context.Context) error {
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
+	path := ctx.Config.Announce.Feed.Path
+	if err := env.CheckResolved(path, "announce.feed.path"); err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return fmt.Errorf("announce.feed.path %q is a directory — it must name the feed file, e.g. %s/releases.xml", path, path)
+	}
+
+	ctx.Logger.Debug("Announce configuration validated successfully")
+	return nil
+}
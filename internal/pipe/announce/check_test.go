@@ -0,0 +1,42 @@
+package announce
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macCtx "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/sirupsen/logrus"
+)
+
+func TestCheckPipe(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+		errMsg  string
+	}{
+		{name: "feed file", path: dir + "/releases.xml"},
+		{name: "directory", path: dir, wantErr: true, errMsg: "is a directory"},
+		{name: "unresolved env", path: "env(FEED_PATH)", wantErr: true, errMsg: "announce.feed.path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Announce: config.AnnounceConfig{Feed: config.FeedConfig{Path: tt.path}}}
+			err := CheckPipe{}.Run(macCtx.NewContext(context.Background(), cfg, logrus.New()))
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Run() unexpected error: %v", err)
+			}
+		})
+	}
+}
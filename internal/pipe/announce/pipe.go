@@ -0,0 +1,91 @@
+package announce
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gogithub "github.com/google/go-github/github"
+	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/feed"
+)
+
+// Pipe writes an Atom feed of the repository's published releases, including
+// the one just created, to announce.feed.path.
+type Pipe struct{}
+
+func (Pipe) String() string { return "writing release feed" }
+
+func (Pipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
+// SoftFail reports that a feed that fails to render does not affect the
+// published release.
+func (Pipe) SoftFail() bool { return true }
+
+func (Pipe) Run(ctx *context.Context) error {
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
+	path := ctx.Config.Announce.Feed.Path
+
+	if ctx.GitHubClient == nil {
+		return fmt.Errorf("no GitHub client available — ensure the release step completed successfully")
+	}
+
+	owner := ctx.Config.Release.GitHub.Owner
+	repo := ctx.Config.Release.GitHub.Repo
+	releases, err := ctx.GitHubClient.ListReleases(ctx.StdCtx, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	f := feed.Feed{
+		Title: fmt.Sprintf("%s releases", ctx.Config.Project.Title()),
+		Link:  fmt.Sprintf("https://github.com/%s/%s/releases", owner, repo),
+	}
+	content, err := feed.Atom(f, feedEntries(releases), time.Now())
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create feed directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write feed: %w", err)
+	}
+
+	ctx.Artifacts.FeedPath = path
+	ctx.Logger.Infof("Release feed written to %s", path)
+	return nil
+}
+
+// feedEntries converts releases into feed entries. Drafts are left out,
+// since they are not visible to the feed's readers.
+func feedEntries(releases []*gogithub.RepositoryRelease) []feed.Entry {
+	var entries []feed.Entry
+	for _, r := range releases {
+		if r.GetDraft() {
+			continue
+		}
+		title := r.GetName()
+		if title == "" {
+			title = r.GetTagName()
+		}
+		published := r.GetPublishedAt().Time
+		if published.IsZero() {
+			published = r.GetCreatedAt().Time
+		}
+		entries = append(entries, feed.Entry{
+			Title:     title,
+			Link:      r.GetHTMLURL(),
+			Published: published,
+			Body:      r.GetBody(),
+		})
+	}
+	return entries
+}
@@ -0,0 +1,93 @@
+package announce
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogithub "github.com/google/go-github/github"
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macCtx "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/github"
+	"github.com/sirupsen/logrus"
+)
+
+func newRelease(tag string, published time.Time, draft bool) *gogithub.RepositoryRelease {
+	return &gogithub.RepositoryRelease{
+		TagName:     gogithub.String(tag),
+		Name:        gogithub.String("MyApp " + tag),
+		HTMLURL:     gogithub.String("https://github.com/owner/repo/releases/tag/" + tag),
+		Body:        gogithub.String("Notes for " + tag),
+		Draft:       gogithub.Bool(draft),
+		PublishedAt: &gogithub.Timestamp{Time: published},
+	}
+}
+
+func TestPipeWritesFeed(t *testing.T) {
+	feedPath := filepath.Join(t.TempDir(), "site", "releases.xml")
+	cfg := &config.Config{
+		Project:  config.ProjectConfig{Name: "MyApp"},
+		Release:  config.ReleaseConfig{GitHub: config.GitHubConfig{Owner: "owner", Repo: "repo"}},
+		Announce: config.AnnounceConfig{Feed: config.FeedConfig{Path: feedPath}},
+	}
+	ctx := macCtx.NewContext(context.Background(), cfg, logrus.New())
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock := github.NewMockClient()
+	mock.Releases["owner/repo"] = []*gogithub.RepositoryRelease{
+		newRelease("v1.2.0", base.AddDate(0, 2, 0), false),
+		newRelease("v1.3.0", base.AddDate(0, 3, 0), true), // draft, left out
+		newRelease("v1.0.0", base, false),
+		newRelease("v1.1.0", base.AddDate(0, 1, 0), false),
+	}
+	ctx.GitHubClient = mock
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if ctx.Artifacts.FeedPath != feedPath {
+		t.Errorf("FeedPath = %q, want %q", ctx.Artifacts.FeedPath, feedPath)
+	}
+
+	data, err := os.ReadFile(feedPath)
+	if err != nil {
+		t.Fatalf("failed to read feed: %v", err)
+	}
+	var parsed struct {
+		Title   string `xml:"title"`
+		Entries []struct {
+			Title string `xml:"title"`
+			Link  struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("feed is not valid XML: %v\n%s", err, data)
+	}
+	if parsed.Title != "MyApp releases" {
+		t.Errorf("feed title = %q, want %q", parsed.Title, "MyApp releases")
+	}
+	if len(parsed.Entries) != 3 {
+		t.Fatalf("entries = %d, want 3 published releases\n%s", len(parsed.Entries), data)
+	}
+	if parsed.Entries[0].Title != "MyApp v1.2.0" || parsed.Entries[0].Link.Href != "https://github.com/owner/repo/releases/tag/v1.2.0" {
+		t.Errorf("first entry = %+v, want the newest published release", parsed.Entries[0])
+	}
+}
+
+func TestPipeSkips(t *testing.T) {
+	ctx := macCtx.NewContext(context.Background(), &config.Config{}, logrus.New())
+	if err := (Pipe{}).Run(ctx); err == nil || err.Error() != "no announce.feed.path configured" {
+		t.Errorf("Run() error = %v, want skip without a feed path", err)
+	}
+
+	ctx.Config.Announce.Feed.Path = "releases.xml"
+	ctx.SkipPublish = true
+	if err := (Pipe{}).Run(ctx); err == nil || err.Error() != "publishing skipped" {
+		t.Errorf("Run() error = %v, want skip with --skip-publish", err)
+	}
+}
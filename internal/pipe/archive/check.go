@@ -1,27 +1,121 @@
 package archive
 
 import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/archive"
 	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/env"
 	"github.com/macreleaser/macreleaser/pkg/validate"
 )
 
+// skipError signals an intentional skip. It satisfies the pipe.IsSkip interface
+// checked by the pipeline runner, without importing pkg/pipe (which would cause
+// an import cycle through pkg/pipe/registry.go).
+type skipError string
+
+func (e skipError) Error() string { return string(e) }
+func (e skipError) IsSkip() bool  { return true }
+
+// skipReason returns why the check and execution pipes skip, or "" when
+// they run. It depends only on flags and configuration.
+func skipReason(ctx *context.Context) string {
+	if ctx.AppOnly {
+		return "skipped with --app-only"
+	}
+	return ""
+}
+
 // CheckPipe validates archive configuration
 type CheckPipe struct{}
 
 func (CheckPipe) String() string { return "validating archive configuration" }
 
+func (CheckPipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (CheckPipe) Run(ctx *context.Context) error {
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
 	cfg := ctx.Config.Archive
 
-	if err := validate.RequiredSlice(cfg.Formats, "archive.formats"); err != nil {
+	if err := validate.RequiredSlice(cfg.Formats.Types(), "archive.formats"); err != nil {
 		return err
 	}
 
 	validFormats := []string{"dmg", "zip", "app"}
-	if err := validate.AllOneOf(cfg.Formats, validFormats, "archive.formats"); err != nil {
+	if err := validate.AllOneOf(cfg.Formats.Types(), validFormats, "archive.formats"); err != nil {
 		return err
 	}
 
+	// Each zip and dmg entry must render a distinct package name, or the
+	// later package would overwrite the earlier one
+	names := make(map[string]bool)
+	for _, format := range cfg.Formats {
+		if format.Background != "" && format.Type != "dmg" {
+			return fmt.Errorf("archive.formats: background is only supported for dmg, not %s", format.Type)
+		}
+		if format.Type == "app" {
+			if format.NameTemplate != "" {
+				return fmt.Errorf("archive.formats: name_template is not supported for app — the bundle keeps its built name")
+			}
+			continue
+		}
+
+		name, err := archive.RenderName(format.NameTemplate, "Name", "Version", "Commit")
+		if err != nil {
+			return fmt.Errorf("archive.formats: %w", err)
+		}
+		file := name + "." + format.Type
+		// The Homebrew cask never picks a .dSYM.zip, and include_dsym
+		// writes one of its own
+		if format.Type == "zip" && strings.HasSuffix(file, archive.DSYMSuffix) {
+			return fmt.Errorf("archive.formats: zip name_template %q must not end in .dSYM — that name is reserved for the debug symbols package", format.NameTemplate)
+		}
+		if names[file] {
+			return fmt.Errorf("archive.formats has more than one %s named %q — give each a distinct name_template", format.Type, name)
+		}
+		names[file] = true
+	}
+
+	// Resolving the globs catches traversal and patterns that match
+	// nothing before the build runs
+	if _, err := archive.ResolveExtraFiles(cfg.ExtraFiles); err != nil {
+		return err
+	}
+
+	for _, pattern := range cfg.Exclude {
+		if err := archive.CheckExcludePattern(pattern); err != nil {
+			return err
+		}
+	}
+
+	if license := cfg.DMG.License; license != "" {
+		if err := env.CheckResolved(license, "archive.dmg.license"); err != nil {
+			return err
+		}
+		info, err := os.Stat(license)
+		if err != nil {
+			return fmt.Errorf("archive.dmg.license %q not found: %w", license, err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("archive.dmg.license %q is not a regular file", license)
+		}
+		if !validate.ContainsAny(cfg.Formats.Types(), "dmg") {
+			ctx.Logger.Warn("archive.dmg.license is set but archive.formats has no dmg — the license will not be used")
+		}
+	}
+
+	if err := archive.CheckSBOMFormat(cfg.SBOMFormat); err != nil {
+		return err
+	}
+	if cfg.SBOMFormat != "" && !cfg.SBOM {
+		ctx.Logger.Warn("archive.sbom_format is set but archive.sbom is not enabled — no SBOM will be generated")
+	}
+
 	ctx.Logger.Debug("Archive configuration validated successfully")
 	return nil
 }
@@ -24,7 +24,7 @@ func TestCheckPipe(t *testing.T) {
 			name: "valid configuration with single format",
 			config: &config.Config{
 				Archive: config.ArchiveConfig{
-					Formats: []string{"dmg"},
+					Formats: config.ArchiveFormats{{Type: "dmg"}},
 				},
 			},
 			wantErr: false,
@@ -33,7 +33,7 @@ func TestCheckPipe(t *testing.T) {
 			name: "valid configuration with multiple formats",
 			config: &config.Config{
 				Archive: config.ArchiveConfig{
-					Formats: []string{"dmg", "zip"},
+					Formats: config.ArchiveFormats{{Type: "dmg"}, {Type: "zip"}},
 				},
 			},
 			wantErr: false,
@@ -42,7 +42,7 @@ func TestCheckPipe(t *testing.T) {
 			name: "valid configuration with all formats",
 			config: &config.Config{
 				Archive: config.ArchiveConfig{
-					Formats: []string{"dmg", "zip", "app"},
+					Formats: config.ArchiveFormats{{Type: "dmg"}, {Type: "zip"}, {Type: "app"}},
 				},
 			},
 			wantErr: false,
@@ -51,7 +51,7 @@ func TestCheckPipe(t *testing.T) {
 			name: "empty formats",
 			config: &config.Config{
 				Archive: config.ArchiveConfig{
-					Formats: []string{},
+					Formats: config.ArchiveFormats{},
 				},
 			},
 			wantErr: true,
@@ -71,7 +71,7 @@ func TestCheckPipe(t *testing.T) {
 			name: "invalid format",
 			config: &config.Config{
 				Archive: config.ArchiveConfig{
-					Formats: []string{"tar"},
+					Formats: config.ArchiveFormats{{Type: "tar"}},
 				},
 			},
 			wantErr: true,
@@ -81,7 +81,7 @@ func TestCheckPipe(t *testing.T) {
 			name: "mixed valid and invalid formats",
 			config: &config.Config{
 				Archive: config.ArchiveConfig{
-					Formats: []string{"dmg", "invalid", "zip"},
+					Formats: config.ArchiveFormats{{Type: "dmg"}, {Type: "invalid"}, {Type: "zip"}},
 				},
 			},
 			wantErr: true,
@@ -91,12 +91,162 @@ func TestCheckPipe(t *testing.T) {
 			name: "uppercase format",
 			config: &config.Config{
 				Archive: config.ArchiveConfig{
-					Formats: []string{"DMG"},
+					Formats: config.ArchiveFormats{{Type: "DMG"}},
 				},
 			},
 			wantErr: true,
 			errMsg:  "invalid archive.formats: DMG",
 		},
+		{
+			name: "extra file outside project",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats:    config.ArchiveFormats{{Type: "zip"}},
+					ExtraFiles: []string{"../LICENSE"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "must be a relative path inside the project",
+		},
+		{
+			name: "extra file glob matches nothing",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats:    config.ArchiveFormats{{Type: "zip"}},
+					ExtraFiles: []string{"NO_SUCH_FILE*"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "matched no files",
+		},
+		{
+			name: "valid exclude globs",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "zip"}},
+					Exclude: []string{"*.log", "docs/drafts"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "exclude glob inside the app",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "zip"}},
+					Exclude: []string{"MyApp.app/Contents/Resources/debug"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "inside the signed .app",
+		},
+		{
+			name: "malformed exclude glob",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "zip"}},
+					Exclude: []string{"[abc"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid archive.exclude entry",
+		},
+		{
+			name: "two dmgs with distinct names",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{
+						{Type: "dmg", Background: "background.png"},
+						{Type: "dmg", NameTemplate: "{{.Name}}-{{.Version}}-plain"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "two dmgs with the same name",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "dmg"}, {Type: "dmg", Background: "background.png"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "more than one dmg",
+		},
+		{
+			name: "malformed name template",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "zip", NameTemplate: "{{.Name"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid name_template",
+		},
+		{
+			name: "background on zip",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "zip", Background: "background.png"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "background is only supported for dmg",
+		},
+		{
+			name: "name template on app",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "app", NameTemplate: "{{.Name}}"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "name_template is not supported for app",
+		},
+		{
+			name: "zip named like the dSYM package",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "zip", NameTemplate: "{{.Name}}-{{.Version}}.dSYM"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "must not end in .dSYM",
+		},
+		{
+			name: "unknown sbom format",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats:    config.ArchiveFormats{{Type: "zip"}},
+					SBOM:       true,
+					SBOMFormat: "swid",
+				},
+			},
+			wantErr: true,
+			errMsg:  `invalid archive.sbom_format "swid": must be one of cyclonedx, spdx`,
+		},
+		{
+			name: "missing dmg license",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "dmg"}},
+					DMG:     config.DMGConfig{License: "missing-license.rtf"},
+				},
+			},
+			wantErr: true,
+			errMsg:  `archive.dmg.license "missing-license.rtf" not found`,
+		},
+		{
+			name: "dmg license is a directory",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "dmg"}},
+					DMG:     config.DMGConfig{License: "."},
+				},
+			},
+			wantErr: true,
+			errMsg:  `archive.dmg.license "." is not a regular file`,
+		},
 	}
 
 	for _, tt := range tests {
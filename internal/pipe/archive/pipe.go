@@ -2,11 +2,14 @@ package archive
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/macreleaser/macreleaser/pkg/archive"
+	"github.com/macreleaser/macreleaser/pkg/command"
 	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/validate"
 )
 
 // Pipe packages the built .app into the configured archive formats (zip, dmg).
@@ -14,7 +17,13 @@ type Pipe struct{}
 
 func (Pipe) String() string { return "packaging archives" }
 
+func (Pipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (Pipe) Run(ctx *context.Context) error {
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
 	if ctx.Artifacts.AppPath == "" {
 		return fmt.Errorf("no .app found to package — ensure the build step completed successfully")
 	}
@@ -22,18 +31,50 @@ func (Pipe) Run(ctx *context.Context) error {
 	cfg := ctx.Config
 	outputDir := ctx.Artifacts.BuildOutputDir
 
-	// Derive app name without extension for package naming
-	// Replace spaces with hyphens for safe filenames (GitHub converts spaces to dots in asset names)
 	appBase := filepath.Base(ctx.Artifacts.AppPath)
-	appName := strings.ReplaceAll(strings.TrimSuffix(appBase, ".app"), " ", "-")
+	appName := packageName(ctx.Artifacts.AppPath)
+
+	// zip and dmg package a staging copy of the .app, alongside any extra
+	// files, with Finder litter and excluded entries pruned
+	stagingDir := ""
+	if validate.ContainsAny(cfg.Archive.Formats.Types(), "zip", "dmg") {
+		extraFiles, err := archive.ResolveExtraFiles(cfg.Archive.ExtraFiles)
+		if err != nil {
+			return err
+		}
+		stagingDir = filepath.Join(outputDir, appName+"-staging")
+		if len(extraFiles) > 0 {
+			ctx.Logger.Infof("Staging %d extra file(s) alongside %s", len(extraFiles), appBase)
+		}
+		if err := archive.Stage(ctx.StdCtx, ctx.Artifacts.AppPath, stagingDir, extraFiles); err != nil {
+			return fmt.Errorf("staging failed: %w", err)
+		}
+		defer func() {
+			if err := os.RemoveAll(stagingDir); err != nil {
+				ctx.Logger.Warnf("Failed to remove staging directory %s: %v", stagingDir, err)
+			}
+		}()
+
+		removed, err := archive.RemoveExcluded(stagingDir, cfg.Archive.Exclude)
+		if err != nil {
+			return fmt.Errorf("staging failed: %w", err)
+		}
+		for _, rel := range removed {
+			ctx.Logger.Debugf("Excluded from packages: %s", rel)
+		}
+	}
 
 	for _, format := range cfg.Archive.Formats {
-		switch format {
+		switch format.Type {
 		case "zip":
-			outputPath := filepath.Join(outputDir, fmt.Sprintf("%s-%s.zip", appName, ctx.Version))
+			baseName, err := renderPackageName(ctx, format.NameTemplate, appName)
+			if err != nil {
+				return err
+			}
+			outputPath := filepath.Join(outputDir, baseName+".zip")
 			ctx.Logger.Infof("Creating ZIP: %s", outputPath)
 
-			if err := archive.CreateZip(ctx.Artifacts.AppPath, outputPath); err != nil {
+			if err := createZip(ctx, cfg.Archive.Reproducible, stagingDir, outputPath); err != nil {
 				return fmt.Errorf("ZIP packaging failed: %w", err)
 			}
 
@@ -41,13 +82,23 @@ func (Pipe) Run(ctx *context.Context) error {
 			ctx.Logger.Infof("ZIP created: %s", outputPath)
 
 		case "dmg":
-			outputPath := filepath.Join(outputDir, fmt.Sprintf("%s-%s.dmg", appName, ctx.Version))
+			baseName, err := renderPackageName(ctx, format.NameTemplate, appName)
+			if err != nil {
+				return err
+			}
+			outputPath := filepath.Join(outputDir, baseName+".dmg")
 			volumeName := fmt.Sprintf("%s %s", appName, ctx.Version)
 			ctx.Logger.Infof("Creating DMG: %s", outputPath)
 
-			if err := archive.CreateDMG(ctx.Artifacts.AppPath, outputPath, volumeName); err != nil {
+			if err := archive.CreateDMG(ctx.StdCtx, stagingDir, outputPath, volumeName); err != nil {
 				return fmt.Errorf("DMG packaging failed: %w", err)
 			}
+			if license := cfg.Archive.DMG.License; license != "" {
+				ctx.Logger.Infof("Adding license agreement from %s", license)
+				if err := archive.AddDMGLicense(ctx.StdCtx, outputPath, license); err != nil {
+					return fmt.Errorf("DMG packaging failed: %w", err)
+				}
+			}
 
 			ctx.Artifacts.Packages = append(ctx.Artifacts.Packages, outputPath)
 			ctx.Logger.Infof("DMG created: %s", outputPath)
@@ -59,5 +110,98 @@ func (Pipe) Run(ctx *context.Context) error {
 		}
 	}
 
+	if cfg.Archive.IncludeDSYM {
+		if err := packageDSYMs(ctx, appName); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Archive.SBOM {
+		if err := packageSBOM(ctx, appName); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// createZip packages the staging directory with ditto, or with Go's
+// archive/zip when byte-identical output across rebuilds is requested.
+func createZip(ctx *context.Context, reproducible bool, stagingDir, outputPath string) error {
+	if !reproducible {
+		return archive.CreateZipFromDir(ctx.StdCtx, stagingDir, outputPath)
+	}
+	modTime, err := archive.SourceDateEpoch()
+	if err != nil {
+		return err
+	}
+	return archive.CreateReproducibleZip(stagingDir, outputPath, modTime)
+}
+
+// packageDSYMs zips the .xcarchive's debug symbols and appends the ZIP to
+// the release packages.
+func packageDSYMs(ctx *context.Context, appName string) error {
+	if ctx.Artifacts.ArchivePath == "" {
+		return fmt.Errorf("no .xcarchive found for dSYM packaging — ensure the build step completed successfully")
+	}
+
+	dsyms, err := archive.FindDSYMs(ctx.Artifacts.ArchivePath)
+	if err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(ctx.Artifacts.BuildOutputDir, fmt.Sprintf("%s-%s%s", appName, ctx.Version, archive.DSYMSuffix))
+	ctx.Logger.Infof("Creating dSYM ZIP: %s", outputPath)
+	if err := archive.CreateDSYMZip(dsyms, outputPath); err != nil {
+		return fmt.Errorf("dSYM packaging failed: %w", err)
+	}
+
+	ctx.Artifacts.Packages = append(ctx.Artifacts.Packages, outputPath)
+	ctx.Logger.Infof("dSYM ZIP created: %s", outputPath)
+	return nil
+}
+
+// packageSBOM generates a software bill of materials for the .app and
+// appends it to the release packages. syft is an optional dependency, so
+// the SBOM is skipped with a notice when it is not installed.
+func packageSBOM(ctx *context.Context, appName string) error {
+	format := ctx.Config.Archive.SBOMFormat
+	outputPath := filepath.Join(ctx.Artifacts.BuildOutputDir, fmt.Sprintf("%s-%s%s", appName, ctx.Version, archive.SBOMSuffix(format)))
+	ctx.Logger.Infof("Creating SBOM: %s", outputPath)
+
+	err := archive.CreateSBOM(ctx.StdCtx, ctx.Artifacts.AppPath, outputPath, format)
+	if command.IsNotFound(err) {
+		ctx.Logger.Warn("syft not found — skipping the SBOM; install it with: brew install syft")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.Artifacts.Packages = append(ctx.Artifacts.Packages, outputPath)
+	ctx.Logger.Infof("SBOM created: %s", outputPath)
+	return nil
+}
+
+// renderPackageName renders a zip or dmg file name, without extension. A
+// snapshot package always carries the short commit: it is appended unless
+// the name already contains it, as the default name does through the
+// snapshot version.
+func renderPackageName(ctx *context.Context, nameTemplate, appName string) (string, error) {
+	short := ctx.Git.ShortCommit
+	name, err := archive.RenderName(nameTemplate, appName, ctx.Version, short)
+	if err != nil {
+		return "", err
+	}
+	if ctx.Snapshot && short != "" && !strings.Contains(name, short) {
+		name += "-" + short
+	}
+	return name, nil
+}
+
+// packageName derives the app name used in package filenames from the .app
+// path. Spaces are replaced with hyphens for safe filenames (GitHub converts
+// spaces to dots in asset names).
+func packageName(appPath string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(filepath.Base(appPath), ".app"), " ", "-")
+}
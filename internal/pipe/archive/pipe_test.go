@@ -1,12 +1,19 @@
 package archive
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/macreleaser/macreleaser/internal/pipe/release"
+	"github.com/macreleaser/macreleaser/pkg/command"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macCtx "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/github"
 	"github.com/sirupsen/logrus"
 )
 
@@ -24,7 +31,7 @@ func TestPipeNoApp(t *testing.T) {
 
 	cfg := &config.Config{
 		Archive: config.ArchiveConfig{
-			Formats: []string{"zip"},
+			Formats: config.ArchiveFormats{{Type: "zip"}},
 		},
 	}
 	c := macCtx.NewContext(context.Background(), cfg, logger)
@@ -38,3 +45,206 @@ func TestPipeNoApp(t *testing.T) {
 		t.Errorf("error = %v, want containing 'no .app found to package'", err)
 	}
 }
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		appPath string
+		want    string
+	}{
+		{appPath: "dist/MyApp.app", want: "MyApp"},
+		{appPath: "dist/My App.app", want: "My-App"},
+		{appPath: "dist/My Big App.app", want: "My-Big-App"},
+	}
+
+	for _, tt := range tests {
+		if got := packageName(tt.appPath); got != tt.want {
+			t.Errorf("packageName(%q) = %q, want %q", tt.appPath, got, tt.want)
+		}
+	}
+}
+
+func TestRenderPackageNameSnapshot(t *testing.T) {
+	tests := []struct {
+		name         string
+		snapshot     bool
+		version      string
+		nameTemplate string
+		want         string
+	}{
+		{name: "release", version: "v1.2.0", want: "MyApp-v1.2.0"},
+		{name: "release custom template", version: "v1.2.0", nameTemplate: "{{.Name}}-nightly", want: "MyApp-nightly"},
+		{name: "snapshot version carries the commit", snapshot: true, version: "v1.2.0-SNAPSHOT-abc1234", want: "MyApp-v1.2.0-SNAPSHOT-abc1234"},
+		{name: "snapshot custom template", snapshot: true, version: "v1.2.0-SNAPSHOT-abc1234", nameTemplate: "{{.Name}}-nightly", want: "MyApp-nightly-abc1234"},
+		{name: "snapshot template with commit", snapshot: true, version: "v1.2.0-SNAPSHOT-abc1234", nameTemplate: "{{.Name}}-{{.ShortCommit}}-test", want: "MyApp-abc1234-test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := macCtx.NewContext(context.Background(), &config.Config{}, logrus.New())
+			c.Snapshot = tt.snapshot
+			c.Version = tt.version
+			c.Git.ShortCommit = "abc1234"
+
+			got, err := renderPackageName(c, tt.nameTemplate, "MyApp")
+			if err != nil {
+				t.Fatalf("renderPackageName() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("renderPackageName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipeIncludeDSYM(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Fake .xcarchive with an app and its dSYM
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "MyApp.xcarchive")
+	dwarfDir := filepath.Join(archivePath, "dSYMs", "MyApp.app.dSYM", "Contents", "Resources", "DWARF")
+	if err := os.MkdirAll(dwarfDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dwarfDir, "MyApp"), []byte("dwarf"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		Project: config.ProjectConfig{Name: "MyApp"},
+		Archive: config.ArchiveConfig{
+			Formats:     config.ArchiveFormats{{Type: "app"}},
+			IncludeDSYM: true,
+		},
+		Release: config.ReleaseConfig{
+			GitHub: config.GitHubConfig{Owner: "testowner", Repo: "testrepo"},
+		},
+	}
+	c := macCtx.NewContext(context.Background(), cfg, logger)
+	c.Version = "v1.2.3"
+	c.Artifacts.BuildOutputDir = dir
+	c.Artifacts.ArchivePath = archivePath
+	c.Artifacts.AppPath = filepath.Join(dir, "MyApp.app")
+
+	if err := (Pipe{}).Run(c); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	dsymZip := filepath.Join(dir, "MyApp-v1.2.3.dSYM.zip")
+	if len(c.Artifacts.Packages) != 2 || c.Artifacts.Packages[1] != dsymZip {
+		t.Fatalf("Packages = %v, want app followed by %s", c.Artifacts.Packages, dsymZip)
+	}
+
+	r, err := zip.OpenReader(dsymZip)
+	if err != nil {
+		t.Fatalf("failed to open dSYM ZIP: %v", err)
+	}
+	defer r.Close()
+	found := false
+	for _, f := range r.File {
+		if f.Name == "MyApp.app.dSYM/Contents/Resources/DWARF/MyApp" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("dSYM ZIP missing MyApp.app.dSYM/Contents/Resources/DWARF/MyApp")
+	}
+
+	// The dSYM ZIP is uploaded with the release like any other package
+	if err := os.MkdirAll(c.Artifacts.AppPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	mock := github.NewMockClient()
+	c.GitHubClient = mock
+	if err := (release.Pipe{}).Run(c); err != nil {
+		t.Fatalf("release Run() unexpected error: %v", err)
+	}
+	if len(mock.UploadedAssets) != 1 || mock.UploadedAssets[0] != dsymZip {
+		t.Errorf("UploadedAssets = %v, want [%s]", mock.UploadedAssets, dsymZip)
+	}
+}
+
+func TestPipeIncludeDSYMMissing(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	dir := t.TempDir()
+	cfg := &config.Config{
+		Archive: config.ArchiveConfig{
+			Formats:     config.ArchiveFormats{{Type: "app"}},
+			IncludeDSYM: true,
+		},
+	}
+	c := macCtx.NewContext(context.Background(), cfg, logger)
+	c.Artifacts.BuildOutputDir = dir
+	c.Artifacts.ArchivePath = filepath.Join(dir, "MyApp.xcarchive")
+	c.Artifacts.AppPath = filepath.Join(dir, "MyApp.app")
+
+	err := Pipe{}.Run(c)
+	if err == nil || !strings.Contains(err.Error(), "no .dSYM found") {
+		t.Errorf("Run() error = %v, want error containing %q", err, "no .dSYM found")
+	}
+}
+
+func TestPipeSBOM(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		syftErr    error
+		wantSBOM   string // empty when the SBOM is skipped
+		wantSyftOp string
+	}{
+		{name: "cyclonedx by default", wantSBOM: "MyApp-v1.2.3.cdx.json", wantSyftOp: "cyclonedx-json="},
+		{name: "spdx", format: "spdx", wantSBOM: "MyApp-v1.2.3.spdx.json", wantSyftOp: "spdx-json="},
+		{name: "syft not installed", syftErr: &command.NotFoundError{Name: "syft"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			var buf bytes.Buffer
+			logger.SetOutput(&buf)
+
+			dir := t.TempDir()
+			cfg := &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats:    config.ArchiveFormats{{Type: "app"}},
+					SBOM:       true,
+					SBOMFormat: tt.format,
+				},
+			}
+			c := macCtx.NewContext(context.Background(), cfg, logger)
+			fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+				return "", tt.syftErr
+			}}
+			c.StdCtx = command.WithRunner(c.StdCtx, fake)
+			c.Version = "v1.2.3"
+			c.Artifacts.BuildOutputDir = dir
+			c.Artifacts.AppPath = filepath.Join(dir, "MyApp.app")
+
+			if err := (Pipe{}).Run(c); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			if tt.wantSBOM == "" {
+				if len(c.Artifacts.Packages) != 1 {
+					t.Errorf("Packages = %v, want only the app", c.Artifacts.Packages)
+				}
+				if !strings.Contains(buf.String(), "syft not found") {
+					t.Errorf("expected a notice that syft is missing, got: %s", buf.String())
+				}
+				return
+			}
+
+			sbomPath := filepath.Join(dir, tt.wantSBOM)
+			if len(c.Artifacts.Packages) != 2 || c.Artifacts.Packages[1] != sbomPath {
+				t.Fatalf("Packages = %v, want app followed by %s", c.Artifacts.Packages, sbomPath)
+			}
+			calls := fake.Commands()
+			if len(calls) != 1 || !strings.HasPrefix(calls[0], "syft scan dir:"+c.Artifacts.AppPath) || !strings.Contains(calls[0], tt.wantSyftOp+sbomPath) {
+				t.Errorf("commands = %v, want syft writing %s%s", calls, tt.wantSyftOp, sbomPath)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package build
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/macreleaser/macreleaser/pkg/build"
 	"github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/env"
 	"github.com/macreleaser/macreleaser/pkg/validate"
@@ -22,6 +27,48 @@ func (CheckPipe) Run(ctx *context.Context) error {
 		return err
 	}
 
+	if cfg.VersionFile != "" {
+		if err := env.CheckResolved(cfg.VersionFile, "build.version_file"); err != nil {
+			return err
+		}
+		if !filepath.IsLocal(cfg.VersionFile) {
+			return fmt.Errorf("build.version_file contains a path traversal or absolute path: %q", cfg.VersionFile)
+		}
+		if _, err := build.DefaultVersionKey(cfg.VersionFile); err != nil {
+			return err
+		}
+		if _, err := os.Stat(cfg.VersionFile); err != nil {
+			return fmt.Errorf("build.version_file %q not found: %w", cfg.VersionFile, err)
+		}
+	} else if cfg.VersionKey != "" || cfg.CommitVersion {
+		return fmt.Errorf("build.version_key and build.commit_version require build.version_file")
+	}
+
+	if cfg.XcodebuildPath != "" {
+		if err := env.CheckResolved(cfg.XcodebuildPath, "build.xcodebuild_path"); err != nil {
+			return err
+		}
+		if err := validate.Executable(cfg.XcodebuildPath, "build.xcodebuild_path"); err != nil {
+			return err
+		}
+	}
+
+	if cfg.ExportOptions != "" {
+		if err := env.CheckResolved(cfg.ExportOptions, "build.export_options"); err != nil {
+			return err
+		}
+		if filepath.Ext(cfg.ExportOptions) != ".plist" {
+			return fmt.Errorf("build.export_options must be an ExportOptions .plist file, got %q", cfg.ExportOptions)
+		}
+		if _, err := os.Stat(cfg.ExportOptions); err != nil {
+			return fmt.Errorf("build.export_options %q not found: %w", cfg.ExportOptions, err)
+		}
+	}
+
+	if err := validate.AllOneOf(cfg.Architectures, build.ValidArchitectures, "build.architectures"); err != nil {
+		return err
+	}
+
 	ctx.Logger.Debug("Build configuration validated successfully")
 	return nil
 }
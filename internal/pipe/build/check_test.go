@@ -2,6 +2,8 @@ package build
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -29,6 +31,71 @@ func TestCheckPipe(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "universal architectures",
+			config: &config.Config{
+				Build: config.BuildConfig{
+					Configuration: "Release",
+					Architectures: []string{"arm64", "x86_64"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown architecture",
+			config: &config.Config{
+				Build: config.BuildConfig{
+					Configuration: "Release",
+					Architectures: []string{"arm64", "i386"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "build.architectures",
+		},
+		{
+			name: "version file outside project",
+			config: &config.Config{
+				Build: config.BuildConfig{
+					Configuration: "Release",
+					VersionFile:   "../Info.plist",
+				},
+			},
+			wantErr: true,
+			errMsg:  "build.version_file contains a path traversal",
+		},
+		{
+			name: "unsupported version file type",
+			config: &config.Config{
+				Build: config.BuildConfig{
+					Configuration: "Release",
+					VersionFile:   "VERSION.txt",
+				},
+			},
+			wantErr: true,
+			errMsg:  "must be an .plist or .xcconfig file",
+		},
+		{
+			name: "missing version file",
+			config: &config.Config{
+				Build: config.BuildConfig{
+					Configuration: "Release",
+					VersionFile:   "NoSuch/Info.plist",
+				},
+			},
+			wantErr: true,
+			errMsg:  "build.version_file \"NoSuch/Info.plist\" not found",
+		},
+		{
+			name: "commit_version without version file",
+			config: &config.Config{
+				Build: config.BuildConfig{
+					Configuration: "Release",
+					CommitVersion: true,
+				},
+			},
+			wantErr: true,
+			errMsg:  "require build.version_file",
+		},
 		{
 			name: "missing configuration",
 			config: &config.Config{
@@ -60,6 +127,65 @@ func TestCheckPipe(t *testing.T) {
 	}
 }
 
+func TestCheckPipeXcodebuildPath(t *testing.T) {
+	logger := logrus.New()
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "xcodebuild")
+	if err := os.WriteFile(executable, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := macCtx.NewContext(context.Background(), &config.Config{
+		Build: config.BuildConfig{Configuration: "Release", XcodebuildPath: executable},
+	}, logger)
+	if err := (CheckPipe{}).Run(ctx); err != nil {
+		t.Errorf("Run() unexpected error: %v", err)
+	}
+
+	ctx = macCtx.NewContext(context.Background(), &config.Config{
+		Build: config.BuildConfig{Configuration: "Release", XcodebuildPath: filepath.Join(dir, "missing")},
+	}, logger)
+	if err := (CheckPipe{}).Run(ctx); err == nil || !strings.Contains(err.Error(), "invalid build.xcodebuild_path") {
+		t.Errorf("Run() error = %v, want invalid build.xcodebuild_path error", err)
+	}
+}
+
+func TestCheckPipeExportOptions(t *testing.T) {
+	logger := logrus.New()
+	plist := filepath.Join(t.TempDir(), "ExportOptions.plist")
+	if err := os.WriteFile(plist, []byte("<plist/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		exportOptions string
+		errMsg        string
+	}{
+		{name: "existing plist", exportOptions: plist},
+		{name: "missing plist", exportOptions: filepath.Join(filepath.Dir(plist), "Missing.plist"), errMsg: "not found"},
+		{name: "not a plist", exportOptions: "ExportOptions.json", errMsg: "must be an ExportOptions .plist file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := macCtx.NewContext(context.Background(), &config.Config{
+				Build: config.BuildConfig{Configuration: "Release", ExportOptions: tt.exportOptions},
+			}, logger)
+			err := CheckPipe{}.Run(ctx)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Run() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
 func TestCheckPipeString(t *testing.T) {
 	p := CheckPipe{}
 	expected := "validating build configuration"
@@ -0,0 +1,134 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/macreleaser/macreleaser/pkg/archive"
+	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/retry"
+	"github.com/macreleaser/macreleaser/pkg/xattr"
+)
+
+// copyTree copies a directory tree; replaced in tests.
+var copyTree = copyDir
+
+// sleep waits between copy attempts; replaced in tests.
+var sleep = retry.Sleep
+
+// copyDirWithRetry copies src to dst, retrying under the top-level retry
+// policy when the copy fails with a transient filesystem error, as network
+// filesystems occasionally report. A partial copy is removed before each
+// new attempt.
+func copyDirWithRetry(ctx *context.Context, src, dst string) error {
+	rc := ctx.Config.Retry
+	policy, err := retry.Parse(rc.MaxAttempts, rc.BaseDelay, rc.MaxDelay)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := copyTree(src, dst)
+		if err == nil || attempt >= policy.MaxAttempts || !transient(err) {
+			return err
+		}
+
+		delay := policy.Delay(attempt)
+		ctx.Logger.Warnf("Copying %s failed (attempt %d of %d), retrying in %s: %v", src, attempt, policy.MaxAttempts, delay, err)
+		if err := os.RemoveAll(dst); err != nil {
+			return fmt.Errorf("failed to remove partial copy %s: %w", dst, err)
+		}
+		if err := sleep(ctx.StdCtx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// transient returns true if a copy failure is worth retrying.
+func transient(err error) bool {
+	return errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EINTR)
+}
+
+// copyDir recursively copies the directory src to dst, which must not
+// exist. Symlinks are recreated rather than followed, and files and
+// directories keep their permission bits and extended attributes, which
+// include resource forks and the signature Xcode stores for code that is
+// not Mach-O, such as a script. ACLs are not copied.
+func copyDir(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", src)
+	}
+
+	// Create the directory writable so its contents can be copied in, and
+	// apply its real mode once they are
+	if err := os.Mkdir(dst, 0700); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := copyEntry(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name()), entry); err != nil {
+			return err
+		}
+	}
+
+	if err := xattr.Copy(src, dst); err != nil {
+		return err
+	}
+	return os.Chmod(dst, info.Mode().Perm())
+}
+
+// copyEntry copies a single directory entry from src to dst.
+func copyEntry(src, dst string, entry os.DirEntry) error {
+	switch mode := entry.Type(); {
+	case mode.IsDir():
+		return copyDir(src, dst)
+	case mode&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		if err := os.Symlink(target, dst); err != nil {
+			return err
+		}
+		return xattr.Copy(src, dst)
+	case mode.IsRegular():
+		if err := archive.CopyFile(src, dst); err != nil {
+			return err
+		}
+		return copyFileXattrs(src, dst)
+	default:
+		return fmt.Errorf("cannot copy %s: unsupported file type %s", src, mode)
+	}
+}
+
+// copyFileXattrs copies the extended attributes of the file src to its copy
+// dst. Setting them needs write access, so a read-only copy is made
+// writable by its owner meanwhile.
+func copyFileXattrs(src, dst string) error {
+	info, err := os.Lstat(dst)
+	if err != nil {
+		return err
+	}
+	perm := info.Mode().Perm()
+	if perm&0200 != 0 {
+		return xattr.Copy(src, dst)
+	}
+	if err := os.Chmod(dst, perm|0200); err != nil {
+		return err
+	}
+	if err := xattr.Copy(src, dst); err != nil {
+		return err
+	}
+	return os.Chmod(dst, perm)
+}
@@ -0,0 +1,299 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macCtx "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/xattr"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+func TestCopyDirNestedSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "MyApp.app")
+
+	// A framework bundle in the usual versioned layout, with symlinks at
+	// several levels
+	framework := filepath.Join(src, "Contents", "Frameworks", "Kit.framework")
+	if err := os.MkdirAll(filepath.Join(framework, "Versions", "A", "Resources"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(framework, "Versions", "A", "Kit"), []byte("binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		filepath.Join(framework, "Versions", "Current"): "A",
+		filepath.Join(framework, "Kit"):                 "Versions/Current/Kit",
+		filepath.Join(framework, "Resources"):           "Versions/Current/Resources",
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst := filepath.Join(dir, "out", "MyApp.app")
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := copyDir(src, dst); err != nil {
+		t.Fatalf("copyDir() error = %v", err)
+	}
+
+	for link, want := range links {
+		copied := filepath.Join(dst, strings.TrimPrefix(link, src))
+		info, err := os.Lstat(copied)
+		if err != nil {
+			t.Fatalf("copied symlink missing: %v", err)
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("%s was copied as %s, want a symlink", copied, info.Mode())
+			continue
+		}
+		if got, _ := os.Readlink(copied); got != want {
+			t.Errorf("%s points to %q, want %q", copied, got, want)
+		}
+	}
+
+	// The symlinks resolve inside the copy
+	data, err := os.ReadFile(filepath.Join(dst, "Contents", "Frameworks", "Kit.framework", "Kit"))
+	if err != nil {
+		t.Fatalf("reading through copied symlinks: %v", err)
+	}
+	if string(data) != "binary" {
+		t.Errorf("contents = %q, want %q", data, "binary")
+	}
+}
+
+func TestCopyDirKeepsExtendedAttributes(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "MyApp.app")
+	resources := filepath.Join(src, "Contents", "Resources")
+	if err := os.MkdirAll(resources, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(resources, "run.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	readOnly := filepath.Join(resources, "data.plist")
+	if err := os.WriteFile(readOnly, []byte("<plist/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Linux only allows attributes in the user namespace
+	attrs := map[string]string{script: "signature", readOnly: "finder", resources: "folder"}
+	for path, value := range attrs {
+		if err := unix.Setxattr(path, "user.test", []byte(value), 0); err != nil {
+			t.Skipf("extended attributes not supported here: %v", err)
+		}
+	}
+	if err := os.Chmod(readOnly, 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "Copy.app")
+	if err := copyDir(src, dst); err != nil {
+		t.Fatalf("copyDir() error = %v", err)
+	}
+
+	for path, want := range attrs {
+		copied := filepath.Join(dst, strings.TrimPrefix(path, src))
+		got, err := xattr.Get(copied, "user.test")
+		if err != nil {
+			t.Errorf("attribute of %s not copied: %v", copied, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("attribute of %s = %q, want %q", copied, got, want)
+		}
+	}
+	info, err := os.Stat(filepath.Join(dst, "Contents", "Resources", "data.plist"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0444 {
+		t.Errorf("read-only file mode = %v, want 0444", info.Mode().Perm())
+	}
+}
+
+func TestCopyDirPreservesPermissions(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "MyApp.app")
+	macOS := filepath.Join(src, "Contents", "MacOS")
+	if err := os.MkdirAll(macOS, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]os.FileMode{
+		filepath.Join("Contents", "MacOS", "MyApp"): 0755,
+		filepath.Join("Contents", "Info.plist"):     0644,
+		filepath.Join("Contents", "secret.txt"):     0600,
+		filepath.Join("Contents", "readonly.txt"):   0444,
+	}
+	for name, mode := range files {
+		path := filepath.Join(src, name)
+		if err := os.WriteFile(path, []byte(name), mode); err != nil {
+			t.Fatal(err)
+		}
+		// Apply the mode regardless of the umask
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(macOS, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "MyApp-copy.app")
+	if err := copyDir(src, dst); err != nil {
+		t.Fatalf("copyDir() error = %v", err)
+	}
+
+	for name, want := range files {
+		info, err := os.Stat(filepath.Join(dst, name))
+		if err != nil {
+			t.Fatalf("copied file missing: %v", err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s mode = %v, want %v", name, got, want)
+		}
+	}
+	info, err := os.Stat(filepath.Join(dst, "Contents", "MacOS"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0750 {
+		t.Errorf("Contents/MacOS mode = %v, want %v", got, os.FileMode(0750))
+	}
+}
+
+func TestCopyDirExistingDestination(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "MyApp.app")
+	dst := filepath.Join(dir, "out", "MyApp.app")
+	for _, d := range []string{src, dst} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := copyDir(src, dst)
+	if err == nil {
+		t.Fatal("expected error for an existing destination")
+	}
+	if !strings.Contains(err.Error(), dst) {
+		t.Errorf("error = %v, want it to name %s", err, dst)
+	}
+}
+
+func TestCopyDirWithRetry(t *testing.T) {
+	eagain := &os.PathError{Op: "write", Path: "MyApp.app/Contents/MacOS/MyApp", Err: syscall.EAGAIN}
+
+	tests := []struct {
+		name      string
+		failures  []error // returned by successive copy attempts before succeeding
+		retry     config.RetryConfig
+		wantCalls int
+		errMsg    string
+	}{
+		{
+			name:      "succeeds first time",
+			wantCalls: 1,
+		},
+		{
+			name:      "retries a transient error",
+			failures:  []error{eagain, eagain},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after max attempts",
+			failures:  []error{eagain, eagain},
+			retry:     config.RetryConfig{MaxAttempts: 2},
+			wantCalls: 2,
+			errMsg:    "resource temporarily unavailable",
+		},
+		{
+			name:      "does not retry other errors",
+			failures:  []error{&os.PathError{Op: "open", Path: "MyApp.app", Err: syscall.EACCES}},
+			wantCalls: 1,
+			errMsg:    "permission denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			dst := filepath.Join(dir, "MyApp.app")
+
+			calls := 0
+			origCopy, origSleep := copyTree, sleep
+			defer func() { copyTree, sleep = origCopy, origSleep }()
+			copyTree = func(src, dst string) error {
+				calls++
+				// Leave a partial copy behind, which must be removed before
+				// the next attempt
+				if err := os.Mkdir(dst, 0755); err != nil {
+					return err
+				}
+				if calls <= len(tt.failures) {
+					return tt.failures[calls-1]
+				}
+				return nil
+			}
+			var delays []time.Duration
+			sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			c := macCtx.NewContext(context.Background(), &config.Config{Retry: tt.retry}, logrus.New())
+			err := copyDirWithRetry(c, "MyApp.app", dst)
+
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("copyDirWithRetry() error = %v, want containing %q", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Errorf("copyDirWithRetry() unexpected error: %v", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("copy attempts = %d, want %d", calls, tt.wantCalls)
+			}
+			if len(delays) != calls-1 {
+				t.Errorf("slept %d times, want %d", len(delays), calls-1)
+			}
+			for i, d := range delays {
+				if want := time.Second << i; d != want {
+					t.Errorf("delay %d = %s, want %s", i+1, d, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCopyDirWithRetryCancelled(t *testing.T) {
+	origCopy := copyTree
+	defer func() { copyTree = origCopy }()
+	copyTree = func(src, dst string) error {
+		return &os.PathError{Op: "write", Path: src, Err: syscall.EAGAIN}
+	}
+
+	stdCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := macCtx.NewContext(stdCtx, &config.Config{}, logrus.New())
+
+	err := copyDirWithRetry(c, "MyApp.app", filepath.Join(t.TempDir(), "MyApp.app"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("copyDirWithRetry() error = %v, want context.Canceled", err)
+	}
+}
@@ -3,12 +3,12 @@ package build
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/macreleaser/macreleaser/pkg/build"
 	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/git"
 )
 
 // Pipe executes the Xcode build, producing an .xcarchive and extracting the .app.
@@ -38,6 +38,16 @@ func (Pipe) Run(ctx *context.Context) error {
 		return err
 	}
 
+	// A mistyped scheme would otherwise only surface after xcodebuild has
+	// resolved packages and started the archive
+	schemes, err := build.ListSchemes(ctx.StdCtx, cfg.Build.XcodebuildPath, workspace, wsType)
+	if err != nil {
+		return err
+	}
+	if err := build.CheckScheme(cfg.Project.Scheme, schemes); err != nil {
+		return err
+	}
+
 	// Build archive path
 	archivePath := filepath.Join(outputDir, cfg.Project.Scheme+".xcarchive")
 
@@ -48,6 +58,11 @@ func (Pipe) Run(ctx *context.Context) error {
 	marketingVersion := strings.TrimPrefix(ctx.Version, "v")
 	buildNumber := fmt.Sprintf("%d", ctx.Git.CommitCount)
 
+	versionChanged, err := writeVersionFile(ctx, marketingVersion)
+	if err != nil {
+		return err
+	}
+
 	// Run xcodebuild
 	args := build.XcodebuildArgs{
 		Scheme:        cfg.Project.Scheme,
@@ -57,9 +72,11 @@ func (Pipe) Run(ctx *context.Context) error {
 		ArchivePath:   archivePath,
 		Version:       marketingVersion,
 		BuildNumber:   buildNumber,
+
+		XcodebuildPath: cfg.Build.XcodebuildPath,
 	}
 
-	output, err := build.RunXcodebuild(args)
+	output, err := build.RunXcodebuild(ctx.StdCtx, args)
 	if err != nil {
 		ctx.Logger.Debug(output)
 		return fmt.Errorf("build failed: %w", err)
@@ -68,15 +85,103 @@ func (Pipe) Run(ctx *context.Context) error {
 	ctx.Logger.Debug(output)
 	ctx.Artifacts.ArchivePath = archivePath
 
-	// Extract .app from .xcarchive
-	if err := extractApp(ctx, archivePath, outputDir); err != nil {
+	// Export the .app with the configured export options, or take it
+	// from the .xcarchive as built
+	if cfg.Build.ExportOptions != "" {
+		if err := exportApp(ctx, archivePath, outputDir); err != nil {
+			return err
+		}
+	} else if err := extractApp(ctx, archivePath, outputDir); err != nil {
+		return err
+	}
+
+	if err := verifyArchitectures(ctx); err != nil {
 		return err
 	}
 
+	// Commit the version only once it has built, so a failed build leaves
+	// no version bump behind
+	if versionChanged {
+		if err := commitVersionFile(ctx, marketingVersion); err != nil {
+			return err
+		}
+	}
+
 	ctx.Logger.Infof("Build completed: %s", ctx.Artifacts.AppPath)
 	return nil
 }
 
+// writeVersionFile updates build.version_file with version when configured,
+// and reports whether the file changed.
+func writeVersionFile(ctx *context.Context, version string) (bool, error) {
+	cfg := ctx.Config.Build
+	if cfg.VersionFile == "" {
+		return false, nil
+	}
+
+	key := cfg.VersionKey
+	if key == "" {
+		var err error
+		if key, err = build.DefaultVersionKey(cfg.VersionFile); err != nil {
+			return false, err
+		}
+	}
+
+	changed, err := build.SetVersionInFile(cfg.VersionFile, key, version)
+	if err != nil {
+		return false, fmt.Errorf("failed to update version file: %w", err)
+	}
+	if !changed {
+		ctx.Logger.Infof("%s already has %s = %s", cfg.VersionFile, key, version)
+		return false, nil
+	}
+	ctx.Logger.Infof("Set %s = %s in %s", key, version, cfg.VersionFile)
+	return true, nil
+}
+
+// commitVersionFile commits the updated build.version_file when
+// build.commit_version is set and publishing is enabled.
+func commitVersionFile(ctx *context.Context, version string) error {
+	cfg := ctx.Config.Build
+	if !cfg.CommitVersion {
+		return nil
+	}
+	if ctx.SkipPublish {
+		ctx.Logger.Infof("Not committing %s: publishing is skipped", cfg.VersionFile)
+		return nil
+	}
+	if err := git.CommitFiles(ctx.StdCtx, fmt.Sprintf("Bump version to %s", version), cfg.VersionFile); err != nil {
+		return fmt.Errorf("failed to commit version file: %w", err)
+	}
+	ctx.Logger.Infof("Committed %s", cfg.VersionFile)
+	return nil
+}
+
+// verifyArchitectures checks that the built app's main executable contains
+// every architecture listed in build.architectures.
+func verifyArchitectures(ctx *context.Context) error {
+	want := ctx.Config.Build.Architectures
+	if len(want) == 0 {
+		return nil
+	}
+
+	executable, err := build.MainExecutable(ctx.Artifacts.AppPath)
+	if err != nil {
+		return err
+	}
+	got, err := build.RunLipoArchs(ctx.StdCtx, executable)
+	if err != nil {
+		return err
+	}
+
+	if missing := build.MissingArchs(want, got); len(missing) > 0 {
+		return fmt.Errorf("%s is built for %s but build.architectures also requires %s — check ARCHS and ONLY_ACTIVE_ARCH for the %s configuration",
+			filepath.Base(executable), strings.Join(got, ", "), strings.Join(missing, ", "), ctx.Config.Build.Configuration)
+	}
+	ctx.Logger.Infof("Verified architectures: %s", strings.Join(got, ", "))
+	return nil
+}
+
 // resolveWorkspace determines the workspace or project path to use.
 func resolveWorkspace(ctx *context.Context) (string, build.WorkspaceType, error) {
 	configured := ctx.Config.Project.Workspace
@@ -113,13 +218,42 @@ func resolveWorkspace(ctx *context.Context) (string, build.WorkspaceType, error)
 	return detected.Path, detected.Type, nil
 }
 
+// exportApp runs xcodebuild -exportArchive with build.export_options and
+// copies the exported .app to the output directory.
+func exportApp(ctx *context.Context, archivePath, outputDir string) error {
+	exportPath := strings.TrimSuffix(archivePath, ".xcarchive") + "-export"
+	ctx.Logger.Infof("Exporting archive with %s", ctx.Config.Build.ExportOptions)
+
+	output, err := build.RunExportArchive(ctx.StdCtx, build.ExportArgs{
+		ArchivePath:        archivePath,
+		ExportPath:         exportPath,
+		ExportOptionsPlist: ctx.Config.Build.ExportOptions,
+		XcodebuildPath:     ctx.Config.Build.XcodebuildPath,
+	})
+	ctx.Logger.Debug(output)
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(exportPath); err != nil {
+			ctx.Logger.Warnf("Failed to remove export directory %s: %v", exportPath, err)
+		}
+	}()
+
+	return copyApp(ctx, exportPath, "export", outputDir)
+}
+
 // extractApp locates the .app inside the .xcarchive and copies it to the output directory.
 func extractApp(ctx *context.Context, archivePath, outputDir string) error {
-	appsDir := filepath.Join(archivePath, "Products", "Applications")
+	return copyApp(ctx, filepath.Join(archivePath, "Products", "Applications"), ".xcarchive Products/Applications", outputDir)
+}
 
+// copyApp copies the first .app in appsDir, described by source in errors,
+// to the output directory.
+func copyApp(ctx *context.Context, appsDir, source, outputDir string) error {
 	entries, err := os.ReadDir(appsDir)
 	if err != nil {
-		return fmt.Errorf("failed to read .xcarchive Products/Applications: %w", err)
+		return fmt.Errorf("failed to read %s: %w", source, err)
 	}
 
 	var appName string
@@ -146,10 +280,8 @@ func extractApp(ctx *context.Context, archivePath, outputDir string) error {
 		return fmt.Errorf(".app at %s is not a directory — the archive may be corrupted", srcApp)
 	}
 
-	// Copy using cp -R to preserve structure
-	cmd := exec.Command("cp", "-R", srcApp, dstApp)
-	if out, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to copy .app to output directory: %s: %w", string(out), err)
+	if err := copyDirWithRetry(ctx, srcApp, dstApp); err != nil {
+		return fmt.Errorf("failed to copy .app to output directory: %w", err)
 	}
 
 	ctx.Artifacts.AppPath = dstApp
@@ -2,11 +2,13 @@ package build
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/macreleaser/macreleaser/pkg/command"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macCtx "github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/sirupsen/logrus"
@@ -174,6 +176,39 @@ func TestExtractApp(t *testing.T) {
 	}
 }
 
+func TestExtractAppNameWithSpaces(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "My App.xcarchive")
+	contents := filepath.Join(archivePath, "Products", "Applications", "My App.app", "Contents")
+	if err := os.MkdirAll(contents, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(contents, "Info.plist"), []byte("<plist/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir := filepath.Join(dir, "output dir")
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := macCtx.NewContext(context.Background(), &config.Config{}, logger)
+	if err := extractApp(c, archivePath, outputDir); err != nil {
+		t.Fatalf("extractApp() error = %v", err)
+	}
+
+	expectedAppPath := filepath.Join(outputDir, "My App.app")
+	if c.Artifacts.AppPath != expectedAppPath {
+		t.Errorf("AppPath = %q, want %q", c.Artifacts.AppPath, expectedAppPath)
+	}
+	if _, err := os.Stat(filepath.Join(expectedAppPath, "Contents", "Info.plist")); err != nil {
+		t.Errorf("copied .app missing Info.plist: %v", err)
+	}
+}
+
 func TestExtractAppNoApp(t *testing.T) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.DebugLevel)
@@ -219,3 +254,121 @@ func TestExtractAppMissingProductsDir(t *testing.T) {
 		t.Errorf("error = %v, want containing 'Products/Applications'", err)
 	}
 }
+
+func TestExportApp(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "MyApp.xcarchive")
+	exportPath := filepath.Join(dir, "MyApp-export")
+
+	// xcodebuild writes the exported app, along with its logs, to -exportPath
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		if name == "xcodebuild" {
+			if err := os.MkdirAll(filepath.Join(exportPath, "MyApp.app", "Contents"), 0755); err != nil {
+				return "", err
+			}
+			return "** EXPORT SUCCEEDED **", os.WriteFile(filepath.Join(exportPath, "Packaging.log"), nil, 0644)
+		}
+		return "", nil
+	}}
+
+	cfg := &config.Config{Build: config.BuildConfig{ExportOptions: "ExportOptions.plist"}}
+	c := macCtx.NewContext(context.Background(), cfg, logger)
+	c.StdCtx = command.WithRunner(c.StdCtx, fake)
+
+	if err := exportApp(c, archivePath, dir); err != nil {
+		t.Fatalf("exportApp() error = %v", err)
+	}
+
+	want := []string{
+		"xcodebuild -exportArchive -archivePath " + archivePath + " -exportPath " + exportPath + " -exportOptionsPlist ExportOptions.plist",
+	}
+	if got := fake.Commands(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+	if c.Artifacts.AppPath != filepath.Join(dir, "MyApp.app") {
+		t.Errorf("AppPath = %q, want %q", c.Artifacts.AppPath, filepath.Join(dir, "MyApp.app"))
+	}
+	if _, err := os.Stat(exportPath); !os.IsNotExist(err) {
+		t.Errorf("export directory was not removed: %v", err)
+	}
+}
+
+func TestPipeCommitsVersionFileAfterBuild(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildFails bool
+		wantCommit bool
+	}{
+		{name: "successful build commits the version", wantCommit: true},
+		{name: "failed build leaves no commit", buildFails: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			origDir, _ := os.Getwd()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = os.Chdir(origDir) }()
+
+			if err := os.WriteFile("Version.xcconfig", []byte("MARKETING_VERSION = 1.0.0\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			archivePath := filepath.Join("dist", "MyApp.xcarchive")
+			fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+				if name != "xcodebuild" {
+					return "", nil
+				}
+				if strings.Contains(strings.Join(args, " "), "-list") {
+					return `{"project": {"schemes": ["MyApp"]}}`, nil
+				}
+				if tt.buildFails {
+					return "** ARCHIVE FAILED **", errors.New("exit status 65")
+				}
+				return "** ARCHIVE SUCCEEDED **", os.MkdirAll(filepath.Join(archivePath, "Products", "Applications", "MyApp.app", "Contents"), 0755)
+			}}
+
+			cfg := &config.Config{
+				Project: config.ProjectConfig{Name: "MyApp", Scheme: "MyApp", Workspace: "MyApp.xcodeproj"},
+				Build:   config.BuildConfig{Configuration: "Release", VersionFile: "Version.xcconfig", CommitVersion: true},
+			}
+			c := macCtx.NewContext(context.Background(), cfg, logrus.New())
+			c.StdCtx = command.WithRunner(c.StdCtx, fake)
+			c.Version = "v1.2.3"
+
+			err := Pipe{}.Run(c)
+			if tt.buildFails != (err != nil) {
+				t.Fatalf("Run() error = %v, want failure %v", err, tt.buildFails)
+			}
+
+			var gitCalls []string
+			for _, line := range fake.Commands() {
+				if strings.HasPrefix(line, "git ") {
+					gitCalls = append(gitCalls, line)
+				}
+			}
+			if !tt.wantCommit {
+				if len(gitCalls) != 0 {
+					t.Errorf("git ran %q, want no commit after a failed build", gitCalls)
+				}
+				return
+			}
+			want := []string{
+				"git add -- Version.xcconfig",
+				"git commit -m Bump version to 1.2.3 -- Version.xcconfig",
+			}
+			if strings.Join(gitCalls, "\n") != strings.Join(want, "\n") {
+				t.Errorf("git ran %q, want %q", gitCalls, want)
+			}
+			commands := fake.Commands()
+			if last := commands[len(commands)-1]; last != want[1] {
+				t.Errorf("last command = %q, want the commit after the build", last)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/macreleaser/macreleaser/pkg/changelog"
 	"github.com/macreleaser/macreleaser/pkg/context"
 )
 
@@ -15,22 +16,54 @@ type skipError string
 func (e skipError) Error() string { return string(e) }
 func (e skipError) IsSkip() bool  { return true }
 
+// skipReason returns why the check and execution pipes skip, or "" when
+// they run. It depends only on flags and configuration.
+func skipReason(ctx *context.Context) string {
+	if ctx.AppOnly {
+		return "skipped with --app-only"
+	}
+	if ctx.Config.Changelog.Disable {
+		return "changelog disabled"
+	}
+	return ""
+}
+
 // CheckPipe validates changelog configuration.
 type CheckPipe struct{}
 
 func (CheckPipe) String() string { return "validating changelog configuration" }
 
-func (CheckPipe) Run(ctx *context.Context) error {
-	cfg := ctx.Config.Changelog
+func (CheckPipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
 
-	if cfg.Disable {
-		return skipError("changelog disabled")
+func (CheckPipe) Run(ctx *context.Context) error {
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
 	}
 
+	cfg := ctx.Config.Changelog
+
 	if cfg.Sort != "" && cfg.Sort != "asc" && cfg.Sort != "desc" {
 		return fmt.Errorf("changelog.sort must be \"asc\" or \"desc\", got %q", cfg.Sort)
 	}
 
+	if cfg.Since != "" && cfg.Since != changelog.SinceLastStable {
+		return fmt.Errorf("changelog.since must be %q, got %q", changelog.SinceLastStable, cfg.Since)
+	}
+
+	if cfg.Dependencies != "" && cfg.Dependencies != changelog.DependenciesGroup && cfg.Dependencies != changelog.DependenciesExclude {
+		return fmt.Errorf("changelog.dependencies must be %q or %q, got %q", changelog.DependenciesGroup, changelog.DependenciesExclude, cfg.Dependencies)
+	}
+
+	if cfg.PreviousTag != "" {
+		if err := changelog.CheckPreviousTag(ctx.StdCtx, cfg.PreviousTag); err != nil {
+			return err
+		}
+	}
+
+	if cfg.MaxEntries < 0 {
+		return fmt.Errorf("changelog.max_entries must not be negative, got %d", cfg.MaxEntries)
+	}
+
 	for _, pattern := range cfg.Filters.Exclude {
 		if _, err := regexp.Compile(pattern); err != nil {
 			return fmt.Errorf("changelog.filters.exclude: invalid regex %q: %w", pattern, err)
@@ -43,6 +76,12 @@ func (CheckPipe) Run(ctx *context.Context) error {
 		}
 	}
 
+	for i, rule := range cfg.Replace {
+		if _, err := regexp.Compile(rule.Regexp); err != nil {
+			return fmt.Errorf("changelog.replace[%d]: invalid regexp %q: %w", i, rule.Regexp, err)
+		}
+	}
+
 	for i, group := range cfg.Groups {
 		if group.Title == "" {
 			return fmt.Errorf("changelog.groups[%d]: title is required", i)
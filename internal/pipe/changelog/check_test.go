@@ -133,3 +133,48 @@ func TestCheckPipeString(t *testing.T) {
 		t.Errorf("String() = %q, want %q", got, expected)
 	}
 }
+
+func TestCheckPipeNegativeMaxEntries(t *testing.T) {
+	ctx := newCheckContext(config.ChangelogConfig{MaxEntries: -1})
+	err := CheckPipe{}.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "changelog.max_entries") {
+		t.Errorf("Run() error = %v, want changelog.max_entries error", err)
+	}
+}
+
+func TestCheckPipeSince(t *testing.T) {
+	if err := (CheckPipe{}).Run(newCheckContext(config.ChangelogConfig{Since: "last-stable"})); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	err := CheckPipe{}.Run(newCheckContext(config.ChangelogConfig{Since: "last-tag"}))
+	if err == nil || !strings.Contains(err.Error(), "changelog.since") {
+		t.Errorf("Run() error = %v, want changelog.since error", err)
+	}
+}
+
+func TestCheckPipeDependencies(t *testing.T) {
+	for _, mode := range []string{"group", "exclude"} {
+		if err := (CheckPipe{}).Run(newCheckContext(config.ChangelogConfig{Dependencies: mode})); err != nil {
+			t.Fatalf("Run() with dependencies %q unexpected error: %v", mode, err)
+		}
+	}
+
+	err := CheckPipe{}.Run(newCheckContext(config.ChangelogConfig{Dependencies: "hide"}))
+	if err == nil || !strings.Contains(err.Error(), "changelog.dependencies") {
+		t.Errorf("Run() error = %v, want changelog.dependencies error", err)
+	}
+}
+
+func TestCheckPipeInvalidReplaceRegex(t *testing.T) {
+	ctx := newCheckContext(config.ChangelogConfig{
+		Replace: []config.ChangelogReplaceConfig{
+			{Regexp: `^JIRA-\d+: `, Replacement: ""},
+			{Regexp: "[invalid", Replacement: ""},
+		},
+	})
+	err := CheckPipe{}.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "changelog.replace[1]") {
+		t.Errorf("Run() error = %v, want changelog.replace[1] error", err)
+	}
+}
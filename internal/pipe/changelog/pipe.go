@@ -15,9 +15,11 @@ type Pipe struct{}
 
 func (Pipe) String() string { return "generating changelog" }
 
+func (Pipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (Pipe) Run(ctx *context.Context) error {
-	if ctx.Config.Changelog.Disable {
-		return skipError("changelog disabled")
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
 	}
 
 	gitRef := ctx.Git.Tag
@@ -25,22 +27,28 @@ func (Pipe) Run(ctx *context.Context) error {
 		gitRef = "HEAD"
 	}
 
-	prevTag, err := git.PreviousTag(gitRef)
+	prevTag, err := changelog.PreviousRef(ctx.StdCtx, ctx.Config.Changelog, gitRef)
 	if err != nil {
 		return fmt.Errorf("failed to find previous tag: %w", err)
 	}
 
-	commits, err := git.LogBetween(prevTag, gitRef)
+	commits, err := git.LogCommitsBetween(ctx.StdCtx, prevTag, gitRef, ctx.Config.Changelog.IncludeTrailers)
 	if err != nil {
 		return fmt.Errorf("failed to get git log: %w", err)
 	}
 
-	content, err := changelog.Generate(ctx.Version, commits, ctx.Config.Changelog)
+	content, err := changelog.GenerateFromCommits(ctx.Version, commits, ctx.Config.Changelog)
 	if err != nil {
 		return fmt.Errorf("failed to generate changelog: %w", err)
 	}
 
-	ctx.ReleaseNotes = content
+	// With release_body off the file is still written, but the release body
+	// is left to be maintained by hand
+	if ctx.Config.Changelog.UseAsReleaseBody() {
+		ctx.ReleaseNotes = content
+	} else {
+		ctx.Logger.Debug("changelog.release_body is false, leaving the release body unset")
+	}
 
 	// Write CHANGELOG.md to dist/
 	distDir := ctx.Artifacts.BuildOutputDir
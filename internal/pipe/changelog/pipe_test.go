@@ -3,6 +3,7 @@ package changelog
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -125,6 +126,126 @@ func TestPipeRun(t *testing.T) {
 	}
 }
 
+func TestPipeRunWithoutReleaseBody(t *testing.T) {
+	dir := setupGitRepo(t)
+	chdir(t, dir)
+
+	distDir := filepath.Join(dir, "dist")
+	releaseBody := false
+	cfg := &config.Config{Changelog: config.ChangelogConfig{ReleaseBody: &releaseBody}}
+	ctx := macCtx.NewContext(context.Background(), cfg, logrus.New())
+	ctx.Version = "v2.0.0"
+	ctx.Git = git.GitInfo{Tag: "v2.0.0"}
+	ctx.Artifacts.BuildOutputDir = distDir
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if ctx.ReleaseNotes != "" {
+		t.Errorf("ReleaseNotes = %q, want empty with release_body: false", ctx.ReleaseNotes)
+	}
+	data, err := os.ReadFile(filepath.Join(distDir, "CHANGELOG.md"))
+	if err != nil {
+		t.Fatalf("failed to read CHANGELOG.md: %v", err)
+	}
+	if !strings.Contains(string(data), "feat: add new feature") {
+		t.Errorf("CHANGELOG.md missing feat commit:\n%s", data)
+	}
+}
+
+func TestPipeRunSinceLastStable(t *testing.T) {
+	dir := setupGitRepo(t)
+	chdir(t, dir)
+
+	// v2.0.0 is followed by interleaved prereleases before v2.1.0
+	for i, step := range []struct{ commit, tag string }{
+		{"feat: beta widget", "v2.1.0-beta.1"},
+		{"fix: beta crash", "v2.1.0-beta.2"},
+		{"feat: final polish", "v2.1.0"},
+	} {
+		writeFile(t, filepath.Join(dir, fmt.Sprintf("step%d.txt", i)), step.commit)
+		runGit(t, dir, "add", ".")
+		runGit(t, dir, "commit", "-m", step.commit)
+		runGit(t, dir, "tag", step.tag)
+	}
+
+	tests := []struct {
+		since   string
+		want    []string
+		notWant []string
+	}{
+		{
+			since:   "",
+			want:    []string{"feat: final polish"},
+			notWant: []string{"feat: beta widget", "fix: beta crash", "fix: resolve crash"},
+		},
+		{
+			since:   "last-stable",
+			want:    []string{"feat: beta widget", "fix: beta crash", "feat: final polish"},
+			notWant: []string{"fix: resolve crash"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("since="+tt.since, func(t *testing.T) {
+			logger := logrus.New()
+			ctx := macCtx.NewContext(context.Background(), &config.Config{
+				Changelog: config.ChangelogConfig{Since: tt.since},
+			}, logger)
+			ctx.Version = "v2.1.0"
+			ctx.Git = git.GitInfo{Tag: "v2.1.0"}
+			ctx.Artifacts.BuildOutputDir = filepath.Join(dir, "dist")
+
+			if err := (Pipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(ctx.ReleaseNotes, want) {
+					t.Errorf("ReleaseNotes missing %q:\n%s", want, ctx.ReleaseNotes)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(ctx.ReleaseNotes, notWant) {
+					t.Errorf("ReleaseNotes should not contain %q:\n%s", notWant, ctx.ReleaseNotes)
+				}
+			}
+		})
+	}
+}
+
+func TestPipeRunPreviousTag(t *testing.T) {
+	dir := setupGitRepo(t)
+	chdir(t, dir)
+	// Tag the commit between v1.0.0 and v2.0.0 so the override can skip it
+	runGit(t, dir, "tag", "v1.5.0", "v2.0.0^")
+
+	newCtx := func(previousTag string) *macCtx.Context {
+		ctx := macCtx.NewContext(context.Background(), &config.Config{
+			Changelog: config.ChangelogConfig{PreviousTag: previousTag},
+		}, logrus.New())
+		ctx.Version = "v2.0.0"
+		ctx.Git = git.GitInfo{Tag: "v2.0.0"}
+		ctx.Artifacts.BuildOutputDir = filepath.Join(dir, "dist")
+		return ctx
+	}
+
+	ctx := newCtx("v1.0.0")
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for _, want := range []string{"feat: add new feature", "fix: resolve crash"} {
+		if !strings.Contains(ctx.ReleaseNotes, want) {
+			t.Errorf("ReleaseNotes missing %q:\n%s", want, ctx.ReleaseNotes)
+		}
+	}
+
+	err := Pipe{}.Run(newCtx("v0.9.0"))
+	if err == nil || !strings.Contains(err.Error(), `changelog.previous_tag "v0.9.0" does not exist`) {
+		t.Errorf("Run() error = %v, want nonexistent previous_tag error", err)
+	}
+}
+
 func TestPipeRunWithGroups(t *testing.T) {
 	dir := setupGitRepo(t)
 	chdir(t, dir)
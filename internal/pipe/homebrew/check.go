@@ -7,6 +7,7 @@ import (
 	"github.com/macreleaser/macreleaser/pkg/config"
 	"github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/env"
+	"github.com/macreleaser/macreleaser/pkg/homebrew"
 	"github.com/macreleaser/macreleaser/pkg/validate"
 )
 
@@ -15,9 +16,11 @@ type CheckPipe struct{}
 
 func (CheckPipe) String() string { return "validating homebrew configuration" }
 
+func (CheckPipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (CheckPipe) Run(ctx *context.Context) error {
-	if ctx.SkipPublish {
-		return skipError("homebrew publishing skipped")
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
 	}
 
 	cfg := ctx.Config.Homebrew
@@ -39,6 +42,13 @@ func (CheckPipe) Run(ctx *context.Context) error {
 		return fmt.Errorf("invalid cask name %q: must not contain path separators or '..'", cfg.Cask.Name)
 	}
 
+	if err := env.CheckResolved(cfg.Cask.Token, "homebrew.cask.token"); err != nil {
+		return err
+	}
+	if err := homebrew.ValidateToken(caskToken(cfg.Cask)); err != nil {
+		return err
+	}
+
 	if err := validate.RequiredString(cfg.Cask.Desc, "homebrew.cask.desc"); err != nil {
 		return err
 	}
@@ -47,33 +57,220 @@ func (CheckPipe) Run(ctx *context.Context) error {
 		return err
 	}
 
+	if err := env.CheckResolved(cfg.Cask.AppTarget, "homebrew.cask.app_target"); err != nil {
+		return err
+	}
+	if err := homebrew.ValidateAppTarget(cfg.Cask.AppTarget); err != nil {
+		return fmt.Errorf("homebrew.cask: %w", err)
+	}
+
+	conflicts := cfg.Cask.ConflictsWith
+	if err := homebrew.ValidateConflicts(conflicts.Cask, conflicts.Formula); err != nil {
+		return err
+	}
+
+	// The cask must point at a .zip or .dmg; catch this before the build runs
+	// rather than failing in SelectPackage after packaging. An empty formats
+	// list is reported by the archive check.
+	formats := ctx.Config.Archive.Formats.Types()
+	if len(formats) > 0 && !validate.ContainsAny(formats, "zip", "dmg") {
+		return fmt.Errorf("homebrew cask requires a zip or dmg package, but archive.formats is %v — add \"zip\" or \"dmg\" to archive.formats", formats)
+	}
+
+	// A rolling cask always downloads the latest release's asset, so the
+	// asset name must not change from release to release
+	if cfg.Cask.Rolling {
+		if cfg.Tap.AlsoLatest {
+			return fmt.Errorf("homebrew.cask.rolling cannot be combined with homebrew.tap.also_latest — a rolling cask already tracks the latest release")
+		}
+		for i, tap := range cfg.Taps {
+			if tap.AlsoLatest {
+				return fmt.Errorf("homebrew.cask.rolling cannot be combined with homebrew.taps[%d].also_latest — a rolling cask already tracks the latest release", i)
+			}
+		}
+		if ctx.Config.Release.GitHub.AssetNameTemplate == "" {
+			ctx.Logger.Warn("homebrew.cask.rolling is set but release.github.asset_name_template is not — if asset names include the version, the cask URL will break after the next release")
+		}
+	}
+
 	// If custom tap is configured, validate its required fields
 	if isTapConfigured(cfg.Tap) {
-		if err := env.CheckResolved(cfg.Tap.Owner, "homebrew.tap.owner"); err != nil {
+		if err := checkTap(cfg.Tap, "homebrew.tap"); err != nil {
 			return err
 		}
-		if err := env.CheckResolved(cfg.Tap.Name, "homebrew.tap.name"); err != nil {
+	}
+
+	// Each additional tap needs the same fields, and no repository may be
+	// listed twice or the cask would be committed to it twice
+	seen := make(map[string]bool)
+	if isTapConfigured(cfg.Tap) {
+		seen[strings.ToLower(cfg.Tap.Owner+"/"+cfg.Tap.Name)] = true
+	}
+	for i, tap := range cfg.Taps {
+		field := fmt.Sprintf("homebrew.taps[%d]", i)
+		if err := checkTap(tap, field); err != nil {
 			return err
 		}
-		if err := env.CheckResolved(cfg.Tap.Token, "homebrew.tap.token"); err != nil {
+		if isBetaTapConfigured(tap.Beta) {
+			return fmt.Errorf("%s.beta is not supported — configure the beta tap under homebrew.tap.beta", field)
+		}
+		slug := strings.ToLower(tap.Owner + "/" + tap.Name)
+		if seen[slug] {
+			return fmt.Errorf("%s: tap %s/%s is configured more than once", field, tap.Owner, tap.Name)
+		}
+		seen[slug] = true
+	}
+
+	if isBetaTapConfigured(cfg.Tap.Beta) {
+		if err := env.CheckResolved(cfg.Tap.Beta.Owner, "homebrew.tap.beta.owner"); err != nil {
+			return err
+		}
+		if err := env.CheckResolved(cfg.Tap.Beta.Name, "homebrew.tap.beta.name"); err != nil {
+			return err
+		}
+		if err := env.CheckResolved(cfg.Tap.Beta.Token, "homebrew.tap.beta.token"); err != nil {
 			return err
 		}
 
-		if err := validate.RequiredString(cfg.Tap.Owner, "homebrew.tap.owner"); err != nil {
+		if err := validate.RequiredString(cfg.Tap.Beta.Owner, "homebrew.tap.beta.owner"); err != nil {
 			return err
 		}
-		if err := validate.RequiredString(cfg.Tap.Name, "homebrew.tap.name"); err != nil {
+		if err := validate.RequiredString(cfg.Tap.Beta.Name, "homebrew.tap.beta.name"); err != nil {
 			return err
 		}
-		if err := validate.RequiredString(cfg.Tap.Token, "homebrew.tap.token"); err != nil {
+		if err := validate.RequiredString(cfg.Tap.Beta.Token, "homebrew.tap.beta.token"); err != nil {
 			return err
 		}
 	}
 
+	if err := checkCommitMessageTemplates(cfg); err != nil {
+		return err
+	}
+
+	// Confirm each tap can be published to now, rather than after the build,
+	// signing and notarization have already run
+	if err := checkTapAccess(ctx, cfg); err != nil {
+		return err
+	}
+
 	ctx.Logger.Debug("Homebrew configuration validated successfully")
 	return nil
 }
 
+// checkTapAccess verifies that every configured tap repository exists and
+// that its token is allowed to push to it. The injected ctx.HomebrewClient
+// is used when set (e.g., by tests).
+func checkTapAccess(ctx *context.Context, cfg config.HomebrewConfig) error {
+	type tapRepo struct {
+		field, owner, name, token string
+	}
+	var taps []tapRepo
+	if isTapConfigured(cfg.Tap) {
+		taps = append(taps, tapRepo{"homebrew.tap", cfg.Tap.Owner, cfg.Tap.Name, cfg.Tap.Token})
+	}
+	for i, tap := range cfg.Taps {
+		taps = append(taps, tapRepo{fmt.Sprintf("homebrew.taps[%d]", i), tap.Owner, tap.Name, tap.Token})
+	}
+	if isBetaTapConfigured(cfg.Tap.Beta) {
+		beta := cfg.Tap.Beta
+		taps = append(taps, tapRepo{"homebrew.tap.beta", beta.Owner, beta.Name, beta.Token})
+	}
+
+	for _, tap := range taps {
+		client := ctx.HomebrewClient
+		if client == nil {
+			var err error
+			if client, err = newTapClient(ctx, tap.token); err != nil {
+				return err
+			}
+		}
+
+		repo, err := client.GetRepository(ctx.StdCtx, tap.owner, tap.name)
+		if err != nil {
+			return fmt.Errorf("%s: cannot access tap repository %s/%s — check that it exists and that %s.token can read it: %w", tap.field, tap.owner, tap.name, tap.field, err)
+		}
+		// Permissions are only reported for authenticated requests; when
+		// they are missing, leave any push failure to the publish step
+		if repo.Permissions != nil && !repo.GetPermissions()["push"] {
+			return fmt.Errorf("%s: %s.token cannot push to tap repository %s/%s — grant it write access to the repository", tap.field, tap.field, tap.owner, tap.name)
+		}
+		ctx.Logger.Debugf("Tap repository %s/%s is writable", tap.owner, tap.name)
+	}
+	return nil
+}
+
+// caskToken returns the configured token override, or a token derived from
+// the cask name when none is set.
+func caskToken(cfg config.CaskConfig) string {
+	if cfg.Token != "" {
+		return cfg.Token
+	}
+	return homebrew.NormalizeToken(cfg.Name)
+}
+
+// checkTap validates the repository and token of a tap configured under
+// field, such as "homebrew.tap" or "homebrew.taps[1]".
+func checkTap(tap config.TapConfig, field string) error {
+	if err := env.CheckResolved(tap.Owner, field+".owner"); err != nil {
+		return err
+	}
+	if err := env.CheckResolved(tap.Name, field+".name"); err != nil {
+		return err
+	}
+	if err := env.CheckResolved(tap.Token, field+".token"); err != nil {
+		return err
+	}
+
+	if err := validate.RequiredString(tap.Owner, field+".owner"); err != nil {
+		return err
+	}
+	if err := validate.RequiredString(tap.Name, field+".name"); err != nil {
+		return err
+	}
+	if err := validate.RequiredString(tap.Token, field+".token"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkCommitMessageTemplates renders the commit message template of every
+// tap a release may commit to with sample data. The beta tap uses the
+// template of homebrew.tap, so it is checked even when only the beta tap is
+// configured.
+func checkCommitMessageTemplates(cfg config.HomebrewConfig) error {
+	type tapTemplate struct {
+		field, template string
+	}
+	var templates []tapTemplate
+	if isTapConfigured(cfg.Tap) || isBetaTapConfigured(cfg.Tap.Beta) {
+		templates = append(templates, tapTemplate{"homebrew.tap", cfg.Tap.CommitMessageTemplate})
+	}
+	for i, tap := range cfg.Taps {
+		templates = append(templates, tapTemplate{fmt.Sprintf("homebrew.taps[%d]", i), tap.CommitMessageTemplate})
+	}
+
+	sample := commitMessageData{Token: "myapp", Version: "1.0.0", Action: "Add"}
+	for _, t := range templates {
+		if t.template == "" {
+			continue
+		}
+		if _, err := renderCommitMessage(t.template, sample); err != nil {
+			return fmt.Errorf("%s: %w", t.field, err)
+		}
+	}
+	return nil
+}
+
+// PushesToTap reports whether cfg commits the cask to at least one tap.
+// Each push asks for confirmation first.
+func PushesToTap(cfg config.HomebrewConfig) bool {
+	return isTapConfigured(cfg.Tap) || isBetaTapConfigured(cfg.Tap.Beta) || len(cfg.Taps) > 0
+}
+
 func isTapConfigured(cfg config.TapConfig) bool {
+	return cfg.Owner != "" || cfg.Name != "" || cfg.Token != "" || cfg.AlsoLatest
+}
+
+func isBetaTapConfigured(cfg config.BetaTapConfig) bool {
 	return cfg.Owner != "" || cfg.Name != "" || cfg.Token != ""
 }
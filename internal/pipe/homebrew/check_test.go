@@ -2,11 +2,14 @@ package homebrew
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
+	gogithub "github.com/google/go-github/github"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macCtx "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/github"
 	"github.com/sirupsen/logrus"
 )
 
@@ -186,11 +189,317 @@ func TestCheckPipe(t *testing.T) {
 			wantErr: true,
 			errMsg:  "homebrew.tap.name is required",
 		},
+		{
+			name: "also_latest without a tap",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Tap: config.TapConfig{
+						AlsoLatest: true,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew.tap.owner is required",
+		},
+		{
+			name: "valid rolling cask",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+						Rolling:  true,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rolling cask with also_latest",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+						Rolling:  true,
+					},
+					Tap: config.TapConfig{
+						Owner:      "user",
+						Name:       "homebrew-tap",
+						Token:      "ghp_testtoken123",
+						AlsoLatest: true,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew.cask.rolling cannot be combined with homebrew.tap.also_latest",
+		},
+		{
+			name: "valid conflicts_with",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:          "myapp",
+						Desc:          "My awesome macOS application",
+						Homepage:      "https://github.com/user/myapp",
+						ConflictsWith: config.CaskConflicts{Formula: []string{"myapp-cli"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "conflicts_with entry with a quote",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:          "myapp",
+						Desc:          "My awesome macOS application",
+						Homepage:      "https://github.com/user/myapp",
+						ConflictsWith: config.CaskConflicts{Cask: []string{`legacy"app`}},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid conflicts_with.cask",
+		},
+		{
+			name: "valid configuration with beta tap",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Tap: config.TapConfig{
+						Beta: config.BetaTapConfig{
+							Owner: "user",
+							Name:  "homebrew-beta",
+							Token: "ghp_testtoken123",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "beta tap with missing name",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Tap: config.TapConfig{
+						Beta: config.BetaTapConfig{
+							Owner: "user",
+							Token: "ghp_testtoken123",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew.tap.beta.name is required",
+		},
+		{
+			name: "valid additional taps",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Tap: config.TapConfig{Owner: "org", Name: "homebrew-tap", Token: "ghp_org"},
+					Taps: []config.TapConfig{
+						{Owner: "user", Name: "homebrew-tap", Token: "ghp_user"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "additional tap with missing token",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Taps: []config.TapConfig{
+						{Owner: "org", Name: "homebrew-tap", Token: "ghp_org"},
+						{Owner: "user", Name: "homebrew-tap"},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew.taps[1].token is required",
+		},
+		{
+			name: "commit message template with unknown field",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Tap: config.TapConfig{
+						Owner:                 "user",
+						Name:                  "homebrew-tap",
+						Token:                 "ghp_testtoken123",
+						CommitMessageTemplate: "Bump {{.Cask}}",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew.tap: invalid commit_message_template",
+		},
+		{
+			name: "beta tap only with invalid commit message template",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Tap: config.TapConfig{
+						CommitMessageTemplate: "Bump {{.Cask}}",
+						Beta: config.BetaTapConfig{
+							Owner: "user",
+							Name:  "homebrew-beta",
+							Token: "ghp_testtoken123",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew.tap: invalid commit_message_template",
+		},
+		{
+			name: "tap listed twice",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+					Tap: config.TapConfig{Owner: "org", Name: "homebrew-tap", Token: "ghp_org"},
+					Taps: []config.TapConfig{
+						{Owner: "Org", Name: "homebrew-tap", Token: "ghp_other"},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew.taps[0]: tap Org/homebrew-tap is configured more than once",
+		},
+		{
+			name: "display name is normalized to a valid token",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "My App",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid token override",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "My App",
+						Token:    "my-app-pro",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid token override",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Token:    "My_App",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid cask token \"My_App\"",
+		},
+		{
+			name: "name without any token characters",
+			config: &config.Config{
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "!!!",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid cask token",
+		},
+		{
+			name: "app-only archive formats",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "app"}},
+				},
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "homebrew cask requires a zip or dmg package",
+		},
+		{
+			name: "dmg archive format satisfies cask",
+			config: &config.Config{
+				Archive: config.ArchiveConfig{
+					Formats: config.ArchiveFormats{{Type: "app"}, {Type: "dmg"}},
+				},
+				Homebrew: config.HomebrewConfig{
+					Cask: config.CaskConfig{
+						Name:     "myapp",
+						Desc:     "My awesome macOS application",
+						Homepage: "https://github.com/user/myapp",
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := macCtx.NewContext(context.Background(), tt.config, logger)
+			ctx.HomebrewClient = mockTapRepos(tt.config.Homebrew)
 			err := CheckPipe{}.Run(ctx)
 
 			if (err != nil) != tt.wantErr {
@@ -207,6 +516,109 @@ func TestCheckPipe(t *testing.T) {
 	}
 }
 
+// mockTapRepos returns a mock client holding a writable repository for
+// every tap in cfg.
+func mockTapRepos(cfg config.HomebrewConfig) *github.MockClient {
+	mock := github.NewMockClient()
+	add := func(owner, name string) {
+		mock.Repositories[owner+"/"+name] = newTapRepo(name, true)
+	}
+	add(cfg.Tap.Owner, cfg.Tap.Name)
+	add(cfg.Tap.Beta.Owner, cfg.Tap.Beta.Name)
+	for _, tap := range cfg.Taps {
+		add(tap.Owner, tap.Name)
+	}
+	return mock
+}
+
+func newTapRepo(name string, push bool) *gogithub.Repository {
+	return &gogithub.Repository{
+		Name:        gogithub.String(name),
+		Permissions: &map[string]bool{"pull": true, "push": push},
+	}
+}
+
+func TestCheckPipeTapAccess(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	validCask := config.CaskConfig{
+		Name:     "myapp",
+		Desc:     "My awesome macOS application",
+		Homepage: "https://github.com/user/myapp",
+	}
+
+	tests := []struct {
+		name   string
+		tap    config.TapConfig
+		taps   []config.TapConfig
+		repos  map[string]*gogithub.Repository
+		err    error
+		errMsg string
+	}{
+		{
+			name:  "existing writable tap",
+			tap:   config.TapConfig{Owner: "user", Name: "homebrew-tap", Token: "ghp_test"},
+			repos: map[string]*gogithub.Repository{"user/homebrew-tap": newTapRepo("homebrew-tap", true)},
+		},
+		{
+			name:  "permissions not reported",
+			tap:   config.TapConfig{Owner: "user", Name: "homebrew-tap", Token: "ghp_test"},
+			repos: map[string]*gogithub.Repository{"user/homebrew-tap": {Name: gogithub.String("homebrew-tap")}},
+		},
+		{
+			name:   "missing tap",
+			tap:    config.TapConfig{Owner: "user", Name: "homebrew-tap", Token: "ghp_test"},
+			errMsg: "homebrew.tap: cannot access tap repository user/homebrew-tap — check that it exists and that homebrew.tap.token can read it",
+		},
+		{
+			name:   "read-only tap",
+			tap:    config.TapConfig{Owner: "user", Name: "homebrew-tap", Token: "ghp_test"},
+			repos:  map[string]*gogithub.Repository{"user/homebrew-tap": newTapRepo("homebrew-tap", false)},
+			errMsg: "homebrew.tap: homebrew.tap.token cannot push to tap repository user/homebrew-tap",
+		},
+		{
+			name:   "missing additional tap",
+			tap:    config.TapConfig{Owner: "user", Name: "homebrew-tap", Token: "ghp_test"},
+			taps:   []config.TapConfig{{Owner: "org", Name: "homebrew-tap", Token: "ghp_org"}},
+			repos:  map[string]*gogithub.Repository{"user/homebrew-tap": newTapRepo("homebrew-tap", true)},
+			errMsg: "homebrew.taps[0]: cannot access tap repository org/homebrew-tap",
+		},
+		{
+			name:   "API error",
+			tap:    config.TapConfig{Owner: "user", Name: "homebrew-tap", Token: "ghp_test"},
+			err:    errors.New("401 Bad credentials"),
+			errMsg: "401 Bad credentials",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Homebrew: config.HomebrewConfig{Cask: validCask, Tap: tt.tap, Taps: tt.taps},
+			}
+			ctx := macCtx.NewContext(context.Background(), cfg, logger)
+			mock := github.NewMockClient()
+			for slug, repo := range tt.repos {
+				mock.Repositories[slug] = repo
+			}
+			mock.ErrorToReturn = tt.err
+			ctx.HomebrewClient = mock
+
+			err := CheckPipe{}.Run(ctx)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Fatalf("Run() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
 func TestCheckPipeString(t *testing.T) {
 	p := CheckPipe{}
 	expected := "validating homebrew configuration"
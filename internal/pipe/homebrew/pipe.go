@@ -1,14 +1,21 @@
 package homebrew
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"text/template"
 
+	"github.com/macreleaser/macreleaser/pkg/config"
 	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/git"
 	gh "github.com/macreleaser/macreleaser/pkg/github"
 	"github.com/macreleaser/macreleaser/pkg/homebrew"
+	"github.com/macreleaser/macreleaser/pkg/retry"
 )
 
 // skipError signals an intentional skip. It satisfies the pipe.IsSkip interface
@@ -19,111 +26,348 @@ type skipError string
 func (e skipError) Error() string { return string(e) }
 func (e skipError) IsSkip() bool  { return true }
 
+// skipReason returns why the check and execution pipes skip, or "" when
+// they run. It depends only on flags and configuration.
+func skipReason(ctx *context.Context) string {
+	if ctx.SkipPublish {
+		return "homebrew publishing skipped"
+	}
+	return ""
+}
+
 // Pipe generates a Homebrew cask file and optionally commits it to a custom tap.
 type Pipe struct{}
 
 func (Pipe) String() string { return "generating Homebrew cask" }
 
+func (Pipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
+// SoftFail marks the tap commit as non-critical: by the time it runs the
+// GitHub release is already published, so --continue-on-error may go on.
+func (Pipe) SoftFail() bool { return true }
+
 func (Pipe) Run(ctx *context.Context) error {
-	if ctx.SkipPublish {
-		return skipError("homebrew publishing skipped")
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
 	}
 
+	data, caskContent, err := RenderCask(ctx)
+	if err != nil {
+		return err
+	}
+
+	taps := stableTaps(ctx.Config.Homebrew)
+	if useBetaTap(ctx) {
+		beta := ctx.Config.Homebrew.Tap.Beta
+		taps = []tapTarget{{
+			owner:           beta.Owner,
+			name:            beta.Name,
+			token:           beta.Token,
+			messageTemplate: ctx.Config.Homebrew.Tap.CommitMessageTemplate,
+		}}
+		ctx.Logger.Infof("Prerelease %s: publishing to beta tap %s/%s", ctx.Version, beta.Owner, beta.Name)
+	}
+
+	// Write local cask file
+	localPath := filepath.Join(ctx.Artifacts.BuildOutputDir, data.Token+".rb")
+	if err := os.WriteFile(localPath, []byte(caskContent), 0600); err != nil {
+		return fmt.Errorf("failed to write cask file: %w", err)
+	}
+	ctx.Artifacts.HomebrewCaskPath = localPath
+	ctx.Logger.Infof("Generated cask file: %s", localPath)
+
+	// Commit to every configured tap. A failure in one tap does not stop
+	// the others from being updated; all failures are reported together.
+	var errs []error
+	for _, tap := range taps {
+		if err := publishToTap(ctx, tap, data, caskContent); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		if len(taps) == 1 {
+			return errs[0]
+		}
+		return fmt.Errorf("failed to publish the cask to %d of %d taps: %w", len(errs), len(taps), errors.Join(errs...))
+	}
+
+	ctx.Logger.Infof("Homebrew cask generated: %s", data.Token)
+	return nil
+}
+
+// RenderCask selects the package the cask downloads, hashes it, and renders
+// the cask for the release described by ctx. Nothing is written or
+// published.
+func RenderCask(ctx *context.Context) (homebrew.CaskData, string, error) {
 	if len(ctx.Artifacts.Packages) == 0 {
-		return fmt.Errorf("no packages found for Homebrew cask — ensure the archive step completed successfully")
+		return homebrew.CaskData{}, "", fmt.Errorf("no packages found for Homebrew cask — ensure the archive step completed successfully")
 	}
 
 	if ctx.Artifacts.AppPath == "" {
-		return fmt.Errorf("no .app path found — ensure the build step completed successfully")
+		return homebrew.CaskData{}, "", fmt.Errorf("no .app path found — ensure the build step completed successfully")
 	}
 
-	// Select the best archive for the cask (prefer .zip)
-	packagePath, err := homebrew.SelectPackage(ctx.Artifacts.Packages)
+	// Select the best archive for the cask (prefer .zip) among those the
+	// release uploaded, since the cask cannot download one left off it
+	packages := withoutSkipped(ctx.Artifacts.Packages, ctx.Artifacts.SkippedAssets)
+	packagePath, err := homebrew.SelectPackage(packages)
 	if err != nil {
-		return err
+		if len(packages) < len(ctx.Artifacts.Packages) {
+			return homebrew.CaskData{}, "", fmt.Errorf("no .zip or .dmg package was uploaded to the release for the Homebrew cask — %d package(s) larger than release.github.max_asset_size were left off it", len(ctx.Artifacts.Packages)-len(packages))
+		}
+		return homebrew.CaskData{}, "", err
 	}
 
 	filename := filepath.Base(packagePath)
-	ctx.Logger.Infof("Computing SHA256 hash of %s", filename)
+	rolling := ctx.Config.Homebrew.Cask.Rolling
 
-	hash, err := homebrew.ComputeSHA256(packagePath)
-	if err != nil {
-		return fmt.Errorf("failed to compute SHA256 for %s: %w", filename, err)
+	// A rolling cask uses sha256 :no_check, so there is nothing to hash
+	hash := ""
+	if !rolling {
+		ctx.Logger.Infof("Computing SHA256 hash of %s", filename)
+		hash, err = homebrew.ComputeSHA256(packagePath)
+		if err != nil {
+			return homebrew.CaskData{}, "", fmt.Errorf("failed to compute SHA256 for %s: %w", filename, err)
+		}
+	}
+
+	// The release may have uploaded the package under another name
+	assetName := filename
+	if name, ok := ctx.Artifacts.AssetNames[packagePath]; ok {
+		assetName = name
 	}
 
 	owner := ctx.Config.Release.GitHub.Owner
 	repo := ctx.Config.Release.GitHub.Repo
-	assetURL := homebrew.BuildAssetURL(owner, repo, ctx.Version, filename)
+	tag := ctx.Version
+	if rollingTag := ctx.Config.Release.GitHub.RollingTag; rollingTag != "" {
+		tag = rollingTag
+	}
+	assetURL := homebrew.BuildAssetURL(owner, repo, tag, assetName)
+	if rolling {
+		assetURL = homebrew.BuildLatestAssetURL(owner, repo, assetName)
+	}
 
-	data := homebrew.CaskData{
-		Token:    ctx.Config.Homebrew.Cask.Name,
-		Version:  strings.TrimPrefix(ctx.Version, "v"),
-		SHA256:   hash,
-		URL:      assetURL,
-		Name:     ctx.Config.Project.Name,
-		Desc:     ctx.Config.Homebrew.Cask.Desc,
-		Homepage: ctx.Config.Homebrew.Cask.Homepage,
-		AppName:  filepath.Base(ctx.Artifacts.AppPath),
+	// Validate cask name doesn't contain path traversal sequences
+	name := ctx.Config.Homebrew.Cask.Name
+	if strings.ContainsAny(name, "/\\") || strings.Contains(name, "..") {
+		return homebrew.CaskData{}, "", fmt.Errorf("invalid cask name %q: must not contain path separators or '..'", name)
 	}
 
-	// Validate cask token doesn't contain path traversal sequences
-	if strings.ContainsAny(data.Token, "/\\") || strings.Contains(data.Token, "..") {
-		return fmt.Errorf("invalid cask name %q: must not contain path separators or '..'", data.Token)
+	token := caskToken(ctx.Config.Homebrew.Cask)
+	if err := homebrew.ValidateToken(token); err != nil {
+		return homebrew.CaskData{}, "", err
+	}
+
+	// Prereleases routed to the beta tap get a distinct token
+	if useBetaTap(ctx) {
+		token += "@beta"
+	}
+
+	data := homebrew.CaskData{
+		Token:       token,
+		Version:     strings.TrimPrefix(ctx.Version, "v"),
+		SHA256:      hash,
+		URL:         assetURL,
+		Name:        ctx.Config.Project.Title(),
+		Desc:        caskDesc(ctx),
+		Homepage:    ctx.Config.Homebrew.Cask.Homepage,
+		AutoUpdates: ctx.Config.Homebrew.Cask.AutoUpdates,
+		AppName:     filepath.Base(ctx.Artifacts.AppPath),
+		AppTarget:   ctx.Config.Homebrew.Cask.AppTarget,
+		Caveats:     ctx.Config.Homebrew.Cask.Caveats,
+		Rolling:     rolling,
+
+		ConflictsWithCasks:    ctx.Config.Homebrew.Cask.ConflictsWith.Cask,
+		ConflictsWithFormulae: ctx.Config.Homebrew.Cask.ConflictsWith.Formula,
 	}
 
 	caskContent, err := homebrew.RenderCask(data)
 	if err != nil {
-		return err
+		return homebrew.CaskData{}, "", err
 	}
+	return data, caskContent, nil
+}
 
-	// Write local cask file
-	localPath := filepath.Join(ctx.Artifacts.BuildOutputDir, data.Token+".rb")
-	if err := os.WriteFile(localPath, []byte(caskContent), 0600); err != nil {
-		return fmt.Errorf("failed to write cask file: %w", err)
+// useBetaTap reports whether the release is a prerelease routed to the
+// configured beta tap.
+func useBetaTap(ctx *context.Context) bool {
+	return git.IsPrerelease(ctx.Version) && isBetaTapConfigured(ctx.Config.Homebrew.Tap.Beta)
+}
+
+// tapTarget identifies the tap repository a cask is committed to.
+type tapTarget struct {
+	owner           string
+	name            string
+	token           string
+	alsoLatest      bool
+	messageTemplate string
+}
+
+// stableTaps returns the taps a stable release is committed to: the
+// singular tap, if configured, followed by each entry of taps.
+func stableTaps(cfg config.HomebrewConfig) []tapTarget {
+	var taps []tapTarget
+	if isTapConfigured(cfg.Tap) {
+		taps = append(taps, newTapTarget(cfg.Tap))
 	}
-	ctx.Artifacts.HomebrewCaskPath = localPath
-	ctx.Logger.Infof("Generated cask file: %s", localPath)
+	for _, tap := range cfg.Taps {
+		taps = append(taps, newTapTarget(tap))
+	}
+	return taps
+}
 
-	// Commit to custom tap if configured
-	if isTapConfigured(ctx.Config.Homebrew.Tap) {
-		if err := commitToTap(ctx, data, caskContent); err != nil {
-			return err
+func newTapTarget(cfg config.TapConfig) tapTarget {
+	return tapTarget{
+		owner:           cfg.Owner,
+		name:            cfg.Name,
+		token:           cfg.Token,
+		alsoLatest:      cfg.AlsoLatest,
+		messageTemplate: cfg.CommitMessageTemplate,
+	}
+}
+
+// commitMessageData is the data a tap commit message template is rendered with.
+type commitMessageData struct {
+	Token   string
+	Version string
+	Action  string // "Add" for a new cask, "Update" for an existing one
+}
+
+// renderCommitMessage renders the tap commit message template. Without one,
+// the message is "Add <token> <version>" or "Update <token> to <version>".
+func renderCommitMessage(messageTemplate string, data commitMessageData) (string, error) {
+	if messageTemplate == "" {
+		if data.Action == "Update" {
+			return fmt.Sprintf("Update %s to %s", data.Token, data.Version), nil
 		}
+		return fmt.Sprintf("Add %s %s", data.Token, data.Version), nil
 	}
+	tmpl, err := template.New("commit_message").Parse(messageTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid commit_message_template %q: %w", messageTemplate, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("invalid commit_message_template %q: %w", messageTemplate, err)
+	}
+	message := strings.TrimSpace(buf.String())
+	if message == "" {
+		return "", fmt.Errorf("commit_message_template %q renders an empty commit message", messageTemplate)
+	}
+	return message, nil
+}
 
-	ctx.Logger.Infof("Homebrew cask generated: %s", data.Token)
-	return nil
+// caskDesc returns the configured cask description, normalized for brew
+// audit when homebrew.cask.normalize_desc is set.
+func caskDesc(ctx *context.Context) string {
+	desc := ctx.Config.Homebrew.Cask.Desc
+	if !ctx.Config.Homebrew.Cask.NormalizeDesc {
+		return desc
+	}
+	normalized := homebrew.NormalizeDesc(desc)
+	if normalized != desc {
+		ctx.Logger.Infof("Normalized homebrew.cask.desc from %q to %q", desc, normalized)
+	}
+	return normalized
 }
 
-func commitToTap(ctx *context.Context, data homebrew.CaskData, caskContent string) error {
-	tapOwner := ctx.Config.Homebrew.Tap.Owner
-	tapName := ctx.Config.Homebrew.Tap.Name
+// newTapClient creates the GitHub client for a tap's token; replaced in tests.
+var newTapClient = func(ctx *context.Context, token string) (gh.ClientInterface, error) {
+	timeout, err := gh.ParseTimeout(ctx.Config.Release.GitHub.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	rc := ctx.Config.Retry
+	policy, err := retry.Parse(rc.MaxAttempts, rc.BaseDelay, rc.MaxDelay)
+	if err != nil {
+		return nil, err
+	}
+	client, err := gh.NewClientWithOptions(token, gh.ClientOptions{
+		Timeout: timeout,
+		Retry:   policy,
+		Headers: ctx.Config.Release.GitHub.Headers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client for tap: %w", err)
+	}
+	return client, nil
+}
 
-	// Create GitHub client from tap token if not already injected (e.g., by tests)
-	if ctx.HomebrewClient == nil {
-		client, err := gh.NewClient(ctx.Config.Homebrew.Tap.Token)
-		if err != nil {
-			return fmt.Errorf("failed to create GitHub client for tap: %w", err)
+// publishToTap commits the cask, and its @latest copy when enabled, to tap.
+// The injected ctx.HomebrewClient is used when set (e.g., by tests);
+// otherwise a client is created with the tap's own token.
+func publishToTap(ctx *context.Context, tap tapTarget, data homebrew.CaskData, caskContent string) error {
+	client := ctx.HomebrewClient
+	if client == nil {
+		var err error
+		if client, err = newTapClient(ctx, tap.token); err != nil {
+			return err
 		}
-		ctx.HomebrewClient = client
 	}
 
+	if err := commitToTap(ctx, client, tap, data, caskContent); err != nil {
+		return err
+	}
+	if tap.alsoLatest {
+		return commitLatest(ctx, client, tap, data)
+	}
+	return nil
+}
+
+// commitLatest commits a copy of the cask under a "<token>@latest" token,
+// pinned to the release just published.
+func commitLatest(ctx *context.Context, client gh.ClientInterface, tap tapTarget, data homebrew.CaskData) error {
+	data.Token += "@latest"
+	content, err := homebrew.RenderCask(data)
+	if err != nil {
+		return err
+	}
+	return commitToTap(ctx, client, tap, data, content)
+}
+
+func commitToTap(ctx *context.Context, client gh.ClientInterface, tap tapTarget, data homebrew.CaskData, caskContent string) error {
+	tapOwner := tap.owner
+	tapName := tap.name
+
 	caskPath := fmt.Sprintf("Casks/%s.rb", data.Token)
 	content := []byte(caskContent)
 
 	// Check if the file already exists (for update vs create)
-	existing, err := ctx.HomebrewClient.GetFileContents(ctx.StdCtx, tapOwner, tapName, caskPath)
+	existing, err := client.GetFileContents(ctx.StdCtx, tapOwner, tapName, caskPath)
 	if err == nil {
-		// File exists — update it
-		message := fmt.Sprintf("Update %s to %s", data.Token, data.Version)
-		if err := ctx.HomebrewClient.UpdateFile(ctx.StdCtx, tapOwner, tapName, caskPath, message, content, existing.GetSHA()); err != nil {
+		// File exists — update it unless the rendered cask is identical
+		current, err := existing.GetContent()
+		if err != nil {
+			return fmt.Errorf("failed to decode existing cask in tap %s/%s: %w", tapOwner, tapName, err)
+		}
+		if current == caskContent {
+			ctx.Logger.Infof("Cask in %s/%s is unchanged, skipping commit: %s", tapOwner, tapName, caskPath)
+			return nil
+		}
+		ctx.Logger.Debugf("Cask changes:\n%s", homebrew.Diff(caskPath, caskPath+" (new)", current, caskContent))
+
+		message, err := renderCommitMessage(tap.messageTemplate, commitMessageData{Token: data.Token, Version: data.Version, Action: "Update"})
+		if err != nil {
+			return err
+		}
+		if err := confirmPush(ctx, tap, data); err != nil {
+			return err
+		}
+		if err := client.UpdateFile(ctx.StdCtx, tapOwner, tapName, caskPath, message, content, existing.GetSHA()); err != nil {
 			return fmt.Errorf("failed to commit cask to tap %s/%s: %w", tapOwner, tapName, err)
 		}
 		ctx.Logger.Infof("Updated cask in %s/%s: %s", tapOwner, tapName, caskPath)
 	} else if gh.IsNotFound(err) {
 		// File doesn't exist — create it
-		message := fmt.Sprintf("Add %s %s", data.Token, data.Version)
-		if err := ctx.HomebrewClient.CreateFile(ctx.StdCtx, tapOwner, tapName, caskPath, message, content); err != nil {
+		message, err := renderCommitMessage(tap.messageTemplate, commitMessageData{Token: data.Token, Version: data.Version, Action: "Add"})
+		if err != nil {
+			return err
+		}
+		if err := confirmPush(ctx, tap, data); err != nil {
+			return err
+		}
+		if err := client.CreateFile(ctx.StdCtx, tapOwner, tapName, caskPath, message, content); err != nil {
 			return fmt.Errorf("failed to commit cask to tap %s/%s: %w", tapOwner, tapName, err)
 		}
 		ctx.Logger.Infof("Created cask in %s/%s: %s", tapOwner, tapName, caskPath)
@@ -133,3 +377,20 @@ func commitToTap(ctx *context.Context, data homebrew.CaskData, caskContent strin
 
 	return nil
 }
+
+// confirmPush asks before committing the cask to tap. It runs only once the
+// cask is known to change, so an unchanged cask never prompts.
+func confirmPush(ctx *context.Context, tap tapTarget, data homebrew.CaskData) error {
+	return ctx.Confirm(fmt.Sprintf("push the %s cask to the tap %s/%s", data.Token, tap.owner, tap.name))
+}
+
+// withoutSkipped returns packages without those in skipped.
+func withoutSkipped(packages, skipped []string) []string {
+	var kept []string
+	for _, p := range packages {
+		if !slices.Contains(skipped, p) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
@@ -1,6 +1,7 @@
 package homebrew
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"testing"
 
 	gogithub "github.com/google/go-github/github"
+	"github.com/macreleaser/macreleaser/internal/pipe/release"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macCtx "github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/github"
@@ -175,6 +177,108 @@ func TestPipeGenerateCaskAndCommitToTap(t *testing.T) {
 	}
 }
 
+func TestPipeSkipsOversizedReleaseAssets(t *testing.T) {
+	tests := []struct {
+		name    string
+		maxSize int64
+		wantURL string
+		errMsg  string
+	}{
+		{name: "no limit", wantURL: "TestApp-1.2.3.zip"},
+		{name: "zip too large", maxSize: 10, wantURL: "TestApp-1.2.3.dmg"},
+		{name: "everything too large", maxSize: 1, errMsg: "2 package(s) larger than release.github.max_asset_size were left off it"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, tmpDir := newTestContext(t)
+			dmgPath := filepath.Join(tmpDir, "TestApp-1.2.3.dmg")
+			if err := os.WriteFile(dmgPath, []byte("dmg"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			// The 16-byte zip is preferred by the cask unless it is left off
+			ctx.Artifacts.Packages = append(ctx.Artifacts.Packages, dmgPath)
+			ctx.Config.Release.GitHub.MaxAssetSize = tt.maxSize
+			ctx.GitHubClient = github.NewMockClient()
+			ctx.HomebrewClient = github.NewMockClient()
+
+			if err := (release.Pipe{}).Run(ctx); err != nil {
+				t.Fatalf("release Run() unexpected error: %v", err)
+			}
+
+			err := Pipe{}.Run(ctx)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("Run() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			cask, err := os.ReadFile(ctx.Artifacts.HomebrewCaskPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := "/releases/download/v1.2.3/" + tt.wantURL + `"`
+			if !strings.Contains(string(cask), want) {
+				t.Errorf("cask does not link to %s:\n%s", tt.wantURL, cask)
+			}
+		})
+	}
+}
+
+func TestPipeRenamedReleaseAsset(t *testing.T) {
+	ctx, tmpDir := newTestContext(t)
+	zipPath := ctx.Artifacts.Packages[0]
+	ctx.Artifacts.AssetNames[zipPath] = "TestApp-latest.zip"
+	ctx.HomebrewClient = github.NewMockClient()
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "testapp.rb"))
+	if err != nil {
+		t.Fatalf("failed to read generated cask file: %v", err)
+	}
+	want := `url "https://github.com/testowner/testrepo/releases/download/v1.2.3/TestApp-latest.zip"`
+	if !strings.Contains(string(content), want) {
+		t.Errorf("cask file missing %q\ngot:\n%s", want, content)
+	}
+}
+
+func TestPipeRollingCask(t *testing.T) {
+	ctx, tmpDir := newTestContext(t)
+	ctx.Config.Homebrew.Cask.Rolling = true
+	zipPath := ctx.Artifacts.Packages[0]
+	ctx.Artifacts.AssetNames[zipPath] = "TestApp.zip"
+	ctx.HomebrewClient = github.NewMockClient()
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "testapp.rb"))
+	if err != nil {
+		t.Fatalf("failed to read generated cask file: %v", err)
+	}
+	cask := string(content)
+	for _, want := range []string{
+		"version :latest",
+		"sha256 :no_check",
+		`url "https://github.com/testowner/testrepo/releases/latest/download/TestApp.zip"`,
+	} {
+		if !strings.Contains(cask, want) {
+			t.Errorf("cask file missing %q\ngot:\n%s", want, cask)
+		}
+	}
+	if strings.Contains(cask, `sha256 "`) {
+		t.Errorf("rolling cask contains a pinned sha256\ngot:\n%s", cask)
+	}
+}
+
 func TestPipeUpdateExistingCask(t *testing.T) {
 	ctx, _ := newTestContext(t)
 
@@ -208,6 +312,120 @@ func TestPipeUpdateExistingCask(t *testing.T) {
 	}
 }
 
+func TestPipeCommitMessageTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		existing bool
+		want     string
+	}{
+		{name: "default create", want: "Add testapp 1.2.3"},
+		{name: "default update", existing: true, want: "Update testapp to 1.2.3"},
+		{name: "custom create", template: "{{.Token}}: {{.Action}} v{{.Version}} [skip ci]", want: "testapp: Add v1.2.3 [skip ci]"},
+		{name: "custom update", template: "{{.Token}}: {{.Action}} v{{.Version}} [skip ci]", existing: true, want: "testapp: Update v1.2.3 [skip ci]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := newTestContext(t)
+			ctx.Config.Homebrew.Tap = config.TapConfig{
+				Owner:                 "tapowner",
+				Name:                  "homebrew-tap",
+				Token:                 "fake-token",
+				CommitMessageTemplate: tt.template,
+			}
+
+			mock := github.NewMockClient()
+			if tt.existing {
+				sha := "existing-sha"
+				mock.AddFileContent("tapowner", "homebrew-tap", "Casks/testapp.rb", &gogithub.RepositoryContent{SHA: &sha})
+			}
+			ctx.HomebrewClient = mock
+
+			if err := (Pipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+			if got := mock.CommitMessages["tapowner/homebrew-tap/Casks/testapp.rb"]; got != tt.want {
+				t.Errorf("commit message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipeUnchangedCaskSkipsCommit(t *testing.T) {
+	ctx, _ := newTestContext(t)
+	ctx.Config.Homebrew.Tap = config.TapConfig{
+		Owner: "tapowner",
+		Name:  "homebrew-tap",
+		Token: "fake-token",
+	}
+
+	// Render the cask once to learn what the tap would hold after this release
+	first := github.NewMockClient()
+	ctx.HomebrewClient = first
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	key := "tapowner/homebrew-tap/Casks/testapp.rb"
+	rendered := string(first.CreatedFiles[key])
+
+	tests := []struct {
+		name       string
+		existing   string
+		wantUpdate bool
+		wantLog    string
+	}{
+		{
+			name:       "unchanged cask is not committed",
+			existing:   rendered,
+			wantUpdate: false,
+			wantLog:    "is unchanged, skipping commit",
+		},
+		{
+			name:       "changed cask logs a diff and is committed",
+			existing:   strings.Replace(rendered, `version "1.2.3"`, `version "1.2.2"`, 1),
+			wantUpdate: true,
+			wantLog:    "+++ Casks/testapp.rb (new)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ctx.Logger.SetOutput(&buf)
+
+			mock := github.NewMockClient()
+			sha := "existing-sha"
+			mock.AddFileContent("tapowner", "homebrew-tap", "Casks/testapp.rb", &gogithub.RepositoryContent{
+				SHA:     &sha,
+				Content: &tt.existing,
+			})
+			ctx.HomebrewClient = mock
+
+			// Only a commit that changes the cask asks first
+			asked := 0
+			ctx.ConfirmFunc = func(string) error {
+				asked++
+				return nil
+			}
+
+			if err := (Pipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			if _, updated := mock.UpdatedFiles[key]; updated != tt.wantUpdate {
+				t.Errorf("cask updated = %v, want %v", updated, tt.wantUpdate)
+			}
+			if wantAsked := tt.wantUpdate; (asked > 0) != wantAsked {
+				t.Errorf("confirmations asked = %d, want one only when the cask changes", asked)
+			}
+			if !strings.Contains(buf.String(), tt.wantLog) {
+				t.Errorf("log output missing %q\ngot:\n%s", tt.wantLog, buf.String())
+			}
+		})
+	}
+}
+
 func TestPipeNoTapConfigured(t *testing.T) {
 	ctx, tmpDir := newTestContext(t)
 	// Tap fields are empty by default — no tap commit should happen
@@ -285,3 +503,404 @@ func TestPipeCommitToTapError(t *testing.T) {
 		t.Errorf("Run() error = %q, want error containing %q", err.Error(), "failed to commit cask to tap")
 	}
 }
+
+func TestPipeTapPushDeclined(t *testing.T) {
+	ctx, _ := newTestContext(t)
+
+	ctx.Config.Homebrew.Tap = config.TapConfig{
+		Owner: "tapowner",
+		Name:  "homebrew-tap",
+		Token: "fake-token",
+	}
+
+	mock := github.NewMockClient()
+	mock.ContentsError = &github.NotFoundError{Message: "not found"}
+	ctx.HomebrewClient = mock
+
+	var asked []string
+	ctx.ConfirmFunc = func(action string) error {
+		asked = append(asked, action)
+		return fmt.Errorf("did not %s: cancelled", action)
+	}
+
+	err := Pipe{}.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Fatalf("Run() error = %v, want the declined confirmation", err)
+	}
+	if want := "push the testapp cask to the tap tapowner/homebrew-tap"; len(asked) != 1 || asked[0] != want {
+		t.Errorf("asked %q, want %q", asked, want)
+	}
+	if len(mock.CreatedFiles) != 0 || len(mock.UpdatedFiles) != 0 {
+		t.Errorf("cask was committed after the push was declined")
+	}
+}
+
+// injectTapClients replaces newTapClient so each tap token gets its own mock.
+func injectTapClients(t *testing.T, clients map[string]*github.MockClient) {
+	t.Helper()
+	original := newTapClient
+	newTapClient = func(_ *macCtx.Context, token string) (github.ClientInterface, error) {
+		client, ok := clients[token]
+		if !ok {
+			return nil, fmt.Errorf("unexpected tap token %q", token)
+		}
+		return client, nil
+	}
+	t.Cleanup(func() { newTapClient = original })
+}
+
+func TestPipeMultipleTaps(t *testing.T) {
+	ctx, _ := newTestContext(t)
+	ctx.Config.Homebrew.Tap = config.TapConfig{Owner: "org", Name: "homebrew-tap", Token: "org-token"}
+	ctx.Config.Homebrew.Taps = []config.TapConfig{
+		{Owner: "me", Name: "homebrew-tap", Token: "my-token"},
+	}
+
+	orgTap, myTap := github.NewMockClient(), github.NewMockClient()
+	injectTapClients(t, map[string]*github.MockClient{"org-token": orgTap, "my-token": myTap})
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	for key, mock := range map[string]*github.MockClient{
+		"org/homebrew-tap/Casks/testapp.rb": orgTap,
+		"me/homebrew-tap/Casks/testapp.rb":  myTap,
+	} {
+		content, ok := mock.CreatedFiles[key]
+		if !ok {
+			t.Errorf("expected %s to be created, created files: %v", key, mock.CreatedFiles)
+			continue
+		}
+		if !strings.Contains(string(content), `cask "testapp" do`) {
+			t.Errorf("%s has wrong content:\n%s", key, content)
+		}
+		if len(mock.CreatedFiles) != 1 {
+			t.Errorf("created files = %v, want only %s", mock.CreatedFiles, key)
+		}
+	}
+}
+
+func TestPipeMultipleTapsAggregatesErrors(t *testing.T) {
+	ctx, _ := newTestContext(t)
+	ctx.Config.Homebrew.Taps = []config.TapConfig{
+		{Owner: "org", Name: "homebrew-tap", Token: "org-token"},
+		{Owner: "me", Name: "homebrew-tap", Token: "my-token"},
+	}
+
+	orgTap, myTap := github.NewMockClient(), github.NewMockClient()
+	orgTap.SetError(fmt.Errorf("permission denied"))
+	injectTapClients(t, map[string]*github.MockClient{"org-token": orgTap, "my-token": myTap})
+
+	err := Pipe{}.Run(ctx)
+	if err == nil {
+		t.Fatal("Run() expected error, got nil")
+	}
+	for _, want := range []string{"failed to publish the cask to 1 of 2 taps", "org/homebrew-tap", "permission denied"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Run() error = %q, want error containing %q", err.Error(), want)
+		}
+	}
+
+	// The failing tap must not stop the other from being updated
+	if _, ok := myTap.CreatedFiles["me/homebrew-tap/Casks/testapp.rb"]; !ok {
+		t.Errorf("expected the second tap to receive the cask, created files: %v", myTap.CreatedFiles)
+	}
+}
+
+func TestPipeAlsoLatest(t *testing.T) {
+	ctx, _ := newTestContext(t)
+	ctx.Config.Homebrew.Tap = config.TapConfig{
+		Owner:      "tapowner",
+		Name:       "homebrew-tap",
+		Token:      "fake-token",
+		AlsoLatest: true,
+	}
+
+	mock := github.NewMockClient()
+	// The @latest cask already exists and is updated in place
+	sha := "latest-sha"
+	mock.AddFileContent("tapowner", "homebrew-tap", "Casks/testapp@latest.rb", &gogithub.RepositoryContent{
+		SHA: &sha,
+	})
+	ctx.HomebrewClient = mock
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	versioned, exists := mock.CreatedFiles["tapowner/homebrew-tap/Casks/testapp.rb"]
+	if !exists {
+		t.Fatalf("expected versioned cask to be created, created files: %v", mock.CreatedFiles)
+	}
+	if !strings.Contains(string(versioned), `cask "testapp" do`) {
+		t.Errorf("versioned cask has wrong token\ngot:\n%s", versioned)
+	}
+
+	latest, exists := mock.UpdatedFiles["tapowner/homebrew-tap/Casks/testapp@latest.rb"]
+	if !exists {
+		t.Fatalf("expected @latest cask to be updated, updated files: %v", mock.UpdatedFiles)
+	}
+	for _, want := range []string{`cask "testapp@latest" do`, `version "1.2.3"`} {
+		if !strings.Contains(string(latest), want) {
+			t.Errorf("@latest cask missing %q\ngot:\n%s", want, latest)
+		}
+	}
+
+	// The local cask file is still the versioned one
+	if filepath.Base(ctx.Artifacts.HomebrewCaskPath) != "testapp.rb" {
+		t.Errorf("HomebrewCaskPath = %q, want testapp.rb", ctx.Artifacts.HomebrewCaskPath)
+	}
+}
+
+func TestPipeAlsoLatestSkippedForBetaChannel(t *testing.T) {
+	ctx, _ := newTestContext(t)
+	ctx.Version = "v1.3.0-beta.1"
+	ctx.Config.Homebrew.Tap = config.TapConfig{
+		Owner:      "tapowner",
+		Name:       "homebrew-tap",
+		Token:      "fake-token",
+		AlsoLatest: true,
+		Beta: config.BetaTapConfig{
+			Owner: "tapowner",
+			Name:  "homebrew-beta",
+			Token: "fake-beta-token",
+		},
+	}
+
+	mock := github.NewMockClient()
+	ctx.HomebrewClient = mock
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if len(mock.CreatedFiles) != 1 {
+		t.Errorf("created files = %v, want only the beta cask", mock.CreatedFiles)
+	}
+}
+
+func TestPipeChannelRouting(t *testing.T) {
+	stableTap := config.TapConfig{
+		Owner: "tapowner",
+		Name:  "homebrew-tap",
+		Token: "fake-token",
+		Beta: config.BetaTapConfig{
+			Owner: "tapowner",
+			Name:  "homebrew-beta",
+			Token: "fake-beta-token",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		version       string
+		tap           config.TapConfig
+		wantKey       string
+		wantLocalFile string
+		wantToken     string
+	}{
+		{
+			name:          "stable version uses stable tap",
+			version:       "v1.2.3",
+			tap:           stableTap,
+			wantKey:       "tapowner/homebrew-tap/Casks/testapp.rb",
+			wantLocalFile: "testapp.rb",
+			wantToken:     `cask "testapp" do`,
+		},
+		{
+			name:          "prerelease uses beta tap and suffixed token",
+			version:       "v1.3.0-beta.1",
+			tap:           stableTap,
+			wantKey:       "tapowner/homebrew-beta/Casks/testapp@beta.rb",
+			wantLocalFile: "testapp@beta.rb",
+			wantToken:     `cask "testapp@beta" do`,
+		},
+		{
+			name:    "prerelease without beta tap uses stable tap",
+			version: "v1.3.0-rc.1",
+			tap: config.TapConfig{
+				Owner: "tapowner",
+				Name:  "homebrew-tap",
+				Token: "fake-token",
+			},
+			wantKey:       "tapowner/homebrew-tap/Casks/testapp.rb",
+			wantLocalFile: "testapp.rb",
+			wantToken:     `cask "testapp" do`,
+		},
+		{
+			name:    "prerelease with only beta tap configured",
+			version: "v2.0.0-alpha",
+			tap: config.TapConfig{
+				Beta: stableTap.Beta,
+			},
+			wantKey:       "tapowner/homebrew-beta/Casks/testapp@beta.rb",
+			wantLocalFile: "testapp@beta.rb",
+			wantToken:     `cask "testapp@beta" do`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, tmpDir := newTestContext(t)
+			ctx.Version = tt.version
+			ctx.Config.Homebrew.Tap = tt.tap
+
+			mock := github.NewMockClient()
+			ctx.HomebrewClient = mock
+
+			if err := (Pipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			created, exists := mock.CreatedFiles[tt.wantKey]
+			if !exists {
+				t.Fatalf("expected cask at %q, created files: %v", tt.wantKey, mock.CreatedFiles)
+			}
+			if len(mock.CreatedFiles) != 1 {
+				t.Errorf("created %d files, want 1", len(mock.CreatedFiles))
+			}
+			if !strings.Contains(string(created), tt.wantToken) {
+				t.Errorf("committed cask missing %q\ngot:\n%s", tt.wantToken, created)
+			}
+
+			wantPath := filepath.Join(tmpDir, tt.wantLocalFile)
+			if ctx.Artifacts.HomebrewCaskPath != wantPath {
+				t.Errorf("HomebrewCaskPath = %q, want %q", ctx.Artifacts.HomebrewCaskPath, wantPath)
+			}
+		})
+	}
+}
+
+func TestPipeCaskToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		caskName  string
+		token     string
+		wantToken string
+	}{
+		{name: "normalized from display name", caskName: "Test App", wantToken: "test-app"},
+		{name: "explicit override", caskName: "Test App", token: "testapp-pro", wantToken: "testapp-pro"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, tmpDir := newTestContext(t)
+			ctx.Config.Homebrew.Cask.Name = tt.caskName
+			ctx.Config.Homebrew.Cask.Token = tt.token
+
+			if err := (Pipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			wantPath := filepath.Join(tmpDir, tt.wantToken+".rb")
+			if ctx.Artifacts.HomebrewCaskPath != wantPath {
+				t.Errorf("HomebrewCaskPath = %q, want %q", ctx.Artifacts.HomebrewCaskPath, wantPath)
+			}
+			content, err := os.ReadFile(wantPath)
+			if err != nil {
+				t.Fatalf("failed to read cask: %v", err)
+			}
+			if !strings.Contains(string(content), fmt.Sprintf("cask %q do", tt.wantToken)) {
+				t.Errorf("cask missing token %q\ngot:\n%s", tt.wantToken, content)
+			}
+		})
+	}
+}
+
+func TestPipeNamedPackages(t *testing.T) {
+	ctx, tmpDir := newTestContext(t)
+
+	// archive.formats: [dmg, {type: zip, name_template: "{{.Name}}-{{.Version}}-app"}]
+	// with include_dsym, listed in the order the archive pipe produces them
+	dmgPath := filepath.Join(tmpDir, "TestApp-v1.2.3.dmg")
+	zipPath := filepath.Join(tmpDir, "TestApp-v1.2.3-app.zip")
+	dsymPath := filepath.Join(tmpDir, "TestApp-v1.2.3.dSYM.zip")
+	for _, path := range []string{dmgPath, zipPath, dsymPath} {
+		if err := os.WriteFile(path, []byte(filepath.Base(path)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx.Artifacts.Packages = []string{dmgPath, zipPath, dsymPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(ctx.Artifacts.HomebrewCaskPath)
+	if err != nil {
+		t.Fatalf("failed to read cask: %v", err)
+	}
+	want := `url "https://github.com/testowner/testrepo/releases/download/v1.2.3/TestApp-v1.2.3-app.zip"`
+	if !strings.Contains(string(content), want) {
+		t.Errorf("cask missing %q\ngot:\n%s", want, content)
+	}
+}
+
+func TestPipeDisplayName(t *testing.T) {
+	ctx, _ := newTestContext(t)
+	ctx.Config.Project.DisplayName = "Test App"
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(ctx.Artifacts.HomebrewCaskPath)
+	if err != nil {
+		t.Fatalf("failed to read cask: %v", err)
+	}
+	if want := `name "Test App"`; !strings.Contains(string(content), want) {
+		t.Errorf("cask missing %q\ngot:\n%s", want, content)
+	}
+}
+
+func TestPipeAppNameWithSpaces(t *testing.T) {
+	ctx, tmpDir := newTestContext(t)
+	ctx.Artifacts.AppPath = "/path/to/My App.app"
+
+	zipPath := filepath.Join(tmpDir, "My-App-1.2.3.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip-content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(ctx.Artifacts.HomebrewCaskPath)
+	if err != nil {
+		t.Fatalf("failed to read cask: %v", err)
+	}
+	for _, want := range []string{
+		`app "My App.app"`,
+		`url "https://github.com/testowner/testrepo/releases/download/v1.2.3/My-App-1.2.3.zip"`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("cask missing %q\ngot:\n%s", want, content)
+		}
+	}
+}
+
+func TestPipeNormalizeDesc(t *testing.T) {
+	for _, normalize := range []bool{false, true} {
+		ctx, _ := newTestContext(t)
+		ctx.Config.Homebrew.Cask.Desc = "A test application."
+		ctx.Config.Homebrew.Cask.NormalizeDesc = normalize
+
+		if err := (Pipe{}).Run(ctx); err != nil {
+			t.Fatalf("Run() unexpected error: %v", err)
+		}
+
+		content, err := os.ReadFile(ctx.Artifacts.HomebrewCaskPath)
+		if err != nil {
+			t.Fatalf("failed to read cask: %v", err)
+		}
+		want := `desc "A test application."`
+		if normalize {
+			want = `desc "Test application"`
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("normalize_desc %v: cask missing %q\ngot:\n%s", normalize, want, content)
+		}
+	}
+}
@@ -1,44 +1,102 @@
 package notarize
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/env"
+	"github.com/macreleaser/macreleaser/pkg/notarize"
 	"github.com/macreleaser/macreleaser/pkg/validate"
 )
 
+// appSpecificPasswordPattern matches the format Apple generates app-specific
+// passwords in.
+var appSpecificPasswordPattern = regexp.MustCompile(`^[a-z]{4}(-[a-z]{4}){3}$`)
+
 // CheckPipe validates notarization configuration
 type CheckPipe struct{}
 
 func (CheckPipe) String() string { return "validating notarization configuration" }
 
+func (CheckPipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (CheckPipe) Run(ctx *context.Context) error {
-	if ctx.SkipNotarize {
-		return skipError("notarization skipped via --skip-notarize")
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
 	}
 
 	cfg := ctx.Config.Notarize
 
-	if err := env.CheckResolved(cfg.AppleID, "notarize.apple_id"); err != nil {
+	if cfg.MaxConcurrent < 0 {
+		return fmt.Errorf("notarize.max_concurrent must not be negative, got %d", cfg.MaxConcurrent)
+	}
+	if _, err := notarize.ParsePollOptions(cfg.PollInterval, cfg.Timeout); err != nil {
+		return err
+	}
+	if !cfg.Async && (cfg.PollInterval != "" || cfg.Timeout != "") {
+		ctx.Logger.Warn("notarize.poll_interval and notarize.timeout only apply with notarize.async: true")
+	}
+	if cfg.XcrunPath != "" {
+		if err := env.CheckResolved(cfg.XcrunPath, "notarize.xcrun_path"); err != nil {
+			return err
+		}
+		if err := validate.Executable(cfg.XcrunPath, "notarize.xcrun_path"); err != nil {
+			return err
+		}
+	}
+
+	// The credentials of a notarize profile replace the top-level ones
+	field := "notarize"
+	if cfg.Profile != "" {
+		if err := env.CheckResolved(cfg.Profile, "notarize.profile"); err != nil {
+			return err
+		}
+		field = "notarize.profiles." + cfg.Profile
+	}
+	cfg, err := cfg.ActiveProfile()
+	if err != nil {
+		return err
+	}
+
+	// An App Store Connect API key replaces the Apple ID credentials
+	if cfg.ASCKeyFile != "" {
+		if err := env.CheckResolved(cfg.ASCKeyFile, field+".asc_key_file"); err != nil {
+			return err
+		}
+		if _, err := notarize.LoadASCKeyFile(cfg.ASCKeyFile); err != nil {
+			return err
+		}
+		ctx.Logger.Debug("Notarization configuration validated successfully")
+		return nil
+	}
+
+	if err := env.CheckResolved(cfg.AppleID, field+".apple_id"); err != nil {
 		return err
 	}
-	if err := env.CheckResolved(cfg.TeamID, "notarize.team_id"); err != nil {
+	if err := env.CheckResolved(cfg.TeamID, field+".team_id"); err != nil {
 		return err
 	}
-	if err := env.CheckResolved(cfg.Password, "notarize.password"); err != nil {
+	if err := env.CheckResolved(cfg.Password, field+".password"); err != nil {
 		return err
 	}
 
-	if err := validate.RequiredString(cfg.AppleID, "notarize.apple_id"); err != nil {
+	if err := validate.RequiredString(cfg.AppleID, field+".apple_id"); err != nil {
 		return err
 	}
 
-	if err := validate.RequiredString(cfg.TeamID, "notarize.team_id"); err != nil {
+	if err := validate.RequiredString(cfg.TeamID, field+".team_id"); err != nil {
 		return err
 	}
 
-	if err := validate.RequiredString(cfg.Password, "notarize.password"); err != nil {
+	if err := validate.RequiredString(cfg.Password, field+".password"); err != nil {
 		return err
 	}
+	// Only warn: a mistyped format would fail at submission anyway, and an
+	// error here could block an edge case the pattern does not anticipate
+	if !appSpecificPasswordPattern.MatchString(cfg.Password) {
+		ctx.Logger.Warnf("%s.password does not look like an app-specific password (xxxx-xxxx-xxxx-xxxx) — notarytool rejects your regular Apple ID password; generate one at https://appleid.apple.com", field)
+	}
 
 	ctx.Logger.Debug("Notarization configuration validated successfully")
 	return nil
@@ -1,7 +1,10 @@
 package notarize
 
 import (
+	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -31,6 +34,59 @@ func TestCheckPipe(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "max_concurrent set",
+			config: &config.Config{
+				Notarize: config.NotarizeConfig{
+					AppleID:       "test@example.com",
+					TeamID:        "TEAM123",
+					Password:      "xxxx-xxxx-xxxx-xxxx",
+					MaxConcurrent: 2,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative max_concurrent",
+			config: &config.Config{
+				Notarize: config.NotarizeConfig{
+					AppleID:       "test@example.com",
+					TeamID:        "TEAM123",
+					Password:      "xxxx-xxxx-xxxx-xxxx",
+					MaxConcurrent: -1,
+				},
+			},
+			wantErr: true,
+			errMsg:  "notarize.max_concurrent must not be negative",
+		},
+		{
+			name: "async with poll settings",
+			config: &config.Config{
+				Notarize: config.NotarizeConfig{
+					AppleID:      "test@example.com",
+					TeamID:       "TEAM123",
+					Password:     "xxxx-xxxx-xxxx-xxxx",
+					Async:        true,
+					PollInterval: "1m",
+					Timeout:      "2h",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid timeout",
+			config: &config.Config{
+				Notarize: config.NotarizeConfig{
+					AppleID:  "test@example.com",
+					TeamID:   "TEAM123",
+					Password: "xxxx-xxxx-xxxx-xxxx",
+					Async:    true,
+					Timeout:  "-1h",
+				},
+			},
+			wantErr: true,
+			errMsg:  `invalid notarize.timeout "-1h": must be positive`,
+		},
 		{
 			name: "missing apple_id",
 			config: &config.Config{
@@ -100,6 +156,161 @@ func TestCheckPipe(t *testing.T) {
 	}
 }
 
+func TestCheckPipeASCKeyFile(t *testing.T) {
+	logger := logrus.New()
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "asc.json")
+	if err := os.WriteFile(valid, []byte(`{"key_id": "ABC123", "issuer_id": "issuer", "key_path": "AuthKey_ABC123.p8"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	incomplete := filepath.Join(dir, "incomplete.json")
+	if err := os.WriteFile(incomplete, []byte(`{"key_id": "ABC123"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The key file replaces apple_id, team_id, and password
+	ctx := macCtx.NewContext(context.Background(), &config.Config{
+		Notarize: config.NotarizeConfig{ASCKeyFile: valid},
+	}, logger)
+	if err := (CheckPipe{}).Run(ctx); err != nil {
+		t.Errorf("Run() unexpected error: %v", err)
+	}
+
+	ctx = macCtx.NewContext(context.Background(), &config.Config{
+		Notarize: config.NotarizeConfig{ASCKeyFile: incomplete},
+	}, logger)
+	if err := (CheckPipe{}).Run(ctx); err == nil || !strings.Contains(err.Error(), "missing issuer_id") {
+		t.Errorf("Run() error = %v, want missing issuer_id error", err)
+	}
+}
+
+func TestCheckPipeNotarizeProfile(t *testing.T) {
+	logger := logrus.New()
+	base := config.NotarizeConfig{
+		AppleID:  "dev@example.com",
+		TeamID:   "TEAM123",
+		Password: "xxxx-xxxx-xxxx-xxxx",
+		Profiles: map[string]config.NotarizeCredentials{
+			"appstore":   {AppleID: "store@example.com", TeamID: "STORE99", Password: "yyyy-yyyy-yyyy-yyyy"},
+			"incomplete": {AppleID: "store@example.com", TeamID: "STORE99"},
+		},
+	}
+
+	tests := []struct {
+		profile string
+		errMsg  string
+	}{
+		{profile: "appstore"},
+		{profile: "incomplete", errMsg: "notarize.profiles.incomplete.password is required"},
+		{profile: "missing", errMsg: `notarize.profile "missing" is not defined in notarize.profiles`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.profile, func(t *testing.T) {
+			cfg := base
+			cfg.Profile = tt.profile
+			ctx := macCtx.NewContext(context.Background(), &config.Config{Notarize: cfg}, logger)
+			err := CheckPipe{}.Run(ctx)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Run() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestCheckPipeXcrunPath(t *testing.T) {
+	logger := logrus.New()
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "xcrun")
+	if err := os.WriteFile(executable, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	plain := filepath.Join(dir, "xcrun.txt")
+	if err := os.WriteFile(plain, []byte("not a program"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		errMsg string
+	}{
+		{name: "executable", path: executable},
+		{name: "missing", path: filepath.Join(dir, "missing"), errMsg: "invalid notarize.xcrun_path"},
+		{name: "not executable", path: plain, errMsg: "is not an executable file"},
+		{name: "directory", path: dir, errMsg: "is not an executable file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := macCtx.NewContext(context.Background(), &config.Config{
+				Notarize: config.NotarizeConfig{
+					AppleID:   "test@example.com",
+					TeamID:    "TEAM123",
+					Password:  "xxxx-xxxx-xxxx-xxxx",
+					XcrunPath: tt.path,
+				},
+			}, logger)
+			err := CheckPipe{}.Run(ctx)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Run() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestCheckPipeWarnsOnPasswordFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantWarn bool
+	}{
+		{name: "app-specific password", password: "abcd-efgh-ijkl-mnop", wantWarn: false},
+		{name: "regular password", password: "Hunter2!", wantWarn: true},
+		{name: "uppercase groups", password: "ABCD-EFGH-IJKL-MNOP", wantWarn: true},
+		{name: "missing group", password: "abcd-efgh-ijkl", wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&buf)
+
+			cfg := &config.Config{
+				Notarize: config.NotarizeConfig{
+					AppleID:  "test@example.com",
+					TeamID:   "TEAM123",
+					Password: tt.password,
+				},
+			}
+			if err := (CheckPipe{}).Run(macCtx.NewContext(context.Background(), cfg, logger)); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			gotWarn := strings.Contains(buf.String(), "does not look like an app-specific password")
+			if gotWarn != tt.wantWarn {
+				t.Errorf("warning logged = %v, want %v\nlog: %s", gotWarn, tt.wantWarn, buf.String())
+			}
+			if strings.Contains(buf.String(), tt.password) {
+				t.Errorf("log contains the password:\n%s", buf.String())
+			}
+		})
+	}
+}
+
 func TestCheckPipeSkipNotarize(t *testing.T) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.DebugLevel)
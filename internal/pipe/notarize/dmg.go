@@ -0,0 +1,69 @@
+package notarize
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/sign"
+	"github.com/macreleaser/macreleaser/pkg/validate"
+)
+
+// DMGPipe signs, notarizes, and staples the disk images built by the
+// archive pipe. It runs after packaging, since the .app inside a DMG must
+// already carry its own stapled ticket.
+type DMGPipe struct{}
+
+func (DMGPipe) String() string { return "notarizing disk images" }
+
+func (DMGPipe) Skip(ctx *context.Context) string { return dmgSkipReason(ctx) }
+
+func (DMGPipe) Run(ctx *context.Context) error {
+	if reason := dmgSkipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
+	dmgs := selectDMGs(ctx.Artifacts.Packages)
+	if len(dmgs) == 0 {
+		return fmt.Errorf("no .dmg found to notarize — ensure the archive step completed successfully")
+	}
+
+	creds, err := submitCredentials(ctx.Config.Notarize)
+	if err != nil {
+		return err
+	}
+
+	identity, keychain := ctx.Config.Sign.Identity, ctx.Config.Sign.Keychain
+	for _, dmg := range dmgs {
+		// Gatekeeper rejects a disk image without a signature of its own,
+		// even when the .app inside it is notarized
+		ctx.Logger.Infof("Signing %s", filepath.Base(dmg))
+		output, err := sign.RunCodesignItem(ctx.StdCtx, identity, dmg, false, keychain)
+		if err != nil {
+			ctx.Logger.Debug(output)
+			return err
+		}
+		ctx.Logger.Debug(output)
+
+		if err := submitAndStaple(ctx, dmg, dmg, creds); err != nil {
+			return err
+		}
+		if err := assess(ctx, dmg); err != nil {
+			return err
+		}
+		ctx.Logger.Infof("Notarization complete: %s", dmg)
+	}
+	return nil
+}
+
+// dmgSkipReason returns why DMGPipe skips, or "" when it runs: it skips
+// with the notarize pipe, and when no dmg format is configured.
+func dmgSkipReason(ctx *context.Context) string {
+	if reason := skipReason(ctx); reason != "" {
+		return reason
+	}
+	if !validate.ContainsAny(ctx.Config.Archive.Formats.Types(), "dmg") {
+		return "no dmg archive format configured"
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package notarize
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macCtx "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/sirupsen/logrus"
+)
+
+func dmgTestConfig() *config.Config {
+	return &config.Config{
+		Sign: config.SignConfig{Identity: "Developer ID Application: John Doe (TEAM123)"},
+		Notarize: config.NotarizeConfig{
+			AppleID:  "dev@example.com",
+			TeamID:   "TEAM123",
+			Password: "xxxx-xxxx-xxxx-xxxx",
+		},
+		Archive: config.ArchiveConfig{Formats: config.ArchiveFormats{{Type: "zip"}, {Type: "dmg"}}},
+	}
+}
+
+func TestDMGPipeSkip(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats config.ArchiveFormats
+		skip    bool
+		want    string
+	}{
+		{name: "dmg configured", formats: config.ArchiveFormats{{Type: "dmg"}}, want: ""},
+		{name: "zip only", formats: config.ArchiveFormats{{Type: "zip"}}, want: "no dmg archive format configured"},
+		{name: "skip notarize", formats: config.ArchiveFormats{{Type: "dmg"}}, skip: true, want: "notarization skipped via --skip-notarize"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := macCtx.NewContext(context.Background(), &config.Config{
+				Archive: config.ArchiveConfig{Formats: tt.formats},
+			}, logrus.New())
+			ctx.SkipNotarize = tt.skip
+			if got := (DMGPipe{}).Skip(ctx); got != tt.want {
+				t.Errorf("Skip() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDMGPipeRun(t *testing.T) {
+	ctx := macCtx.NewContext(context.Background(), dmgTestConfig(), logrus.New())
+	ctx.Artifacts.AppPath = "dist/MyApp.app"
+	ctx.Artifacts.Packages = []string{"dist/MyApp-1.0.0.zip", "dist/MyApp-1.0.0.dmg"}
+
+	fake := &command.Fake{}
+	ctx.StdCtx = command.WithRunner(ctx.StdCtx, fake)
+
+	if err := (DMGPipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	want := []string{
+		"codesign --force --sign Developer ID Application: John Doe (TEAM123) dist/MyApp-1.0.0.dmg",
+		"xcrun notarytool submit dist/MyApp-1.0.0.dmg",
+		"xcrun stapler staple dist/MyApp-1.0.0.dmg",
+		"spctl --assess --type open --context context:primary-signature",
+	}
+	got := fake.Commands()
+	if len(got) != len(want) {
+		t.Fatalf("ran %d commands, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("command %d = %q, want prefix %q", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestDMGPipeNoDMG(t *testing.T) {
+	ctx := macCtx.NewContext(context.Background(), dmgTestConfig(), logrus.New())
+	ctx.Artifacts.Packages = []string{"dist/MyApp-1.0.0.zip"}
+
+	err := DMGPipe{}.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "no .dmg found to notarize") {
+		t.Errorf("Run() error = %v, want error containing %q", err, "no .dmg found to notarize")
+	}
+}
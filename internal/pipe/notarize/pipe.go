@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/macreleaser/macreleaser/pkg/archive"
+	"github.com/macreleaser/macreleaser/pkg/config"
 	"github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/notarize"
 )
@@ -19,65 +20,203 @@ type skipError string
 func (e skipError) Error() string { return string(e) }
 func (e skipError) IsSkip() bool  { return true }
 
+// skipReason returns why the check and execution pipes skip, or "" when
+// they run. It depends only on flags and configuration.
+func skipReason(ctx *context.Context) string {
+	if ctx.AppOnly {
+		return "skipped with --app-only"
+	}
+	if ctx.SkipNotarize {
+		return "notarization skipped via --skip-notarize"
+	}
+	return ""
+}
+
 // Pipe executes Apple notarization on the signed .app bundle.
 type Pipe struct{}
 
 func (Pipe) String() string { return "notarizing application" }
 
+func (Pipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (Pipe) Run(ctx *context.Context) error {
-	if ctx.SkipNotarize {
-		return skipError("notarization skipped via --skip-notarize")
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
 	}
 
+	// The .app is notarized whenever there is one. Without it, as when the
+	// notarize command is given an installer or a disk image, the .pkg or
+	// .dmg is submitted directly.
+	directPath := ""
 	if ctx.Artifacts.AppPath == "" {
-		return fmt.Errorf("no .app found to notarize — ensure the build and sign steps completed successfully")
+		directPath = selectPkg(ctx.Artifacts.Packages)
+		if dmgs := selectDMGs(ctx.Artifacts.Packages); directPath == "" && len(dmgs) > 0 {
+			directPath = dmgs[0]
+		}
+		if directPath == "" {
+			return fmt.Errorf("no .app found to notarize — ensure the build and sign steps completed successfully")
+		}
 	}
 
-	appleID := ctx.Config.Notarize.AppleID
-	teamID := ctx.Config.Notarize.TeamID
-	password := ctx.Config.Notarize.Password
+	creds, err := submitCredentials(ctx.Config.Notarize)
+	if err != nil {
+		return err
+	}
+	if profile := ctx.Config.Notarize.Profile; profile != "" {
+		ctx.Logger.Infof("Using notarize profile %q", profile)
+	}
 
-	// Create temporary ZIP for notarization submission
-	appName := strings.TrimSuffix(filepath.Base(ctx.Artifacts.AppPath), ".app")
-	zipPath := filepath.Join(ctx.Artifacts.BuildOutputDir, appName+"-notarize.zip")
+	// notarytool cannot take a bare .app, so it is zipped first
+	submitPath := directPath
+	zipPath := ""
+	if submitPath == "" {
+		zipPath = notarizeZipPath(ctx.Artifacts.AppPath, ctx.Artifacts.BuildOutputDir)
+
+		ctx.Logger.Info("Creating temporary ZIP for notarization submission")
+		if err := archive.CreateZip(ctx.StdCtx, ctx.Artifacts.AppPath, zipPath); err != nil {
+			return fmt.Errorf("failed to create temp ZIP for notarization: %w", err)
+		}
+		submitPath = zipPath
+		defer cleanupSubmission(ctx, zipPath)
+	}
 
-	ctx.Logger.Info("Creating temporary ZIP for notarization submission")
-	if err := archive.CreateZip(ctx.Artifacts.AppPath, zipPath); err != nil {
-		return fmt.Errorf("failed to create temp ZIP for notarization: %w", err)
+	notarized := ctx.Artifacts.AppPath
+	if directPath != "" {
+		notarized = directPath
+	}
+	if err := submitAndStaple(ctx, submitPath, notarized, creds); err != nil {
+		return err
 	}
 
-	// Submit to Apple notary service
+	// Guard against stapling having altered the signed bundle
+	if directPath == "" && ctx.Config.Notarize.VerifyAfterStaple {
+		ctx.Logger.Info("Verifying signature after stapling")
+		output, err := notarize.RunVerifyStapled(ctx.StdCtx, ctx.Artifacts.AppPath)
+		ctx.Logger.Debug(output)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := assess(ctx, notarized); err != nil {
+		return err
+	}
+
+	ctx.Logger.Infof("Notarization complete: %s", notarized)
+	return nil
+}
+
+// submitAndStaple submits submitPath to Apple's notary service and, once it
+// is accepted, staples the ticket to stapled: the .app for its submission
+// ZIP, or the submitted file itself.
+func submitAndStaple(ctx *context.Context, submitPath, stapled string, creds notarize.Credentials) error {
 	ctx.Logger.Info("Submitting to Apple notary service (this may take several minutes)...")
-	output, err := notarize.RunSubmit(zipPath, appleID, teamID, password)
+	output, err := submit(ctx, submitPath, creds)
 	if err != nil {
 		ctx.Logger.Debug(output)
 		return fmt.Errorf("notarization failed: %w", err)
 	}
 	ctx.Logger.Debug(output)
 
-	// Staple the notarization ticket to the .app
-	ctx.Logger.Info("Stapling notarization ticket")
-	output, err = notarize.RunStaple(ctx.Artifacts.AppPath)
+	ctx.Logger.Infof("Stapling notarization ticket to %s", filepath.Base(stapled))
+	output, err = notarize.RunStaple(ctx.StdCtx, ctx.Config.Notarize.XcrunPath, stapled)
 	if err != nil {
 		ctx.Logger.Debug(output)
 		return fmt.Errorf("stapling failed: %w", err)
 	}
 	ctx.Logger.Debug(output)
+	return nil
+}
+
+// assess verifies with Gatekeeper that a notarized artifact launches,
+// installs, or opens, using the assessment type for its kind.
+func assess(ctx *context.Context, path string) error {
+	assessType := notarize.AssessTypeFor(path)
+	ctx.Logger.Infof("Verifying Gatekeeper assessment of %s (%s)", filepath.Base(path), assessType)
+	output, err := notarize.RunAssess(ctx.StdCtx, path, assessType)
+	ctx.Logger.Debug(output)
+	return err
+}
+
+// submit uploads path to Apple's notary service and waits for the verdict:
+// with notarytool's --wait, or with notarize.async by polling the
+// submission's status and logging each change.
+func submit(ctx *context.Context, path string, creds notarize.Credentials) (string, error) {
+	cfg := ctx.Config.Notarize
+	queue := notarize.SharedQueue(cfg.MaxConcurrent)
+	onRetry := func(attempt int, err error) {
+		ctx.Logger.Debug(err)
+		ctx.Logger.Warnf("Notarization upload failed with a network error (attempt %d/%d), retrying", attempt, notarize.SubmitAttempts)
+	}
+	if !cfg.Async {
+		return queue.Submit(ctx.StdCtx, cfg.XcrunPath, path, creds, onRetry)
+	}
 
-	// Verify with Gatekeeper
-	ctx.Logger.Info("Verifying Gatekeeper assessment")
-	output, err = notarize.RunAssess(ctx.Artifacts.AppPath)
+	poll, err := notarize.ParsePollOptions(cfg.PollInterval, cfg.Timeout)
 	if err != nil {
-		ctx.Logger.Debug(output)
-		return fmt.Errorf("Gatekeeper assessment failed: %w", err) //nolint:staticcheck // proper noun
+		return "", err
 	}
-	ctx.Logger.Debug(output)
+	poll.OnStatus = func(status string) {
+		ctx.Logger.Infof("Notarization status: %s", status)
+	}
+	return queue.SubmitAndPoll(ctx.StdCtx, cfg.XcrunPath, path, creds, onRetry, poll)
+}
 
-	// Clean up temp ZIP
-	if removeErr := os.Remove(zipPath); removeErr != nil {
-		ctx.Logger.Warnf("Failed to remove temp ZIP %s: %v", zipPath, removeErr)
+// submitCredentials returns the credentials notarytool authenticates with,
+// taken from the notarize.profile entry of notarize.profiles when one is
+// selected: the App Store Connect API key in asc_key_file when set,
+// otherwise the Apple ID, team ID, and app-specific password.
+func submitCredentials(cfg config.NotarizeConfig) (notarize.Credentials, error) {
+	cfg, err := cfg.ActiveProfile()
+	if err != nil {
+		return notarize.Credentials{}, err
+	}
+	if cfg.ASCKeyFile != "" {
+		return notarize.LoadASCKeyFile(cfg.ASCKeyFile)
 	}
+	return notarize.Credentials{AppleID: cfg.AppleID, TeamID: cfg.TeamID, Password: cfg.Password}, nil
+}
 
-	ctx.Logger.Infof("Notarization complete: %s", ctx.Artifacts.AppPath)
-	return nil
+// cleanupSubmission removes the temporary ZIP submitted for the .app, or
+// keeps it and logs its path when notarize.keep_submission is set so the
+// exact submission can be inspected after a rejection.
+func cleanupSubmission(ctx *context.Context, zipPath string) {
+	if ctx.Config.Notarize.KeepSubmission {
+		ctx.Logger.Infof("Keeping notarization submission: %s", zipPath)
+		return
+	}
+	if err := os.Remove(zipPath); err != nil {
+		ctx.Logger.Warnf("Failed to remove temp ZIP %s: %v", zipPath, err)
+	}
+}
+
+// notarizeZipPath returns the temporary ZIP path used to submit the .app.
+// The name is passed to ditto and notarytool as a single argument, so app
+// names containing spaces need no escaping.
+func notarizeZipPath(appPath, outputDir string) string {
+	appName := strings.TrimSuffix(filepath.Base(appPath), ".app")
+	return filepath.Join(outputDir, appName+"-notarize.zip")
+}
+
+// selectDMGs returns the .dmg disk images among packages.
+func selectDMGs(packages []string) []string {
+	var dmgs []string
+	for _, p := range packages {
+		if filepath.Ext(p) == ".dmg" {
+			dmgs = append(dmgs, p)
+		}
+	}
+	return dmgs
+}
+
+// selectPkg returns the first .pkg installer among packages, or "" if none.
+// An installer given to the notarize command is submitted to notarytool and
+// stapled directly.
+func selectPkg(packages []string) string {
+	for _, p := range packages {
+		if filepath.Ext(p) == ".pkg" {
+			return p
+		}
+	}
+	return ""
 }
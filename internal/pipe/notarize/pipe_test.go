@@ -2,11 +2,15 @@ package notarize
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/macreleaser/macreleaser/pkg/command"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macCtx "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/notarize"
 	"github.com/sirupsen/logrus"
 )
 
@@ -57,3 +61,164 @@ func TestPipeNoApp(t *testing.T) {
 		t.Errorf("Run() error = %v, want error containing %q", err, "no .app found to notarize")
 	}
 }
+
+func TestPipePrefersAppOverPkg(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	ctx := macCtx.NewContext(context.Background(), &config.Config{
+		Notarize: config.NotarizeConfig{
+			AppleID:  "dev@example.com",
+			TeamID:   "TEAM123",
+			Password: "xxxx-xxxx-xxxx-xxxx",
+		},
+	}, logger)
+	ctx.Artifacts.AppPath = "dist/MyApp.app"
+	ctx.Artifacts.BuildOutputDir = "dist"
+	ctx.Artifacts.Packages = []string{"dist/MyApp-1.0.0.pkg"}
+
+	fake := &command.Fake{}
+	ctx.StdCtx = command.WithRunner(ctx.StdCtx, fake)
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	var submitted, stapled, assessed []string
+	for _, c := range fake.Calls() {
+		switch {
+		case c.Name == "xcrun" && len(c.Args) > 2 && c.Args[1] == "submit":
+			submitted = append(submitted, c.Args[2])
+		case c.Name == "xcrun" && len(c.Args) > 2 && c.Args[0] == "stapler":
+			stapled = append(stapled, c.Args[2])
+		case c.Name == "spctl":
+			assessed = append(assessed, c.Args[len(c.Args)-1])
+		}
+	}
+	want := []string{filepath.Join("dist", "MyApp-notarize.zip")}
+	if strings.Join(submitted, ",") != strings.Join(want, ",") {
+		t.Errorf("submitted %v, want %v", submitted, want)
+	}
+	if strings.Join(stapled, ",") != "dist/MyApp.app" {
+		t.Errorf("stapled %v, want [dist/MyApp.app]", stapled)
+	}
+	if strings.Join(assessed, ",") != "dist/MyApp.app" {
+		t.Errorf("assessed %v, want [dist/MyApp.app]", assessed)
+	}
+}
+
+func TestSelectPkg(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []string
+		want     string
+	}{
+		{name: "no packages", packages: nil, want: ""},
+		{name: "zip and dmg only", packages: []string{"dist/App.zip", "dist/App.dmg"}, want: ""},
+		{name: "pkg among packages", packages: []string{"dist/App.zip", "dist/App.pkg"}, want: "dist/App.pkg"},
+		{name: "first pkg wins", packages: []string{"dist/App.pkg", "dist/Other.pkg"}, want: "dist/App.pkg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectPkg(tt.packages); got != tt.want {
+				t.Errorf("selectPkg() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotarizeZipPath(t *testing.T) {
+	tests := []struct {
+		appPath string
+		want    string
+	}{
+		{appPath: "dist/MyApp.app", want: "dist/MyApp-notarize.zip"},
+		{appPath: "dist/My App.app", want: "dist/My App-notarize.zip"},
+	}
+
+	for _, tt := range tests {
+		if got := notarizeZipPath(tt.appPath, "dist"); got != tt.want {
+			t.Errorf("notarizeZipPath(%q) = %q, want %q", tt.appPath, got, tt.want)
+		}
+	}
+}
+
+func TestSelectDMGs(t *testing.T) {
+	got := selectDMGs([]string{"dist/App.zip", "dist/App.dmg", "dist/App.app", "dist/Extra.dmg"})
+	want := []string{"dist/App.dmg", "dist/Extra.dmg"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("selectDMGs() = %v, want %v", got, want)
+	}
+	if got := selectDMGs([]string{"dist/App.zip"}); len(got) != 0 {
+		t.Errorf("selectDMGs() = %v, want none", got)
+	}
+}
+
+func TestCleanupSubmission(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	for _, keep := range []bool{false, true} {
+		zipPath := filepath.Join(t.TempDir(), "TestApp-notarize.zip")
+		if err := os.WriteFile(zipPath, []byte("fake"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx := macCtx.NewContext(context.Background(), &config.Config{
+			Notarize: config.NotarizeConfig{KeepSubmission: keep},
+		}, logger)
+		cleanupSubmission(ctx, zipPath)
+
+		_, err := os.Stat(zipPath)
+		if exists := err == nil; exists != keep {
+			t.Errorf("keep_submission = %v: submission exists = %v, want %v", keep, exists, keep)
+		}
+	}
+}
+
+func TestSubmitCredentials(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "asc.json")
+	if err := os.WriteFile(keyFile, []byte(`{"key_id": "ABC123", "issuer_id": "issuer", "key_path": "/keys/AuthKey_ABC123.p8"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	base := config.NotarizeConfig{
+		AppleID:  "dev@example.com",
+		TeamID:   "TEAM123",
+		Password: "base-secret",
+		Profiles: map[string]config.NotarizeCredentials{
+			"appstore": {AppleID: "store@example.com", TeamID: "STORE99", Password: "store-secret"},
+			"internal": {ASCKeyFile: keyFile},
+		},
+	}
+
+	tests := []struct {
+		profile string
+		want    notarize.Credentials
+	}{
+		{profile: "", want: notarize.Credentials{AppleID: "dev@example.com", TeamID: "TEAM123", Password: "base-secret"}},
+		{profile: "appstore", want: notarize.Credentials{AppleID: "store@example.com", TeamID: "STORE99", Password: "store-secret"}},
+		{profile: "internal", want: notarize.Credentials{KeyPath: "/keys/AuthKey_ABC123.p8", KeyID: "ABC123", IssuerID: "issuer"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("profile "+tt.profile, func(t *testing.T) {
+			cfg := base
+			cfg.Profile = tt.profile
+			got, err := submitCredentials(cfg)
+			if err != nil {
+				t.Fatalf("submitCredentials() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("submitCredentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	cfg := base
+	cfg.Profile = "missing"
+	if _, err := submitCredentials(cfg); err == nil || !strings.Contains(err.Error(), `notarize.profile "missing" is not defined`) {
+		t.Errorf("submitCredentials() error = %v, want undefined profile error", err)
+	}
+}
@@ -31,6 +31,17 @@ func (CheckPipe) Run(ctx *context.Context) error {
 		return fmt.Errorf("project.name contains a path traversal or absolute path: %q", cfg.Name)
 	}
 
+	name := cfg.FileName()
+	if name == "" {
+		return fmt.Errorf("project.name %q has no letters, digits, '.', '_' or '-' to use in file names; set project.name to a plain ASCII name and project.display_name to the name shown in releases and the cask", cfg.Name)
+	}
+	if name != cfg.Name {
+		ctx.Logger.Warnf("project.name %q contains characters that are awkward in file names and URLs; %q is used in paths instead. Set project.name to a plain name and project.display_name to the name shown in releases and the cask", cfg.Name, name)
+	}
+	if err := env.CheckResolved(cfg.DisplayName, "project.display_name"); err != nil {
+		return err
+	}
+
 	if err := validate.RequiredString(cfg.Scheme, "project.scheme"); err != nil {
 		return err
 	}
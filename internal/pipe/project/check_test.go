@@ -1,6 +1,7 @@
 package project
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"testing"
@@ -63,6 +64,17 @@ func TestCheckPipe(t *testing.T) {
 			wantErr: true,
 			errMsg:  "project.name is required",
 		},
+		{
+			name: "name with no file name characters",
+			config: &config.Config{
+				Project: config.ProjectConfig{
+					Name:   "日本語",
+					Scheme: "MyApp",
+				},
+			},
+			wantErr: true,
+			errMsg:  "has no letters, digits",
+		},
 		{
 			name: "valid with workspace",
 			config: &config.Config{
@@ -95,6 +107,37 @@ func TestCheckPipe(t *testing.T) {
 	}
 }
 
+func TestCheckPipeNameWarning(t *testing.T) {
+	tests := []struct {
+		name     string
+		project  string
+		wantWarn bool
+	}{
+		{name: "plain name", project: "MyApp"},
+		{name: "name with space", project: "My App", wantWarn: true},
+		{name: "name with ampersand", project: "Tom&Jerry", wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&buf)
+
+			cfg := &config.Config{Project: config.ProjectConfig{Name: tt.project, Scheme: "MyApp"}}
+			ctx := macCtx.NewContext(context.Background(), cfg, logger)
+			if err := (CheckPipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			warned := strings.Contains(buf.String(), "project.display_name")
+			if warned != tt.wantWarn {
+				t.Errorf("warned = %v, want %v; log:\n%s", warned, tt.wantWarn, buf.String())
+			}
+		})
+	}
+}
+
 func TestCheckPipeString(t *testing.T) {
 	p := CheckPipe{}
 	expected := "validating project configuration"
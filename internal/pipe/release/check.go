@@ -1,19 +1,60 @@
 package release
 
 import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
 	"github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/env"
+	"github.com/macreleaser/macreleaser/pkg/github"
+	"github.com/macreleaser/macreleaser/pkg/retry"
 	"github.com/macreleaser/macreleaser/pkg/validate"
 )
 
+// resolveRepoSlug detects the GitHub owner and repository; replaced in tests.
+var resolveRepoSlug = github.ResolveRepoSlug
+
+// detectRepository falls back to the repository of the GitHub Actions run
+// or the origin remote for an unset release.github.owner or repo, so they
+// only need configuring when that fails. The release pipe calls it again,
+// since --skip-validation skips CheckPipe.
+func detectRepository(ctx *context.Context) error {
+	cfg := ctx.Config.Release.GitHub
+	if cfg.Owner == "" || cfg.Repo == "" {
+		if owner, repo, source := resolveRepoSlug(ctx.StdCtx); owner != "" {
+			if cfg.Owner == "" {
+				ctx.Config.Release.GitHub.Owner = owner
+			}
+			if cfg.Repo == "" {
+				ctx.Config.Release.GitHub.Repo = repo
+			}
+			cfg = ctx.Config.Release.GitHub
+			ctx.Logger.Infof("Using GitHub repository %s/%s detected from %s", cfg.Owner, cfg.Repo, source)
+		}
+	}
+
+	if err := validate.RequiredString(cfg.Owner, "release.github.owner"); err != nil {
+		return fmt.Errorf("%w — it could not be detected from GITHUB_REPOSITORY or the origin remote", err)
+	}
+	if err := validate.RequiredString(cfg.Repo, "release.github.repo"); err != nil {
+		return fmt.Errorf("%w — it could not be detected from GITHUB_REPOSITORY or the origin remote", err)
+	}
+	return nil
+}
+
 // CheckPipe validates release configuration
 type CheckPipe struct{}
 
 func (CheckPipe) String() string { return "validating release configuration" }
 
+func (CheckPipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (CheckPipe) Run(ctx *context.Context) error {
-	if ctx.SkipPublish {
-		return skipError("publishing skipped")
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
 	}
 
 	cfg := ctx.Config.Release.GitHub
@@ -24,15 +65,139 @@ func (CheckPipe) Run(ctx *context.Context) error {
 	if err := env.CheckResolved(cfg.Repo, "release.github.repo"); err != nil {
 		return err
 	}
+	if err := env.CheckResolved(cfg.Token, "release.github.token"); err != nil {
+		return err
+	}
 
-	if err := validate.RequiredString(cfg.Owner, "release.github.owner"); err != nil {
+	if err := detectRepository(ctx); err != nil {
 		return err
 	}
+	cfg = ctx.Config.Release.GitHub
 
-	if err := validate.RequiredString(cfg.Repo, "release.github.repo"); err != nil {
+	if err := env.CheckResolved(cfg.Timeout, "release.github.timeout"); err != nil {
+		return err
+	}
+	if _, err := github.ParseTimeout(cfg.Timeout); err != nil {
+		return err
+	}
+	if err := checkRetry(ctx.Config.Retry); err != nil {
+		return err
+	}
+	for name, value := range cfg.Headers {
+		if err := env.CheckResolved(value, "release.github.headers."+name); err != nil {
+			return err
+		}
+	}
+	if err := github.ValidateHeaders(cfg.Headers); err != nil {
 		return err
 	}
 
+	if err := github.ValidateContentTypes(cfg.ContentTypes); err != nil {
+		return err
+	}
+
+	for _, pattern := range ctx.Config.Release.AllowedBranches {
+		if err := env.CheckResolved(pattern, "release.allowed_branches"); err != nil {
+			return err
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid release.allowed_branches entry %q: %w", pattern, err)
+		}
+	}
+	if ctx.Config.Release.AllowDetached && len(ctx.Config.Release.AllowedBranches) == 0 {
+		ctx.Logger.Warn("release.allow_detached has no effect without release.allowed_branches")
+	}
+	// The check command does not resolve the git state, so the branch is
+	// only checked when a release is about to be built
+	if ctx.Git.Commit != "" {
+		if err := checkBranch(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := env.CheckResolved(cfg.Target, "release.github.target"); err != nil {
+		return err
+	}
+	for _, pattern := range cfg.ExtraAssets {
+		if err := env.CheckResolved(pattern, "release.github.extra_assets"); err != nil {
+			return err
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid release.github.extra_assets entry %q: %w", pattern, err)
+		}
+	}
+
+	if cfg.MaxAssetSize < 0 {
+		return fmt.Errorf("release.github.max_asset_size must not be negative, got %d", cfg.MaxAssetSize)
+	}
+
+	if cfg.AssetNameTemplate != "" {
+		if _, err := parseAssetNameTemplate(cfg.AssetNameTemplate); err != nil {
+			return err
+		}
+	}
+
+	if err := env.CheckResolved(cfg.RollingTag, "release.github.rolling_tag"); err != nil {
+		return err
+	}
+	if cfg.RollingTag != "" && (strings.ContainsAny(cfg.RollingTag, " \t~^:?*[\\") || strings.Contains(cfg.RollingTag, "..")) {
+		return fmt.Errorf("release.github.rolling_tag %q is not a valid tag name", cfg.RollingTag)
+	}
+
+	if err := env.CheckResolved(cfg.DiscussionCategory, "release.github.discussion_category"); err != nil {
+		return err
+	}
+	if cfg.PublishAfterUpload && cfg.Draft {
+		return fmt.Errorf("release.github.publish_after_upload cannot be combined with release.github.draft — the release would never be published")
+	}
+	if cfg.DiscussionCategory != "" && cfg.Draft {
+		return fmt.Errorf("release.github.discussion_category cannot be used with draft releases — GitHub only creates discussions for published releases")
+	}
+
+	// Only regular files are uploaded, so a release without zip or dmg
+	// packages would be published with no downloadable assets
+	if !validate.ContainsAny(ctx.Config.Archive.Formats.Types(), "zip", "dmg") {
+		ctx.Logger.Warn("archive.formats contains no zip or dmg — the GitHub release will have no downloadable assets")
+	}
+
 	ctx.Logger.Debug("Release configuration validated successfully")
 	return nil
 }
+
+// checkBranch returns an error unless the current branch is allowed to
+// publish by release.allowed_branches. A detached HEAD has no branch to
+// check, so it is only allowed with release.allow_detached.
+func checkBranch(ctx *context.Context) error {
+	cfg := ctx.Config.Release
+	if len(cfg.AllowedBranches) == 0 {
+		return nil
+	}
+
+	branch := ctx.Git.Branch
+	if branch == "" {
+		if cfg.AllowDetached {
+			ctx.Logger.Debug("Publishing from a detached HEAD, allowed by release.allow_detached")
+			return nil
+		}
+		return fmt.Errorf("HEAD is detached, so it cannot be matched against release.allowed_branches — check out an allowed branch, or set release.allow_detached: true to publish from detached checkouts such as CI tag builds")
+	}
+
+	for _, pattern := range cfg.AllowedBranches {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("branch %q is not in release.allowed_branches %v — releases may only be published from those branches", branch, cfg.AllowedBranches)
+}
+
+// checkRetry validates the retry policy the GitHub client is created with.
+func checkRetry(rc config.RetryConfig) error {
+	if err := env.CheckResolved(rc.BaseDelay, "retry.base_delay"); err != nil {
+		return err
+	}
+	if err := env.CheckResolved(rc.MaxDelay, "retry.max_delay"); err != nil {
+		return err
+	}
+	_, err := retry.Parse(rc.MaxAttempts, rc.BaseDelay, rc.MaxDelay)
+	return err
+}
@@ -1,6 +1,7 @@
 package release
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"testing"
@@ -10,9 +11,23 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// stubRepoSlug replaces repository detection for the duration of the test
+func stubRepoSlug(t *testing.T, owner, repo string) {
+	t.Helper()
+	orig := resolveRepoSlug
+	t.Cleanup(func() { resolveRepoSlug = orig })
+	resolveRepoSlug = func(context.Context) (string, string, string) {
+		if owner == "" {
+			return "", "", ""
+		}
+		return owner, repo, "stub"
+	}
+}
+
 func TestCheckPipe(t *testing.T) {
 	logger := logrus.New()
 	logger.SetLevel(logrus.DebugLevel)
+	stubRepoSlug(t, "", "")
 
 	tests := []struct {
 		name    string
@@ -46,6 +61,149 @@ func TestCheckPipe(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid configuration with timeout",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:   "testuser",
+						Repo:    "testrepo",
+						Timeout: "30m",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid timeout",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:   "testuser",
+						Repo:    "testrepo",
+						Timeout: "forever",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid release.github.timeout",
+		},
+		{
+			name: "valid retry policy",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner: "testuser",
+						Repo:  "testrepo",
+					},
+				},
+				Retry: config.RetryConfig{MaxAttempts: 5, BaseDelay: "2s", MaxDelay: "1m"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "retry base delay above max delay",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner: "testuser",
+						Repo:  "testrepo",
+					},
+				},
+				Retry: config.RetryConfig{BaseDelay: "1m", MaxDelay: "5s"},
+			},
+			wantErr: true,
+			errMsg:  "retry.base_delay (1m0s) must not be greater than retry.max_delay (5s)",
+		},
+		{
+			name: "allowed branch globs",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub:          config.GitHubConfig{Owner: "testuser", Repo: "testrepo"},
+					AllowedBranches: []string{"main", "release/*"},
+				},
+			},
+		},
+		{
+			name: "invalid allowed branch glob",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub:          config.GitHubConfig{Owner: "testuser", Repo: "testrepo"},
+					AllowedBranches: []string{"release/["},
+				},
+			},
+			wantErr: true,
+			errMsg:  `invalid release.allowed_branches entry "release/["`,
+		},
+		{
+			name: "header overriding the token",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:   "testuser",
+						Repo:    "testrepo",
+						Headers: map[string]string{"Authorization": "token abc"},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "release.github.headers cannot set Authorization",
+		},
+		{
+			name: "invalid rolling tag",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:      "testuser",
+						Repo:       "testrepo",
+						RollingTag: "nightly build",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `release.github.rolling_tag "nightly build" is not a valid tag name`,
+		},
+		{
+			name: "negative max asset size",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:        "testuser",
+						Repo:         "testrepo",
+						MaxAssetSize: -1,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "release.github.max_asset_size must not be negative",
+		},
+		{
+			name: "valid asset name template",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:             "testuser",
+						Repo:              "testrepo",
+						AssetNameTemplate: "{{.ProjectName}}-latest{{.Ext}}",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "malformed asset name template",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:             "testuser",
+						Repo:              "testrepo",
+						AssetNameTemplate: "{{.ProjectName",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid release.github.asset_name_template",
+		},
 		{
 			name: "missing owner",
 			config: &config.Config{
@@ -88,6 +246,49 @@ func TestCheckPipe(t *testing.T) {
 			wantErr: true,
 			errMsg:  "release.github.owner is required",
 		},
+		{
+			name: "discussion category on published release",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:              "testuser",
+						Repo:               "testrepo",
+						DiscussionCategory: "Announcements",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "discussion category on draft release",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:              "testuser",
+						Repo:               "testrepo",
+						Draft:              true,
+						DiscussionCategory: "Announcements",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "discussion_category cannot be used with draft releases",
+		},
+		{
+			name: "publish after upload on draft release",
+			config: &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{
+						Owner:              "testuser",
+						Repo:               "testrepo",
+						Draft:              true,
+						PublishAfterUpload: true,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "publish_after_upload cannot be combined with release.github.draft",
+		},
 	}
 
 	for _, tt := range tests {
@@ -109,6 +310,46 @@ func TestCheckPipe(t *testing.T) {
 	}
 }
 
+func TestCheckPipeWarnsWithoutUploadableFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		formats  []string
+		wantWarn bool
+	}{
+		{name: "zip and dmg", formats: []string{"zip", "dmg"}, wantWarn: false},
+		{name: "app only", formats: []string{"app"}, wantWarn: true},
+		{name: "empty formats", formats: nil, wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logrus.New()
+			logger.SetOutput(&buf)
+
+			var formats config.ArchiveFormats
+			for _, f := range tt.formats {
+				formats = append(formats, config.ArchiveFormat{Type: f})
+			}
+			cfg := &config.Config{
+				Archive: config.ArchiveConfig{Formats: formats},
+				Release: config.ReleaseConfig{
+					GitHub: config.GitHubConfig{Owner: "testuser", Repo: "testrepo"},
+				},
+			}
+			ctx := macCtx.NewContext(context.Background(), cfg, logger)
+			if err := (CheckPipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			gotWarn := strings.Contains(buf.String(), "no downloadable assets")
+			if gotWarn != tt.wantWarn {
+				t.Errorf("warning logged = %v, want %v\nlog: %s", gotWarn, tt.wantWarn, buf.String())
+			}
+		})
+	}
+}
+
 func TestCheckPipeString(t *testing.T) {
 	p := CheckPipe{}
 	expected := "validating release configuration"
@@ -116,3 +357,122 @@ func TestCheckPipeString(t *testing.T) {
 		t.Errorf("String() = %q, want %q", got, expected)
 	}
 }
+
+func TestCheckPipeDetectsRepository(t *testing.T) {
+	stubRepoSlug(t, "acme", "myapp")
+
+	tests := []struct {
+		name      string
+		github    config.GitHubConfig
+		wantOwner string
+		wantRepo  string
+	}{
+		{name: "both detected", wantOwner: "acme", wantRepo: "myapp"},
+		{name: "configured owner kept", github: config.GitHubConfig{Owner: "other"}, wantOwner: "other", wantRepo: "myapp"},
+		{name: "configured values win", github: config.GitHubConfig{Owner: "other", Repo: "tool"}, wantOwner: "other", wantRepo: "tool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Release: config.ReleaseConfig{GitHub: tt.github}}
+			ctx := macCtx.NewContext(context.Background(), cfg, logrus.New())
+			if err := (CheckPipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			got := ctx.Config.Release.GitHub
+			if got.Owner != tt.wantOwner || got.Repo != tt.wantRepo {
+				t.Errorf("owner/repo = %s/%s, want %s/%s", got.Owner, got.Repo, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestCheckBranch(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowed       []string
+		allowDetached bool
+		branch        string
+		errMsg        string
+	}{
+		{name: "no allowlist", branch: "feature/x"},
+		{name: "no allowlist detached"},
+		{name: "exact match", allowed: []string{"main"}, branch: "main"},
+		{name: "glob match", allowed: []string{"main", "release/*"}, branch: "release/1.2"},
+		{
+			name:    "glob does not cross slashes",
+			allowed: []string{"release/*"},
+			branch:  "release/1.2/hotfix",
+			errMsg:  `branch "release/1.2/hotfix" is not in release.allowed_branches [release/*]`,
+		},
+		{
+			name:    "disallowed branch",
+			allowed: []string{"main", "release/*"},
+			branch:  "feature/x",
+			errMsg:  `branch "feature/x" is not in release.allowed_branches [main release/*]`,
+		},
+		{
+			name:    "detached HEAD",
+			allowed: []string{"main"},
+			errMsg:  "HEAD is detached",
+		},
+		{name: "detached HEAD allowed", allowed: []string{"main"}, allowDetached: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newContext()
+			ctx.Config.Release.AllowedBranches = tt.allowed
+			ctx.Config.Release.AllowDetached = tt.allowDetached
+			ctx.Git.Branch = tt.branch
+
+			err := checkBranch(ctx)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Fatalf("checkBranch() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("checkBranch() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestCheckPipeBranch(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		errMsg string
+	}{
+		{name: "release", commit: "abc123", errMsg: `branch "feature/x" is not in release.allowed_branches [main]`},
+		// The check command runs without git state
+		{name: "check command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := macCtx.NewContext(context.Background(), &config.Config{
+				Release: config.ReleaseConfig{
+					GitHub:          config.GitHubConfig{Owner: "testuser", Repo: "testrepo"},
+					AllowedBranches: []string{"main"},
+				},
+			}, logrus.New())
+			ctx.Git.Commit = tt.commit
+			ctx.Git.Branch = "feature/x"
+
+			err := CheckPipe{}.Run(ctx)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Fatalf("Run() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package release
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
+	"time"
 
 	gogithub "github.com/google/go-github/github"
 	"github.com/macreleaser/macreleaser/pkg/context"
 	gh "github.com/macreleaser/macreleaser/pkg/github"
+	"github.com/macreleaser/macreleaser/pkg/retry"
 )
 
 // skipError signals an intentional skip. It satisfies the pipe.IsSkip interface
@@ -19,27 +23,86 @@ type skipError string
 func (e skipError) Error() string { return string(e) }
 func (e skipError) IsSkip() bool  { return true }
 
+// skipReason returns why the check and execution pipes skip, or "" when
+// they run. It depends only on flags and configuration.
+func skipReason(ctx *context.Context) string {
+	if ctx.SkipPublish {
+		return "publishing skipped"
+	}
+	return ""
+}
+
 // Pipe creates a GitHub release and uploads archive packages as release assets.
 type Pipe struct{}
 
 func (Pipe) String() string { return "publishing GitHub release" }
 
+func (Pipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (Pipe) Run(ctx *context.Context) error {
-	if ctx.SkipPublish {
-		return skipError("publishing skipped")
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
+	// Validation has already checked the branch unless it was skipped
+	if err := checkBranch(ctx); err != nil {
+		return err
 	}
 
 	if len(ctx.Artifacts.Packages) == 0 {
 		return fmt.Errorf("no packages to release — ensure the archive step completed successfully")
 	}
 
+	// Validation has already detected the repository unless it was skipped
+	if err := detectRepository(ctx); err != nil {
+		return err
+	}
+
+	// Resolve extra assets and asset names before creating the release so
+	// a bad glob or a name collision doesn't leave a release without them
+	extraAssets, err := resolveExtraAssets(ctx.Config.Release.GitHub.ExtraAssets, ctx.Artifacts.Packages)
+	if err != nil {
+		return err
+	}
+
+	var assets []string
+	for _, pkg := range ctx.Artifacts.Packages {
+		info, err := os.Stat(pkg)
+		if err != nil || !info.Mode().IsRegular() {
+			ctx.Logger.Warnf("Skipping %s: not a regular file (only files can be uploaded as release assets)", pkg)
+			continue
+		}
+		assets = append(assets, pkg)
+	}
+	assets = append(assets, extraAssets...)
+	assets = withinSizeLimit(ctx, assets, ctx.Config.Release.GitHub.MaxAssetSize)
+
+	assetNames, err := renderAssetNames(ctx.Config.Release.GitHub.AssetNameTemplate, ctx.Config.Project.FileName(), ctx.Version, assets)
+	if err != nil {
+		return err
+	}
+
 	// Create GitHub client if not already injected (e.g., by tests)
 	if ctx.GitHubClient == nil {
-		token := gh.GetGitHubToken()
+		token, source := gh.ResolveToken(ctx.StdCtx, ctx.Config.Release.GitHub.Token)
 		if token == "" {
-			return fmt.Errorf("GITHUB_TOKEN environment variable is required for publishing — create a token at https://github.com/settings/tokens with 'repo' scope")
+			return fmt.Errorf("a GitHub token is required for publishing — set release.github.token or GITHUB_TOKEN, run `gh auth login`, or configure a git credential helper for github.com")
+		}
+		ctx.Logger.Debugf("Using GitHub token from %s", source)
+		timeout, err := gh.ParseTimeout(ctx.Config.Release.GitHub.Timeout)
+		if err != nil {
+			return err
+		}
+		rc := ctx.Config.Retry
+		policy, err := retry.Parse(rc.MaxAttempts, rc.BaseDelay, rc.MaxDelay)
+		if err != nil {
+			return err
 		}
-		client, err := gh.NewClient(token)
+		client, err := gh.NewClientWithOptions(token, gh.ClientOptions{
+			Timeout: timeout,
+			Retry:   policy,
+			Headers: ctx.Config.Release.GitHub.Headers,
+		})
 		if err != nil {
 			return fmt.Errorf("failed to create GitHub client: %w", err)
 		}
@@ -48,21 +111,48 @@ func (Pipe) Run(ctx *context.Context) error {
 
 	owner := ctx.Config.Release.GitHub.Owner
 	repo := ctx.Config.Release.GitHub.Repo
-	releaseName := fmt.Sprintf("%s %s", ctx.Config.Project.Name, ctx.Version)
+	releaseName := fmt.Sprintf("%s %s", ctx.Config.Project.Title(), ctx.Version)
 
-	releaseReq := &gogithub.RepositoryRelease{
-		TagName: &ctx.Version,
-		Name:    &releaseName,
-		Draft:   &ctx.Config.Release.GitHub.Draft,
+	// A rolling tag always carries the latest build. The new release is
+	// drafted and its assets uploaded first, so a failure leaves the
+	// previous release in place; only then are the previous release and the
+	// tag removed, and publishing recreates the tag at the current commit.
+	tag := ctx.Version
+	rollingTag := ctx.Config.Release.GitHub.RollingTag
+	if rollingTag != "" {
+		tag = rollingTag
+	}
+
+	// With publish_after_upload the release stays a draft, invisible to
+	// consumers, until every asset is in place
+	publishAfterUpload := ctx.Config.Release.GitHub.PublishAfterUpload || rollingTag != ""
+	draft := ctx.Config.Release.GitHub.Draft || publishAfterUpload
+
+	releaseReq := &gh.ReleaseRequest{
+		RepositoryRelease: &gogithub.RepositoryRelease{
+			TagName: &tag,
+			Name:    &releaseName,
+			Draft:   &draft,
+		},
 	}
 	if ctx.ReleaseNotes != "" {
 		releaseReq.Body = &ctx.ReleaseNotes
 	}
+	// GitHub creates a missing tag at the default branch unless told where
+	if target := releaseTarget(ctx); target != "" {
+		releaseReq.TargetCommitish = &target
+	}
+	// GitHub rejects a discussion category on drafts, so it is sent when
+	// the release is published
+	category := ctx.Config.Release.GitHub.DiscussionCategory
+	if category != "" && !publishAfterUpload {
+		releaseReq.DiscussionCategoryName = &category
+	}
 
 	release, err := ctx.GitHubClient.CreateRelease(ctx.StdCtx, owner, repo, releaseReq)
 	if err != nil {
 		if strings.Contains(err.Error(), "already_exists") {
-			return fmt.Errorf("release for tag %s already exists — delete the existing release or use a different version tag", ctx.Version)
+			return fmt.Errorf("release for tag %s already exists — delete the existing release or use a different version tag", tag)
 		}
 		return fmt.Errorf("failed to create GitHub release: %w", err)
 	}
@@ -70,21 +160,236 @@ func (Pipe) Run(ctx *context.Context) error {
 	ctx.Artifacts.ReleaseURL = release.GetHTMLURL()
 	ctx.Logger.Infof("Created GitHub release: %s", releaseName)
 
-	// Upload packages as release assets
-	for _, pkg := range ctx.Artifacts.Packages {
-		info, err := os.Stat(pkg)
-		if err != nil || !info.Mode().IsRegular() {
-			ctx.Logger.Warnf("Skipping %s: not a regular file (only files can be uploaded as release assets)", pkg)
-			continue
+	// Upload packages and extra assets as release assets
+	var uploaded []*gogithub.ReleaseAsset
+	for _, asset := range assets {
+		name := assetNames[asset]
+		contentType := gh.ContentTypeForAsset(asset, ctx.Config.Release.GitHub.ContentTypes)
+		uploadedAsset, err := ctx.GitHubClient.UploadReleaseAsset(ctx.StdCtx, owner, repo, release.GetID(), asset, name, contentType, uploadProgress(ctx, name, time.Now))
+		if err != nil {
+			if publishAfterUpload {
+				return fmt.Errorf("failed to upload asset %s (the release was left as a draft): %w", name, err)
+			}
+			return fmt.Errorf("failed to upload asset %s: %w", name, err)
+		}
+		uploaded = append(uploaded, uploadedAsset)
+		if name != filepath.Base(asset) {
+			ctx.Logger.Infof("Uploaded: %s as %s", filepath.Base(asset), name)
+			ctx.Artifacts.AssetNames[asset] = name
+		} else {
+			ctx.Logger.Infof("Uploaded: %s", name)
+		}
+	}
+
+	if rollingTag != "" {
+		if err := removeRollingRelease(ctx, owner, repo, rollingTag, release.GetID()); err != nil {
+			return fmt.Errorf("%w (the new release was left as a draft)", err)
 		}
+	}
 
-		contentType := gh.ContentTypeForAsset(pkg)
-		if _, err := ctx.GitHubClient.UploadReleaseAsset(ctx.StdCtx, owner, repo, release.GetID(), pkg, contentType); err != nil {
-			return fmt.Errorf("failed to upload asset %s: %w", filepath.Base(pkg), err)
+	if publishAfterUpload && !ctx.Config.Release.GitHub.Draft {
+		published := false
+		publishReq := &gh.ReleaseRequest{
+			RepositoryRelease: &gogithub.RepositoryRelease{Draft: &published},
 		}
-		ctx.Logger.Infof("Uploaded: %s", filepath.Base(pkg))
+		if category != "" {
+			publishReq.DiscussionCategoryName = &category
+		}
+		release, err = ctx.GitHubClient.UpdateRelease(ctx.StdCtx, owner, repo, release.GetID(), publishReq)
+		if err != nil {
+			return fmt.Errorf("failed to publish GitHub release (all assets were uploaded; it was left as a draft): %w", err)
+		}
+		ctx.Artifacts.ReleaseURL = release.GetHTMLURL()
+		ctx.Logger.Infof("Published draft release: %s", releaseName)
 	}
 
 	ctx.Logger.Infof("Release published: %s", ctx.Artifacts.ReleaseURL)
+
+	if ctx.Config.Release.GitHub.VerifyDownload {
+		// Draft assets are only served to authenticated API clients
+		if ctx.Config.Release.GitHub.Draft {
+			ctx.Logger.Warn("Skipping download verification: draft release assets are not publicly downloadable")
+			return nil
+		}
+		return verifyDownloads(ctx, uploaded)
+	}
 	return nil
 }
+
+// removeRollingRelease deletes the previous release of a rolling tag, along
+// with its assets, and then the tag, so that publishing the new release,
+// whose ID is keep, recreates the tag at the commit being released. Either
+// may be missing, for example on the first run. Deleting a previous release
+// is confirmed first.
+func removeRollingRelease(ctx *context.Context, owner, repo, tag string, keep int64) error {
+	previous, err := ctx.GitHubClient.GetRelease(ctx.StdCtx, owner, repo, tag)
+	switch {
+	case gh.IsNotFound(err) || (err == nil && previous.GetID() == keep):
+		ctx.Logger.Debugf("No previous release for rolling tag %s", tag)
+	case err != nil:
+		return fmt.Errorf("failed to look up the previous %s release: %w", tag, err)
+	default:
+		if err := ctx.Confirm(fmt.Sprintf("delete the previous %s release %q and its assets", tag, previous.GetName())); err != nil {
+			return err
+		}
+		if err := ctx.GitHubClient.DeleteRelease(ctx.StdCtx, owner, repo, previous.GetID()); err != nil {
+			return fmt.Errorf("failed to delete the previous %s release: %w", tag, err)
+		}
+		ctx.Logger.Infof("Deleted previous release for rolling tag %s: %s", tag, previous.GetName())
+	}
+
+	if err := ctx.GitHubClient.DeleteTag(ctx.StdCtx, owner, repo, tag); err != nil && !gh.IsNotFound(err) {
+		return fmt.Errorf("failed to delete rolling tag %s: %w", tag, err)
+	}
+	return nil
+}
+
+// resolveExtraAssets expands release.github.extra_assets globs. Each pattern
+// must match at least one regular file, and asset names must not collide
+// with each other or with the packages, since GitHub requires unique names.
+func resolveExtraAssets(patterns, packages []string) ([]string, error) {
+	names := make(map[string]string)
+	for _, pkg := range packages {
+		names[filepath.Base(pkg)] = pkg
+	}
+
+	var assets []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid release.github.extra_assets entry %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("release.github.extra_assets entry %q matched no files", pattern)
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat extra asset %s: %w", match, err)
+			}
+			if !info.Mode().IsRegular() {
+				return nil, fmt.Errorf("release.github.extra_assets entry %q matched %s, which is not a regular file", pattern, match)
+			}
+
+			name := filepath.Base(match)
+			if prev, ok := names[name]; ok {
+				if prev == match {
+					continue
+				}
+				return nil, fmt.Errorf("release asset name %q is used by both %s and %s", name, prev, match)
+			}
+			names[name] = match
+			assets = append(assets, match)
+		}
+	}
+	return assets, nil
+}
+
+// withinSizeLimit returns the assets no larger than maxSize bytes, warning
+// about each one left out and recording it in Artifacts.SkippedAssets so
+// the Homebrew cask does not link to it. A non-positive maxSize keeps every
+// asset.
+func withinSizeLimit(ctx *context.Context, assets []string, maxSize int64) []string {
+	if maxSize <= 0 {
+		return assets
+	}
+	var kept []string
+	for _, asset := range assets {
+		info, err := os.Stat(asset)
+		if err == nil && info.Size() > maxSize {
+			ctx.Logger.Warnf("Skipping %s: %s is larger than release.github.max_asset_size (%s)", filepath.Base(asset), formatBytes(float64(info.Size())), formatBytes(float64(maxSize)))
+			ctx.Artifacts.SkippedAssets = append(ctx.Artifacts.SkippedAssets, asset)
+			continue
+		}
+		kept = append(kept, asset)
+	}
+	return kept
+}
+
+// assetNameData holds the fields available to release.github.asset_name_template
+type assetNameData struct {
+	ProjectName string // project.name, reduced to characters safe in file names
+	Version     string // release version
+	Filename    string // local file name, e.g. MyApp-v1.2.0.dmg
+	Ext         string // local file extension including the dot, e.g. .dmg
+}
+
+// parseAssetNameTemplate parses a release.github.asset_name_template value.
+func parseAssetNameTemplate(nameTemplate string) (*template.Template, error) {
+	tmpl, err := template.New("asset_name").Parse(nameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release.github.asset_name_template %q: %w", nameTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// renderAssetNames returns the release asset name of each asset: its local
+// file name, or nameTemplate rendered for it when set. Names must be unique
+// and plain file names, since GitHub stores assets flat per release.
+func renderAssetNames(nameTemplate, projectName, version string, assets []string) (map[string]string, error) {
+	var tmpl *template.Template
+	if nameTemplate != "" {
+		var err error
+		if tmpl, err = parseAssetNameTemplate(nameTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make(map[string]string, len(assets))
+	used := make(map[string]string, len(assets))
+	for _, asset := range assets {
+		name := filepath.Base(asset)
+		if tmpl != nil {
+			var buf bytes.Buffer
+			data := assetNameData{ProjectName: projectName, Version: version, Filename: name, Ext: filepath.Ext(name)}
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return nil, fmt.Errorf("invalid release.github.asset_name_template %q: %w", nameTemplate, err)
+			}
+			name = buf.String()
+			if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+				return nil, fmt.Errorf("release.github.asset_name_template renders %q for %s, which is not a valid file name", name, asset)
+			}
+		}
+
+		if prev, ok := used[name]; ok {
+			return nil, fmt.Errorf("release asset name %q is used by both %s and %s", name, prev, asset)
+		}
+		used[name] = asset
+		names[asset] = name
+	}
+	return names, nil
+}
+
+// uploadProgress returns a progress callback that logs how much of the
+// named asset has been uploaded and the average throughput since start.
+func uploadProgress(ctx *context.Context, name string, now func() time.Time) gh.ProgressFunc {
+	start := now()
+	return func(sent, total int64) {
+		rate := float64(sent) / now().Sub(start).Seconds()
+		ctx.Logger.Infof("Uploading %s: %d%% (%s of %s, %s/s)", name, sent*100/total, formatBytes(float64(sent)), formatBytes(float64(total)), formatBytes(rate))
+	}
+}
+
+// formatBytes formats a byte count with a binary unit, e.g. 1.5 GB.
+func formatBytes(n float64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	i := 0
+	for n >= 1024 && i < len(units)-1 {
+		n /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", n, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
+
+// releaseTarget returns the commitish GitHub creates the tag at when it does
+// not exist yet: release.github.target if set, otherwise the HEAD commit.
+func releaseTarget(ctx *context.Context) string {
+	if target := ctx.Config.Release.GitHub.Target; target != "" {
+		return target
+	}
+	return ctx.Git.Commit
+}
@@ -1,6 +1,7 @@
 package release
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,7 +9,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	gogithub "github.com/google/go-github/github"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macCtx "github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/github"
@@ -132,6 +135,39 @@ func TestPipeCreateReleaseAndUpload(t *testing.T) {
 	}
 }
 
+func TestPipeSkipsAssetsAboveMaxSize(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+	ctx.Config.Release.GitHub.MaxAssetSize = 10
+	var buf bytes.Buffer
+	ctx.Logger.SetOutput(&buf)
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "TestApp-v1.2.3.zip")
+	dsymPath := filepath.Join(tmpDir, "TestApp-v1.2.3-dSYM.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dsymPath, []byte("a very large dSYM"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath, dsymPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if len(mock.UploadedAssets) != 1 || mock.UploadedAssets[0] != zipPath {
+		t.Errorf("UploadedAssets = %v, want only %s", mock.UploadedAssets, zipPath)
+	}
+	if !strings.Contains(buf.String(), "Skipping TestApp-v1.2.3-dSYM.zip: 17 B is larger than release.github.max_asset_size (10 B)") {
+		t.Errorf("expected a warning about the skipped asset, got: %s", buf.String())
+	}
+}
+
 func TestPipeCreateReleaseDraft(t *testing.T) {
 	ctx := newContext()
 	ctx.Version = "v2.0.0"
@@ -158,6 +194,534 @@ func TestPipeCreateReleaseDraft(t *testing.T) {
 	}
 }
 
+func TestPipeCreateReleaseDiscussionCategory(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v2.0.0"
+	ctx.Config.Release.GitHub.DiscussionCategory = "Announcements"
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	zipPath := filepath.Join(t.TempDir(), "TestApp-v2.0.0.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if len(mock.ReleaseRequests) != 1 {
+		t.Fatalf("CreateRelease called %d times, want 1", len(mock.ReleaseRequests))
+	}
+	if got := mock.ReleaseRequests[0].DiscussionCategoryName; got == nil || *got != "Announcements" {
+		t.Errorf("DiscussionCategoryName = %v, want %q", got, "Announcements")
+	}
+}
+
+func TestPipeCreateReleaseTargetCommitish(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit string
+		target string
+		want   string
+	}{
+		{name: "defaults to HEAD commit", commit: "0123456789abcdef0123456789abcdef01234567", want: "0123456789abcdef0123456789abcdef01234567"},
+		{name: "configured target wins", commit: "0123456789abcdef0123456789abcdef01234567", target: "release/2.x", want: "release/2.x"},
+		{name: "unset without git info", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newContext()
+			ctx.Version = "v2.0.0"
+			ctx.Git.Commit = tt.commit
+			ctx.Config.Release.GitHub.Target = tt.target
+
+			mock := github.NewMockClient()
+			ctx.GitHubClient = mock
+
+			zipPath := filepath.Join(t.TempDir(), "TestApp-v2.0.0.zip")
+			if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			ctx.Artifacts.Packages = []string{zipPath}
+
+			if err := (Pipe{}).Run(ctx); err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+
+			rel := mock.Releases["testowner/testrepo"][0]
+			if got := rel.GetTargetCommitish(); got != tt.want {
+				t.Errorf("TargetCommitish = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPipeUploadsExtraAssets(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "TestApp-v1.2.3.zip")
+	extraDir := filepath.Join(tmpDir, "extra")
+	if err := os.MkdirAll(extraDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	installer := filepath.Join(extraDir, "TestApp-Installer.pkg")
+	sidecar := filepath.Join(extraDir, "TestApp-Plugins.zip")
+	for _, path := range []string{zipPath, installer, sidecar} {
+		if err := os.WriteFile(path, []byte("fake"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+	ctx.Config.Release.GitHub.ExtraAssets = []string{filepath.Join(extraDir, "*")}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	want := []string{zipPath, installer, sidecar}
+	if strings.Join(mock.UploadedAssets, ",") != strings.Join(want, ",") {
+		t.Errorf("UploadedAssets = %v, want %v", mock.UploadedAssets, want)
+	}
+	wantTypes := map[string]string{
+		zipPath:   "application/zip",
+		installer: "application/octet-stream",
+		sidecar:   "application/zip",
+	}
+	for path, want := range wantTypes {
+		if got := mock.ContentTypes[path]; got != want {
+			t.Errorf("content type of %s = %q, want %q", filepath.Base(path), got, want)
+		}
+	}
+}
+
+func TestPipeExtraAssetsErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "TestApp-v1.2.3.zip")
+	if err := os.WriteFile(zipPath, []byte("fake"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	otherDir := filepath.Join(tmpDir, "other")
+	if err := os.MkdirAll(otherDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(otherDir, "TestApp-v1.2.3.zip"), []byte("other"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		errMsg  string
+	}{
+		{name: "no match", pattern: filepath.Join(tmpDir, "*.pkg"), errMsg: "matched no files"},
+		{name: "directory", pattern: otherDir, errMsg: "not a regular file"},
+		{name: "name collides with package", pattern: filepath.Join(otherDir, "*.zip"), errMsg: "is used by both"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newContext()
+			ctx.Version = "v1.2.3"
+			mock := github.NewMockClient()
+			ctx.GitHubClient = mock
+			ctx.Artifacts.Packages = []string{zipPath}
+			ctx.Config.Release.GitHub.ExtraAssets = []string{tt.pattern}
+
+			err := Pipe{}.Run(ctx)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+			if len(mock.Releases["testowner/testrepo"]) != 0 {
+				t.Error("release was created despite invalid extra assets")
+			}
+		})
+	}
+}
+
+func TestPipeAssetNameTemplate(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+	ctx.Config.Release.GitHub.AssetNameTemplate = "{{.ProjectName}}-latest{{.Ext}}"
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "TestApp-v1.2.3.zip")
+	dmgPath := filepath.Join(tmpDir, "TestApp-v1.2.3.dmg")
+	for _, path := range []string{zipPath, dmgPath} {
+		if err := os.WriteFile(path, []byte("fake"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx.Artifacts.Packages = []string{zipPath, dmgPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		zipPath: "TestApp-latest.zip",
+		dmgPath: "TestApp-latest.dmg",
+	}
+	for path, name := range want {
+		if got := mock.AssetNames[path]; got != name {
+			t.Errorf("%s uploaded as %q, want %q", filepath.Base(path), got, name)
+		}
+		if got := ctx.Artifacts.AssetNames[path]; got != name {
+			t.Errorf("Artifacts.AssetNames[%s] = %q, want %q", filepath.Base(path), got, name)
+		}
+	}
+}
+
+func TestPipeContentTypes(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+	ctx.Config.Release.GitHub.ContentTypes = map[string]string{".zip": "application/octet-stream"}
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "TestApp-v1.2.3.zip")
+	dmgPath := filepath.Join(tmpDir, "TestApp-v1.2.3.dmg")
+	for _, path := range []string{zipPath, dmgPath} {
+		if err := os.WriteFile(path, []byte("fake"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx.Artifacts.Packages = []string{zipPath, dmgPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	want := map[string]string{
+		zipPath: "application/octet-stream",
+		dmgPath: "application/x-apple-diskimage",
+	}
+	for path, contentType := range want {
+		if got := mock.ContentTypes[path]; got != contentType {
+			t.Errorf("%s uploaded as %q, want %q", filepath.Base(path), got, contentType)
+		}
+	}
+}
+
+func TestPipeProjectNameSplit(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+	ctx.Config.Project.Name = "Test App"
+	ctx.Config.Project.DisplayName = "Test App™"
+	ctx.Config.Release.GitHub.AssetNameTemplate = "{{.ProjectName}}-latest{{.Ext}}"
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	zipPath := filepath.Join(t.TempDir(), "TestApp-v1.2.3.zip")
+	if err := os.WriteFile(zipPath, []byte("fake"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	if got := mock.AssetNames[zipPath]; got != "Test-App-latest.zip" {
+		t.Errorf("asset uploaded as %q, want the sanitized project name", got)
+	}
+	if got := mock.Releases["testowner/testrepo"][0].GetName(); got != "Test App™ v1.2.3" {
+		t.Errorf("release name = %q, want the display name", got)
+	}
+}
+
+func TestPipeAssetNameTemplateErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	zipPath := filepath.Join(tmpDir, "TestApp-v1.2.3.zip")
+	dmgPath := filepath.Join(tmpDir, "TestApp-v1.2.3.dmg")
+	for _, path := range []string{zipPath, dmgPath} {
+		if err := os.WriteFile(path, []byte("fake"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		errMsg   string
+	}{
+		{name: "collision", template: "{{.ProjectName}}-latest", errMsg: "is used by both"},
+		{name: "path separator", template: "latest/{{.Filename}}", errMsg: "not a valid file name"},
+		{name: "empty name", template: "{{if false}}x{{end}}", errMsg: "not a valid file name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newContext()
+			ctx.Version = "v1.2.3"
+			ctx.Config.Release.GitHub.AssetNameTemplate = tt.template
+			mock := github.NewMockClient()
+			ctx.GitHubClient = mock
+			ctx.Artifacts.Packages = []string{zipPath, dmgPath}
+
+			err := Pipe{}.Run(ctx)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+			if len(mock.Releases["testowner/testrepo"]) != 0 {
+				t.Error("release was created despite invalid asset names")
+			}
+		})
+	}
+}
+
+func TestPipePublishAfterUpload(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+	ctx.Config.Release.GitHub.PublishAfterUpload = true
+	ctx.Config.Release.GitHub.DiscussionCategory = "Announcements"
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	zipPath := filepath.Join(t.TempDir(), "TestApp-v1.2.3.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	// Created as a draft without the discussion category
+	created := mock.ReleaseRequests[0]
+	if !created.GetDraft() {
+		t.Error("release was not created as a draft")
+	}
+	if created.DiscussionCategoryName != nil {
+		t.Error("discussion category sent while creating the draft")
+	}
+	if len(mock.UploadedAssets) != 1 {
+		t.Errorf("UploadedAssets = %v, want the zip", mock.UploadedAssets)
+	}
+
+	// Published afterwards, with the discussion category
+	if len(mock.ReleaseUpdates) != 1 {
+		t.Fatalf("UpdateRelease called %d times, want 1", len(mock.ReleaseUpdates))
+	}
+	update := mock.ReleaseUpdates[0]
+	if update.Draft == nil || *update.Draft {
+		t.Error("UpdateRelease did not set Draft to false")
+	}
+	if got := update.DiscussionCategoryName; got == nil || *got != "Announcements" {
+		t.Errorf("UpdateRelease DiscussionCategoryName = %v, want %q", got, "Announcements")
+	}
+	if mock.Releases["testowner/testrepo"][0].GetDraft() {
+		t.Error("release is still a draft after publishing")
+	}
+}
+
+func TestPipePublishAfterUploadFailureLeavesDraft(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+	ctx.Config.Release.GitHub.PublishAfterUpload = true
+
+	mock := github.NewMockClient()
+	mock.UploadError = fmt.Errorf("connection reset")
+	ctx.GitHubClient = mock
+
+	zipPath := filepath.Join(t.TempDir(), "TestApp-v1.2.3.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	err := Pipe{}.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "left as a draft") {
+		t.Fatalf("Run() error = %v, want error mentioning the draft", err)
+	}
+	if len(mock.ReleaseUpdates) != 0 {
+		t.Error("release was published despite the failed upload")
+	}
+	if !mock.Releases["testowner/testrepo"][0].GetDraft() {
+		t.Error("release is not a draft after the failed upload")
+	}
+}
+
+func TestPipeRollingTag(t *testing.T) {
+	ctx := newContext()
+	ctx.Version = "v1.3.0-nightly.20261016"
+	ctx.Git.Commit = "abc123"
+	ctx.Config.Release.GitHub.RollingTag = "nightly"
+
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	zipPath := filepath.Join(t.TempDir(), "TestApp-nightly.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	// First run: nothing to replace yet
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("first Run() unexpected error: %v", err)
+	}
+	if len(mock.DeletedReleases) != 0 {
+		t.Errorf("DeletedReleases = %v, want none on the first run", mock.DeletedReleases)
+	}
+	first := mock.Releases["testowner/testrepo"][0]
+
+	// Second run: the new release is drafted and uploaded, and only then
+	// are the previous release and tag removed and the new one published
+	ctx.Version = "v1.3.0-nightly.20261017"
+	ctx.Git.Commit = "def456"
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("second Run() unexpected error: %v", err)
+	}
+
+	if len(mock.DeletedReleases) != 1 || mock.DeletedReleases[0] != first.GetID() {
+		t.Errorf("DeletedReleases = %v, want [%d]", mock.DeletedReleases, first.GetID())
+	}
+	if strings.Join(mock.DeletedTags, ",") != "nightly,nightly" {
+		t.Errorf("DeletedTags = %v, want nightly deleted before each release", mock.DeletedTags)
+	}
+
+	releases := mock.Releases["testowner/testrepo"]
+	if len(releases) != 1 {
+		t.Fatalf("expected 1 release after re-pointing, got %d", len(releases))
+	}
+	rel := releases[0]
+	if rel.GetTagName() != "nightly" {
+		t.Errorf("release tag = %q, want nightly", rel.GetTagName())
+	}
+	if rel.GetName() != "TestApp v1.3.0-nightly.20261017" {
+		t.Errorf("release name = %q, want the build version", rel.GetName())
+	}
+	if rel.GetTargetCommitish() != "def456" {
+		t.Errorf("release target = %q, want the new commit", rel.GetTargetCommitish())
+	}
+	if rel.GetDraft() {
+		t.Error("release is still a draft, want it published once the previous one was removed")
+	}
+	if len(mock.UploadedAssets) != 2 {
+		t.Errorf("UploadedAssets = %v, want the zip uploaded on each run", mock.UploadedAssets)
+	}
+}
+
+// newRollingContext returns a context releasing to the nightly rolling tag,
+// whose previous release already exists in the returned mock.
+func newRollingContext(t *testing.T) (*macCtx.Context, *github.MockClient, int64) {
+	t.Helper()
+
+	ctx := newContext()
+	ctx.Version = "v1.3.0-nightly.20261017"
+	ctx.Git.Commit = "def456"
+	ctx.Config.Release.GitHub.RollingTag = "nightly"
+
+	mock := github.NewMockClient()
+	previous, err := mock.CreateRelease(context.Background(), "testowner", "testrepo", &github.ReleaseRequest{
+		RepositoryRelease: &gogithub.RepositoryRelease{TagName: gogithub.String("nightly"), Name: gogithub.String("TestApp v1.3.0-nightly.20261016")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ReleaseRequests = nil
+	ctx.GitHubClient = mock
+
+	zipPath := filepath.Join(t.TempDir(), "TestApp-nightly.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	return ctx, mock, previous.GetID()
+}
+
+func TestPipeRollingTagKeepsPreviousOnFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(ctx *macCtx.Context, mock *github.MockClient)
+		errMsg  string
+		deleted bool // whether the previous release was deleted
+	}{
+		{
+			name:   "upload fails",
+			setup:  func(ctx *macCtx.Context, mock *github.MockClient) { mock.UploadError = fmt.Errorf("connection reset") },
+			errMsg: "left as a draft",
+		},
+		{
+			name: "delete declined",
+			setup: func(ctx *macCtx.Context, mock *github.MockClient) {
+				ctx.ConfirmFunc = func(action string) error { return fmt.Errorf("did not %s: cancelled", action) }
+			},
+			errMsg: "cancelled",
+		},
+		{
+			name:    "tag deletion fails",
+			setup:   func(ctx *macCtx.Context, mock *github.MockClient) { mock.DeleteTagError = fmt.Errorf("403 Forbidden") },
+			errMsg:  "failed to delete rolling tag nightly",
+			deleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, mock, previousID := newRollingContext(t)
+			tt.setup(ctx, mock)
+
+			err := Pipe{}.Run(ctx)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Fatalf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+			if deleted := len(mock.DeletedReleases) > 0; deleted != tt.deleted {
+				t.Errorf("previous release deleted = %v, want %v", deleted, tt.deleted)
+			}
+			if len(mock.ReleaseRequests) != 1 || !mock.ReleaseRequests[0].GetDraft() {
+				t.Errorf("want the new release created once, as a draft")
+			}
+			for _, rel := range mock.Releases["testowner/testrepo"] {
+				if rel.GetID() != previousID && !rel.GetDraft() {
+					t.Errorf("new release %d was published despite the failure", rel.GetID())
+				}
+			}
+		})
+	}
+}
+
+func TestPipeRollingTagMissingTag(t *testing.T) {
+	ctx, mock, previousID := newRollingContext(t)
+	// Someone already deleted the tag by hand
+	mock.DeleteTagError = &github.NotFoundError{Message: "tag nightly does not exist in testowner/testrepo"}
+
+	var asked []string
+	ctx.ConfirmFunc = func(action string) error {
+		asked = append(asked, action)
+		return nil
+	}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if len(mock.DeletedReleases) != 1 || mock.DeletedReleases[0] != previousID {
+		t.Errorf("DeletedReleases = %v, want [%d]", mock.DeletedReleases, previousID)
+	}
+	want := `delete the previous nightly release "TestApp v1.3.0-nightly.20261016" and its assets`
+	if len(asked) != 1 || asked[0] != want {
+		t.Errorf("asked %q, want %q", asked, want)
+	}
+}
+
 func TestPipeCreateReleaseError(t *testing.T) {
 	ctx := newContext()
 	ctx.Version = "v1.0.0"
@@ -330,3 +894,39 @@ func TestPipeSkipsNonRegularFiles(t *testing.T) {
 		t.Errorf("uploaded asset = %q, want %q", mock.UploadedAssets[0], zipPath)
 	}
 }
+
+func TestUploadProgress(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	ctx := macCtx.NewContext(context.Background(), &config.Config{}, logger)
+
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	progress := uploadProgress(ctx, "MyApp-v1.2.0.dmg", func() time.Time { return clock })
+
+	clock = clock.Add(10 * time.Second)
+	progress(768<<20, 1536<<20)
+
+	want := "Uploading MyApp-v1.2.0.dmg: 50% (768.0 MB of 1.5 GB, 76.8 MB/s)"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("log = %q, want it to contain %q", buf.String(), want)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    float64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1536, "1.5 KB"},
+		{5 << 20, "5.0 MB"},
+		{1536 << 20, "1.5 GB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%v) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
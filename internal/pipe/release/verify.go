@@ -0,0 +1,62 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	gogithub "github.com/google/go-github/github"
+	"github.com/macreleaser/macreleaser/pkg/context"
+	gh "github.com/macreleaser/macreleaser/pkg/github"
+)
+
+// verifyDownloads checks that each uploaded asset is served at its browser
+// download URL with the size GitHub recorded for it. GitHub occasionally
+// accepts an upload that its CDN then 404s on, which otherwise goes
+// unnoticed until users report broken downloads.
+func verifyDownloads(ctx *context.Context, assets []*gogithub.ReleaseAsset) error {
+	// Send release.github.headers so the requests pass the same proxy or
+	// gateway as the upload
+	client := gh.NewDownloadClient(time.Minute, ctx.Config.Release.GitHub.Headers)
+	var errs []error
+	for _, asset := range assets {
+		if err := verifyDownload(ctx, client, asset); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ctx.Logger.Infof("Verified download: %s", asset.GetName())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d release assets failed download verification: %w", len(errs), len(assets), errors.Join(errs...))
+	}
+	return nil
+}
+
+// verifyDownload sends a HEAD request for the asset and checks the status and
+// Content-Length of the final response.
+func verifyDownload(ctx *context.Context, client *http.Client, asset *gogithub.ReleaseAsset) error {
+	url := asset.GetBrowserDownloadURL()
+	if url == "" {
+		return fmt.Errorf("asset %s has no download URL", asset.GetName())
+	}
+
+	req, err := http.NewRequestWithContext(ctx.StdCtx, http.MethodHead, url, nil)
+	if err != nil {
+		return fmt.Errorf("asset %s: %w", asset.GetName(), err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("asset %s is not downloadable: %w", asset.GetName(), err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("asset %s is not downloadable: %s returned %s", asset.GetName(), url, resp.Status)
+	}
+	if length := resp.Header.Get("Content-Length"); length != strconv.Itoa(asset.GetSize()) {
+		return fmt.Errorf("asset %s is served with Content-Length %q, want %d bytes", asset.GetName(), length, asset.GetSize())
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package release
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/github"
+)
+
+func TestPipeVerifyDownload(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		length int // Content-Length served; -1 serves the file's size
+		errMsg string
+	}{
+		{name: "downloadable", status: http.StatusOK, length: -1},
+		{name: "not found", status: http.StatusNotFound, length: -1, errMsg: "404 Not Found"},
+		{name: "size mismatch", status: http.StatusOK, length: 3, errMsg: `Content-Length "3", want 8 bytes`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var methods []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				methods = append(methods, r.Method)
+				if got := r.Header.Get("X-Gateway-Token"); got != "secret" {
+					t.Errorf("X-Gateway-Token = %q, want the configured header", got)
+				}
+				length := tt.length
+				if length < 0 {
+					length = len("fake-zip")
+				}
+				w.Header().Set("Content-Length", strconv.Itoa(length))
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			ctx := newContext()
+			ctx.Version = "v1.2.3"
+			ctx.Config.Release.GitHub.VerifyDownload = true
+			ctx.Config.Release.GitHub.Headers = map[string]string{"X-Gateway-Token": "secret"}
+			mock := github.NewMockClient()
+			mock.DownloadBaseURL = srv.URL + "/download"
+			ctx.GitHubClient = mock
+
+			zipPath := filepath.Join(t.TempDir(), "TestApp-v1.2.3.zip")
+			if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			ctx.Artifacts.Packages = []string{zipPath}
+
+			err := Pipe{}.Run(ctx)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("Run() error = %v, want error containing %q", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("Run() unexpected error: %v", err)
+			}
+			if len(methods) != 1 || methods[0] != http.MethodHead {
+				t.Errorf("requests = %v, want a single HEAD", methods)
+			}
+		})
+	}
+}
+
+func TestPipeVerifyDownloadSkipsDrafts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s for a draft release", r.Method, r.URL)
+	}))
+	defer srv.Close()
+
+	ctx := newContext()
+	ctx.Version = "v1.2.3"
+	ctx.Config.Release.GitHub.Draft = true
+	ctx.Config.Release.GitHub.VerifyDownload = true
+	mock := github.NewMockClient()
+	mock.DownloadBaseURL = srv.URL
+	ctx.GitHubClient = mock
+
+	zipPath := filepath.Join(t.TempDir(), "TestApp-v1.2.3.zip")
+	if err := os.WriteFile(zipPath, []byte("fake-zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx.Artifacts.Packages = []string{zipPath}
+
+	if err := (Pipe{}).Run(ctx); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+}
@@ -1,17 +1,45 @@
 package sign
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/macreleaser/macreleaser/pkg/env"
 	"github.com/macreleaser/macreleaser/pkg/validate"
 )
 
+// skipError signals an intentional skip. It satisfies the pipe.IsSkip interface
+// checked by the pipeline runner, without importing pkg/pipe (which would cause
+// an import cycle through pkg/pipe/registry.go).
+type skipError string
+
+func (e skipError) Error() string { return string(e) }
+func (e skipError) IsSkip() bool  { return true }
+
+// skipReason returns why the check and execution pipes skip, or "" when
+// they run. It depends only on flags and configuration.
+func skipReason(ctx *context.Context) string {
+	if ctx.AppOnly {
+		return "skipped with --app-only"
+	}
+	return ""
+}
+
 // CheckPipe validates signing configuration
 type CheckPipe struct{}
 
 func (CheckPipe) String() string { return "validating signing configuration" }
 
+func (CheckPipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (CheckPipe) Run(ctx *context.Context) error {
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
 	cfg := ctx.Config.Sign
 
 	if err := env.CheckResolved(cfg.Identity, "sign.identity"); err != nil {
@@ -22,6 +50,35 @@ func (CheckPipe) Run(ctx *context.Context) error {
 		return err
 	}
 
+	if cfg.Keychain != "" {
+		if err := env.CheckResolved(cfg.Keychain, "sign.keychain"); err != nil {
+			return err
+		}
+		info, err := os.Stat(cfg.Keychain)
+		if err != nil {
+			return fmt.Errorf("sign.keychain %q not found: %w", cfg.Keychain, err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("sign.keychain %q is not a regular file — set it to the path of a .keychain-db file", cfg.Keychain)
+		}
+	}
+
+	if err := env.CheckResolved(cfg.KeychainPassword, "sign.keychain_password"); err != nil {
+		return err
+	}
+	if cfg.KeepKeychainUnlocked && cfg.Keychain == "" {
+		return fmt.Errorf("sign.keep_keychain_unlocked requires sign.keychain — it would change the settings of the default keychain")
+	}
+
+	for _, pattern := range cfg.SkipPaths {
+		if filepath.IsAbs(pattern) || pattern == ".." || strings.HasPrefix(pattern, "../") {
+			return fmt.Errorf("sign.skip_paths entry %q must be a relative path inside the .app bundle", pattern)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid sign.skip_paths entry %q: %w", pattern, err)
+		}
+	}
+
 	ctx.Logger.Debug("Signing configuration validated successfully")
 	return nil
 }
@@ -2,6 +2,8 @@ package sign
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -76,3 +78,99 @@ func TestCheckPipeString(t *testing.T) {
 		t.Errorf("String() = %q, want %q", got, expected)
 	}
 }
+
+func TestCheckPipeKeychain(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	dir := t.TempDir()
+	keychain := filepath.Join(dir, "build.keychain-db")
+	if err := os.WriteFile(keychain, []byte("keychain"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		keychain string
+		errMsg   string
+	}{
+		{name: "keychain file", keychain: keychain},
+		{name: "missing keychain", keychain: filepath.Join(dir, "missing.keychain-db"), errMsg: "not found"},
+		{name: "keychain is a directory", keychain: dir, errMsg: "is not a regular file"},
+		{name: "unresolved keychain", keychain: "env(CI_KEYCHAIN)", errMsg: "sign.keychain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Sign: config.SignConfig{
+					Identity: "Developer ID Application: John Doe (TEAM123)",
+					Keychain: tt.keychain,
+				},
+			}
+			err := CheckPipe{}.Run(macCtx.NewContext(context.Background(), cfg, logger))
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Run() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestCheckPipeKeychainPassword(t *testing.T) {
+	logger := logrus.New()
+	identity := "Developer ID Application: John Doe (TEAM123)"
+
+	ctx := macCtx.NewContext(context.Background(), &config.Config{
+		Sign: config.SignConfig{Identity: identity, KeychainPassword: "env(MACRELEASER_TEST_UNSET_KEYCHAIN_PASSWORD)"},
+	}, logger)
+	if err := (CheckPipe{}).Run(ctx); err == nil || !strings.Contains(err.Error(), "sign.keychain_password") {
+		t.Errorf("Run() error = %v, want unresolved sign.keychain_password error", err)
+	}
+
+	ctx = macCtx.NewContext(context.Background(), &config.Config{
+		Sign: config.SignConfig{Identity: identity, KeychainPassword: "secret", KeepKeychainUnlocked: true},
+	}, logger)
+	if err := (CheckPipe{}).Run(ctx); err == nil || !strings.Contains(err.Error(), "requires sign.keychain") {
+		t.Errorf("Run() error = %v, want keep_keychain_unlocked error", err)
+	}
+}
+
+func TestCheckPipeSkipPaths(t *testing.T) {
+	logger := logrus.New()
+	identity := "Developer ID Application: John Doe (TEAM123)"
+
+	tests := []struct {
+		name   string
+		skip   []string
+		errMsg string
+	}{
+		{name: "valid globs", skip: []string{"Contents/Frameworks/Sparkle.framework", "Contents/PlugIns/*.appex"}},
+		{name: "absolute path", skip: []string{"/Applications/MyApp.app/Contents/Frameworks"}, errMsg: "must be a relative path"},
+		{name: "outside the bundle", skip: []string{"../Other.app"}, errMsg: "must be a relative path"},
+		{name: "malformed glob", skip: []string{"Contents/[abc"}, errMsg: "invalid sign.skip_paths entry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := macCtx.NewContext(context.Background(), &config.Config{
+				Sign: config.SignConfig{Identity: identity, SkipPaths: tt.skip},
+			}, logger)
+			err := CheckPipe{}.Run(ctx)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("Run() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Run() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
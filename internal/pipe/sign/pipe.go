@@ -12,16 +12,27 @@ type Pipe struct{}
 
 func (Pipe) String() string { return "signing application" }
 
+func (Pipe) Skip(ctx *context.Context) string { return skipReason(ctx) }
+
 func (Pipe) Run(ctx *context.Context) error {
+	if reason := skipReason(ctx); reason != "" {
+		return skipError(reason)
+	}
+
 	if ctx.Artifacts.AppPath == "" {
 		return fmt.Errorf("no .app found to sign — ensure the build step completed successfully")
 	}
 
 	identity := ctx.Config.Sign.Identity
+	keychain := ctx.Config.Sign.Keychain
+
+	if err := unlockKeychain(ctx); err != nil {
+		return err
+	}
 
 	// Validate that the configured identity exists in the keychain
 	ctx.Logger.Infof("Validating signing identity: %s", identity)
-	if err := sign.CheckIdentityInKeychain(identity); err != nil {
+	if err := sign.CheckIdentityInKeychain(ctx.StdCtx, identity, keychain); err != nil {
 		return fmt.Errorf("identity validation failed: %w", err)
 	}
 
@@ -32,17 +43,13 @@ func (Pipe) Run(ctx *context.Context) error {
 	}
 
 	// Sign the .app bundle in-place
-	ctx.Logger.Infof("Signing %s", ctx.Artifacts.AppPath)
-	output, err := sign.RunCodesign(identity, ctx.Artifacts.AppPath, hardenedRuntime)
-	if err != nil {
-		ctx.Logger.Debug(output)
-		return fmt.Errorf("signing failed: %w", err)
+	if err := signBundle(ctx, identity, hardenedRuntime, keychain); err != nil {
+		return err
 	}
-	ctx.Logger.Debug(output)
 
 	// Verify the signature
 	ctx.Logger.Info("Verifying signature")
-	output, err = sign.RunVerify(ctx.Artifacts.AppPath)
+	output, err := sign.RunVerify(ctx.StdCtx, ctx.Artifacts.AppPath)
 	if err != nil {
 		ctx.Logger.Debug(output)
 		return fmt.Errorf("signature verification failed: %w", err)
@@ -52,3 +59,62 @@ func (Pipe) Run(ctx *context.Context) error {
 	ctx.Logger.Infof("Signed and verified: %s", ctx.Artifacts.AppPath)
 	return nil
 }
+
+// signBundle signs the .app with codesign --deep, or, when sign.skip_paths
+// is set, signs its nested code bottom-up item by item, leaving the skipped
+// entries with the signature they already have.
+func signBundle(ctx *context.Context, identity string, hardenedRuntime bool, keychain string) error {
+	appPath := ctx.Artifacts.AppPath
+	skip := ctx.Config.Sign.SkipPaths
+	if len(skip) == 0 {
+		ctx.Logger.Infof("Signing %s", appPath)
+		output, err := sign.RunCodesign(ctx.StdCtx, identity, appPath, hardenedRuntime, keychain)
+		ctx.Logger.Debug(output)
+		if err != nil {
+			return fmt.Errorf("signing failed: %w", err)
+		}
+		return nil
+	}
+
+	plan, err := sign.BuildSignPlan(appPath, skip)
+	if err != nil {
+		return err
+	}
+	ctx.Logger.Infof("Signing %s item by item (%d items, sign.skip_paths excluded)", appPath, len(plan))
+	for _, path := range plan {
+		ctx.Logger.Debugf("Signing %s", path)
+		output, err := sign.RunCodesignItem(ctx.StdCtx, identity, path, hardenedRuntime, keychain)
+		ctx.Logger.Debug(output)
+		if err != nil {
+			return fmt.Errorf("signing %s failed: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// unlockKeychain unlocks the signing keychain when sign.keychain_password is
+// set, and turns off its auto-lock when sign.keep_keychain_unlocked is set.
+// The password is never logged.
+func unlockKeychain(ctx *context.Context) error {
+	cfg := ctx.Config.Sign
+	if cfg.KeychainPassword == "" {
+		return nil
+	}
+
+	keychain := cfg.Keychain
+	if keychain == "" {
+		keychain = "default keychain"
+	}
+	ctx.Logger.Infof("Unlocking %s", keychain)
+	if err := sign.UnlockKeychain(ctx.StdCtx, cfg.KeychainPassword, cfg.Keychain); err != nil {
+		return fmt.Errorf("failed to unlock keychain: %w", err)
+	}
+
+	if cfg.KeepKeychainUnlocked {
+		ctx.Logger.Debugf("Disabling auto-lock for %s", cfg.Keychain)
+		if err := sign.DisableKeychainAutoLock(ctx.StdCtx, cfg.Keychain); err != nil {
+			return fmt.Errorf("failed to disable keychain auto-lock: %w", err)
+		}
+	}
+	return nil
+}
@@ -1,10 +1,15 @@
 package sign
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/macreleaser/macreleaser/pkg/command"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macCtx "github.com/macreleaser/macreleaser/pkg/context"
 	"github.com/sirupsen/logrus"
@@ -38,3 +43,93 @@ func TestPipeNoApp(t *testing.T) {
 		t.Errorf("Run() error = %v, want error containing %q", err, "no .app found to sign")
 	}
 }
+
+func TestUnlockKeychainNeverLogsPassword(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+	logger.SetOutput(&buf)
+
+	ctx := macCtx.NewContext(context.Background(), &config.Config{
+		Sign: config.SignConfig{
+			Identity:             "Developer ID Application: John Doe (TEAM123)",
+			Keychain:             "/tmp/build.keychain-db",
+			KeychainPassword:     "hunter2",
+			KeepKeychainUnlocked: true,
+		},
+	}, logger)
+
+	// security echoes the failing command line, password included
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		return "security " + strings.Join(args, " ") + ": The specified keychain could not be found.", errors.New("exit status 50")
+	}}
+	ctx.StdCtx = command.WithRunner(ctx.StdCtx, fake)
+
+	err := unlockKeychain(ctx)
+	if err == nil {
+		t.Fatal("unlockKeychain() expected error, got nil")
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("unlockKeychain() error reveals the password: %v", err)
+	}
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("log reveals the password:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "Unlocking /tmp/build.keychain-db") {
+		t.Errorf("log does not mention unlocking the keychain:\n%s", buf.String())
+	}
+}
+
+func TestUnlockKeychainWithoutPassword(t *testing.T) {
+	logger := logrus.New()
+	ctx := macCtx.NewContext(context.Background(), &config.Config{}, logger)
+	if err := unlockKeychain(ctx); err != nil {
+		t.Errorf("unlockKeychain() without a password error = %v, want nil", err)
+	}
+}
+
+func TestSignBundle(t *testing.T) {
+	appPath := filepath.Join(t.TempDir(), "MyApp.app")
+	frameworkPath := filepath.Join(appPath, "Contents", "Frameworks", "Sparkle.framework")
+	if err := os.MkdirAll(frameworkPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		skipPaths []string
+		want      []string
+	}{
+		{
+			name: "deep signing",
+			want: []string{"codesign --deep --force --options runtime --sign Developer ID Application: John Doe (TEAM123) " + appPath},
+		},
+		{
+			name:      "item by item",
+			skipPaths: []string{"Contents/Frameworks/Other.framework"},
+			want: []string{
+				"codesign --force --options runtime --sign Developer ID Application: John Doe (TEAM123) " + frameworkPath,
+				"codesign --force --options runtime --sign Developer ID Application: John Doe (TEAM123) " + appPath,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := macCtx.NewContext(context.Background(), &config.Config{
+				Sign: config.SignConfig{SkipPaths: tt.skipPaths},
+			}, logrus.New())
+			ctx.Artifacts.AppPath = appPath
+			fake := &command.Fake{}
+			ctx.StdCtx = command.WithRunner(ctx.StdCtx, fake)
+
+			if err := signBundle(ctx, "Developer ID Application: John Doe (TEAM123)", true, ""); err != nil {
+				t.Fatalf("signBundle() unexpected error: %v", err)
+			}
+			got := fake.Commands()
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("signBundle() ran:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
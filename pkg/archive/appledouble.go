@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path"
+
+	"github.com/macreleaser/macreleaser/pkg/xattr"
+)
+
+// AppleDouble layout, as written by ditto and copyfile(3) for the
+// "__MACOSX/._<name>" entries that carry a file's extended attributes in a ZIP
+const (
+	appleDoubleMagic   = 0x00051607
+	appleDoubleVersion = 0x00020000
+	attrHeaderMagic    = 0x41545452 // "ATTR"
+
+	finderInfoEntryID   = 9
+	resourceForkEntryID = 2
+
+	finderInfoName   = "com.apple.FinderInfo"
+	resourceForkName = "com.apple.ResourceFork"
+
+	finderInfoOffset = 50 // after the header and its two entry descriptors
+	finderInfoSize   = 32
+	attrHeaderOffset = finderInfoOffset + finderInfoSize + 2 // padded for alignment
+	attrEntriesStart = attrHeaderOffset + 36
+)
+
+// appleDoubleName returns the ZIP entry that holds the extended attributes
+// of the entry name.
+func appleDoubleName(name string) string {
+	dir, base := path.Split(name)
+	return "__MACOSX/" + dir + "._" + base
+}
+
+// readAppleDouble returns the extended attributes of file encoded as an
+// AppleDouble file, or nil when it has none.
+func readAppleDouble(file string) ([]byte, error) {
+	names, err := xattr.List(file)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+	values := make(map[string][]byte, len(names))
+	for _, name := range names {
+		if values[name], err = xattr.Get(file, name); err != nil {
+			return nil, err
+		}
+	}
+	return encodeAppleDouble(names, values)
+}
+
+// encodeAppleDouble encodes attributes as an AppleDouble file. Finder info
+// and the resource fork have entries of their own; the rest follow in the
+// order of names. Nothing that varies between builds, such as the file ID
+// ditto records, is written.
+func encodeAppleDouble(names []string, values map[string][]byte) ([]byte, error) {
+	var attrs []string
+	entriesSize := 0
+	dataSize := 0
+	for _, name := range names {
+		if name == finderInfoName || name == resourceForkName {
+			continue
+		}
+		if len(name) > 254 {
+			return nil, fmt.Errorf("extended attribute name too long: %s", name)
+		}
+		attrs = append(attrs, name)
+		entriesSize += attrEntrySize(name)
+		dataSize += len(values[name])
+	}
+
+	dataStart := attrEntriesStart + entriesSize
+	totalSize := dataStart + dataSize
+	rsrc := values[resourceForkName]
+
+	buf := make([]byte, totalSize, totalSize+len(rsrc))
+	be := binary.BigEndian
+
+	be.PutUint32(buf[0:], appleDoubleMagic)
+	be.PutUint32(buf[4:], appleDoubleVersion)
+	copy(buf[8:24], "Mac OS X        ")
+	be.PutUint16(buf[24:], 2)
+	be.PutUint32(buf[26:], finderInfoEntryID)
+	be.PutUint32(buf[30:], finderInfoOffset)
+	be.PutUint32(buf[34:], uint32(totalSize-finderInfoOffset))
+	be.PutUint32(buf[38:], resourceForkEntryID)
+	be.PutUint32(buf[42:], uint32(totalSize))
+	be.PutUint32(buf[46:], uint32(len(rsrc)))
+	copy(buf[finderInfoOffset:finderInfoOffset+finderInfoSize], values[finderInfoName])
+
+	h := buf[attrHeaderOffset:]
+	be.PutUint32(h[0:], attrHeaderMagic)
+	be.PutUint32(h[8:], uint32(totalSize))
+	be.PutUint32(h[12:], uint32(dataStart))
+	be.PutUint32(h[16:], uint32(dataSize))
+	be.PutUint16(h[34:], uint16(len(attrs)))
+
+	entry := attrEntriesStart
+	data := dataStart
+	for _, name := range attrs {
+		value := values[name]
+		be.PutUint32(buf[entry:], uint32(data))
+		be.PutUint32(buf[entry+4:], uint32(len(value)))
+		buf[entry+10] = byte(len(name) + 1)
+		copy(buf[entry+11:], name)
+		copy(buf[data:], value)
+		entry += attrEntrySize(name)
+		data += len(value)
+	}
+
+	return append(buf, rsrc...), nil
+}
+
+// attrEntrySize is the size of an attribute's entry: offset, length, flags,
+// and its NUL-terminated name, padded to four bytes.
+func attrEntrySize(name string) int {
+	return (11 + len(name) + 1 + 3) &^ 3
+}
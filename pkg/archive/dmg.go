@@ -1,29 +1,29 @@
 package archive
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 // CreateDMG creates a DMG disk image containing the given .app using hdiutil.
+// appPath may also be a staging directory, whose contents form the volume.
 // volumeName is the name shown when the DMG is mounted.
 // Returns on success or error.
-func CreateDMG(appPath, outputPath, volumeName string) error {
-	if _, err := exec.LookPath("hdiutil"); err != nil {
-		return fmt.Errorf("hdiutil not found — this tool is required for DMG packaging on macOS")
-	}
-
-	cmd := exec.Command("hdiutil", "create",
+func CreateDMG(ctx context.Context, appPath, outputPath, volumeName string) error {
+	out, err := command.Run(ctx, "hdiutil", "create",
 		"-volname", volumeName,
 		"-srcfolder", appPath,
 		"-ov",
 		"-format", "UDZO",
 		outputPath,
 	)
-
-	out, err := cmd.CombinedOutput()
+	if command.IsNotFound(err) {
+		return fmt.Errorf("hdiutil not found — this tool is required for DMG packaging on macOS")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create DMG image: %s: %w", string(out), err)
+		return fmt.Errorf("failed to create DMG image: %s: %w", out, err)
 	}
 
 	return nil
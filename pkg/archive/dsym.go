@@ -0,0 +1,107 @@
+package archive
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DSYMSuffix is the filename suffix of the debug symbols package produced
+// alongside the release archives.
+const DSYMSuffix = ".dSYM.zip"
+
+// FindDSYMs returns the .dSYM bundles in the dSYMs directory of the
+// .xcarchive at archivePath.
+func FindDSYMs(archivePath string) ([]string, error) {
+	dsymsDir := filepath.Join(archivePath, "dSYMs")
+	entries, err := os.ReadDir(dsymsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read .xcarchive dSYMs: %w", err)
+	}
+
+	var dsyms []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasSuffix(entry.Name(), ".dSYM") {
+			dsyms = append(dsyms, filepath.Join(dsymsDir, entry.Name()))
+		}
+	}
+	if len(dsyms) == 0 {
+		return nil, fmt.Errorf("no .dSYM found in %s — set DEBUG_INFORMATION_FORMAT to dwarf-with-dsym for the release configuration", dsymsDir)
+	}
+	return dsyms, nil
+}
+
+// CreateDSYMZip writes the given .dSYM bundles into a ZIP at outputPath,
+// each under its bundle name. dSYM bundles hold only plain files, so Go's
+// archive/zip is used instead of ditto.
+func CreateDSYMZip(dsymPaths []string, outputPath string) (err error) {
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create dSYM ZIP: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("failed to close dSYM ZIP: %w", cerr)
+		}
+	}()
+
+	zw := zip.NewWriter(f)
+	for _, dsym := range dsymPaths {
+		if err := addDirToZip(zw, dsym); err != nil {
+			return fmt.Errorf("failed to add %s to dSYM ZIP: %w", filepath.Base(dsym), err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize dSYM ZIP: %w", err)
+	}
+	return nil
+}
+
+// addDirToZip adds dir and its contents to zw, rooted at dir's base name.
+func addDirToZip(zw *zip.Writer, dir string) error {
+	parent := filepath.Dir(dir)
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(parent, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			_, err := zw.CreateHeader(&zip.FileHeader{Name: name + "/", Modified: info.ModTime()})
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("unsupported file type at %s", path)
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = src.Close() }()
+		_, err = io.Copy(w, src)
+		return err
+	})
+}
@@ -0,0 +1,88 @@
+package archive
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultExcludes are Finder and resource-fork artifacts that are always
+// left out of ZIP and DMG packages, outside the .app.
+var DefaultExcludes = []string{".DS_Store", "__MACOSX", "._*"}
+
+// CheckExcludePattern verifies that an archive.exclude entry is a
+// well-formed glob that does not reach into the .app, which RemoveExcluded
+// leaves alone.
+func CheckExcludePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("invalid archive.exclude entry: must not be empty")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid archive.exclude entry %q: %w", pattern, err)
+	}
+	if first, _, ok := strings.Cut(pattern, "/"); ok && strings.HasSuffix(first, ".app") {
+		return fmt.Errorf("invalid archive.exclude entry %q: files inside the signed .app cannot be excluded without breaking its signature — leave them out of the Xcode build instead", pattern)
+	}
+	return nil
+}
+
+// IsExcluded reports whether the entry at rel, a slash-separated path
+// relative to the package root, matches one of the default excludes or the
+// given patterns. Patterns without a slash match the entry's name at any
+// depth; patterns with a slash match the whole relative path.
+func IsExcluded(rel string, patterns []string) bool {
+	return matchesAny(rel, DefaultExcludes) || matchesAny(rel, patterns)
+}
+
+func matchesAny(rel string, patterns []string) bool {
+	for _, pattern := range patterns {
+		target := path.Base(rel)
+		if strings.Contains(pattern, "/") {
+			target = rel
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveExcluded deletes every file and directory under dir that IsExcluded
+// matches and returns their paths relative to dir. It is run on a staging
+// directory, never on the build output itself. The .app bundles at the top
+// of dir are left as they are, since they are signed by now and removing
+// anything their signature seals would break it.
+func RemoveExcluded(dir string, patterns []string) ([]string, error) {
+	var removed []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() && !strings.Contains(rel, "/") && strings.HasSuffix(rel, ".app") {
+			return filepath.SkipDir
+		}
+		if !IsExcluded(rel, patterns) {
+			return nil
+		}
+		if err := os.RemoveAll(p); err != nil {
+			return fmt.Errorf("failed to remove excluded %s: %w", rel, err)
+		}
+		removed = append(removed, rel)
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return removed, err
+}
@@ -0,0 +1,132 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestIsExcluded(t *testing.T) {
+	patterns := []string{"*.log", "MyApp.app/Contents/Resources/debug"}
+
+	tests := []struct {
+		rel  string
+		want bool
+	}{
+		{rel: ".DS_Store", want: true},
+		{rel: "MyApp.app/Contents/.DS_Store", want: true},
+		{rel: "__MACOSX", want: true},
+		{rel: "._MyApp.app", want: true},
+		{rel: "MyApp.app/Contents/._Info.plist", want: true},
+		{rel: "notes.log", want: true},
+		{rel: "MyApp.app/Contents/Resources/build.log", want: true},
+		{rel: "MyApp.app/Contents/Resources/debug", want: true},
+		{rel: "debug", want: false},
+		{rel: "MyApp.app/Contents/Info.plist", want: false},
+		{rel: "README.md", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsExcluded(tt.rel, patterns); got != tt.want {
+			t.Errorf("IsExcluded(%q) = %v, want %v", tt.rel, got, tt.want)
+		}
+	}
+}
+
+func TestCheckExcludePattern(t *testing.T) {
+	for _, pattern := range []string{"*.log", "docs/*", "Thumbs.db"} {
+		if err := CheckExcludePattern(pattern); err != nil {
+			t.Errorf("CheckExcludePattern(%q) unexpected error: %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"", "[", "docs/[a", "MyApp.app/Contents/Resources/debug", "*.app/Contents/*.log"} {
+		if err := CheckExcludePattern(pattern); err == nil {
+			t.Errorf("CheckExcludePattern(%q) expected error", pattern)
+		}
+	}
+}
+
+func TestRemoveExcluded(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".DS_Store"), "finder")
+	writeFile(t, filepath.Join(dir, "__MACOSX", "._MyApp.app"), "rsrc")
+	writeFile(t, filepath.Join(dir, "MyApp.app", "Contents", "Info.plist"), "<plist/>")
+	writeFile(t, filepath.Join(dir, "MyApp.app", "Contents", ".DS_Store"), "finder")
+	writeFile(t, filepath.Join(dir, "MyApp.app", "Contents", "._Info.plist"), "rsrc")
+	writeFile(t, filepath.Join(dir, "build.log"), "log")
+	writeFile(t, filepath.Join(dir, "README.md"), "readme")
+
+	removed, err := RemoveExcluded(dir, []string{"*.log"})
+	if err != nil {
+		t.Fatalf("RemoveExcluded() unexpected error: %v", err)
+	}
+
+	sort.Strings(removed)
+	want := []string{".DS_Store", "__MACOSX", "build.log"}
+	if strings.Join(removed, ",") != strings.Join(want, ",") {
+		t.Errorf("RemoveExcluded() = %v, want %v", removed, want)
+	}
+
+	for _, rel := range want {
+		if _, err := os.Stat(filepath.Join(dir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after RemoveExcluded()", rel)
+		}
+	}
+	// The signed .app is left untouched
+	for _, rel := range []string{"MyApp.app/Contents/Info.plist", "MyApp.app/Contents/.DS_Store", "MyApp.app/Contents/._Info.plist", "README.md"} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err != nil {
+			t.Errorf("%s was removed: %v", rel, err)
+		}
+	}
+}
+
+func TestCreateZipFromDirOmitsExcluded(t *testing.T) {
+	if _, err := exec.LookPath("ditto"); err != nil {
+		t.Skip("Skipping: ditto not available in this environment")
+	}
+
+	dir := t.TempDir()
+	appPath := filepath.Join(dir, "MyApp.app")
+	writeFile(t, filepath.Join(appPath, "Contents", "Info.plist"), "<plist/>")
+	tracePath := filepath.Join(dir, "trace.log")
+	writeFile(t, tracePath, "log")
+
+	stagingDir := filepath.Join(dir, "staging")
+	if err := Stage(context.Background(), appPath, stagingDir, []string{tracePath}); err != nil {
+		t.Fatalf("Stage() unexpected error: %v", err)
+	}
+	writeFile(t, filepath.Join(stagingDir, ".DS_Store"), "finder")
+	if _, err := RemoveExcluded(stagingDir, []string{"*.log"}); err != nil {
+		t.Fatalf("RemoveExcluded() unexpected error: %v", err)
+	}
+
+	zipPath := filepath.Join(dir, "MyApp-1.0.0.zip")
+	if err := CreateZipFromDir(context.Background(), stagingDir, zipPath); err != nil {
+		t.Fatalf("CreateZipFromDir() unexpected error: %v", err)
+	}
+
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("failed to open ZIP: %v", err)
+	}
+	defer r.Close()
+
+	found := false
+	for _, f := range r.File {
+		name := strings.TrimSuffix(f.Name, "/")
+		if name == ".DS_Store" || name == "trace.log" {
+			t.Errorf("ZIP contains excluded entry %q", f.Name)
+		}
+		if name == "MyApp.app/Contents/Info.plist" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("ZIP missing MyApp.app/Contents/Info.plist")
+	}
+}
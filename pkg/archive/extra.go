@@ -0,0 +1,128 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// CheckExtraFilePattern verifies that an archive.extra_files entry is a
+// well-formed glob that stays within the project directory.
+func CheckExtraFilePattern(pattern string) error {
+	if !filepath.IsLocal(pattern) {
+		return fmt.Errorf("invalid archive.extra_files entry %q: must be a relative path inside the project without '..'", pattern)
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid archive.extra_files entry %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// ResolveExtraFiles expands archive.extra_files glob patterns relative to the
+// working directory. Each pattern must match at least one regular file, and
+// matched files must have distinct names since they are placed side by side.
+func ResolveExtraFiles(patterns []string) ([]string, error) {
+	var files []string
+	seen := make(map[string]string)
+
+	for _, pattern := range patterns {
+		if err := CheckExtraFilePattern(pattern); err != nil {
+			return nil, err
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive.extra_files entry %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("archive.extra_files entry %q matched no files", pattern)
+		}
+
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat extra file %s: %w", match, err)
+			}
+			if !info.Mode().IsRegular() {
+				return nil, fmt.Errorf("archive.extra_files entry %q matched %s, which is not a regular file", pattern, match)
+			}
+
+			name := filepath.Base(match)
+			if prev, ok := seen[name]; ok {
+				if prev == match {
+					continue
+				}
+				return nil, fmt.Errorf("archive.extra_files matched two files named %q: %s and %s", name, prev, match)
+			}
+			seen[name] = match
+			files = append(files, match)
+		}
+	}
+
+	return files, nil
+}
+
+// Stage creates stagingDir containing a copy of the .app (made with ditto to
+// preserve signatures and extended attributes) and the given extra files.
+// The staging directory is packaged in place of the .app for zip and dmg.
+func Stage(ctx context.Context, appPath, stagingDir string, extraFiles []string) error {
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return fmt.Errorf("failed to clear staging directory: %w", err)
+	}
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	dest := filepath.Join(stagingDir, filepath.Base(appPath))
+	out, err := command.Run(ctx, "ditto", appPath, dest)
+	if command.IsNotFound(err) {
+		return fmt.Errorf("ditto not found — this tool is required for staging archives on macOS")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy app into staging directory: %s: %w", out, err)
+	}
+
+	return CopyExtraFiles(stagingDir, extraFiles)
+}
+
+// CopyExtraFiles copies each file into dir, keeping its base name.
+func CopyExtraFiles(dir string, files []string) error {
+	for _, file := range files {
+		if err := CopyFile(file, filepath.Join(dir, filepath.Base(file))); err != nil {
+			return fmt.Errorf("failed to copy extra file %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// CopyFile copies the contents and permission bits of the regular file src
+// to dst, replacing dst if it exists.
+func CopyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	// Set the mode explicitly, as the umask would otherwise clear bits
+	return os.Chmod(dst, info.Mode().Perm())
+}
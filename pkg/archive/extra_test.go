@@ -0,0 +1,156 @@
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckExtraFilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{pattern: "README.md"},
+		{pattern: "docs/*.txt"},
+		{pattern: "LICENSE*"},
+		{pattern: "../secrets.txt", wantErr: true},
+		{pattern: "docs/../../etc/passwd", wantErr: true},
+		{pattern: "/etc/passwd", wantErr: true},
+		{pattern: "", wantErr: true},
+		{pattern: "docs/[", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := CheckExtraFilePattern(tt.pattern)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckExtraFilePattern(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveExtraFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	writeFile(t, "README.md", "readme")
+	writeFile(t, "LICENSE", "license")
+	writeFile(t, "docs/a.txt", "a")
+	writeFile(t, "docs/b.txt", "b")
+	writeFile(t, "other/a.txt", "other a")
+
+	t.Run("expands globs", func(t *testing.T) {
+		got, err := ResolveExtraFiles([]string{"README.md", "LICENSE", "docs/*.txt"})
+		if err != nil {
+			t.Fatalf("ResolveExtraFiles() unexpected error: %v", err)
+		}
+		want := []string{"README.md", "LICENSE", "docs/a.txt", "docs/b.txt"}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("ResolveExtraFiles() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("duplicate match is listed once", func(t *testing.T) {
+		got, err := ResolveExtraFiles([]string{"README.md", "README*"})
+		if err != nil {
+			t.Fatalf("ResolveExtraFiles() unexpected error: %v", err)
+		}
+		if len(got) != 1 {
+			t.Errorf("ResolveExtraFiles() = %v, want a single entry", got)
+		}
+	})
+
+	errTests := []struct {
+		name     string
+		patterns []string
+		errMsg   string
+	}{
+		{name: "no match", patterns: []string{"CHANGES.md"}, errMsg: "matched no files"},
+		{name: "traversal", patterns: []string{"../README.md"}, errMsg: "must be a relative path"},
+		{name: "directory", patterns: []string{"docs"}, errMsg: "not a regular file"},
+		{name: "name collision", patterns: []string{"docs/a.txt", "other/a.txt"}, errMsg: "two files named"},
+	}
+
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveExtraFiles(tt.patterns)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ResolveExtraFiles() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestCreateZipFromDirIncludesExtraFiles(t *testing.T) {
+	if _, err := exec.LookPath("ditto"); err != nil {
+		t.Skip("Skipping: ditto not available in this environment")
+	}
+
+	dir := t.TempDir()
+	appPath := filepath.Join(dir, "MyApp.app")
+	writeFile(t, filepath.Join(appPath, "Contents", "Info.plist"), "<plist/>")
+	writeFile(t, filepath.Join(dir, "README.md"), "readme")
+	writeFile(t, filepath.Join(dir, "LICENSE"), "license")
+
+	stagingDir := filepath.Join(dir, "staging")
+	extraFiles := []string{filepath.Join(dir, "README.md"), filepath.Join(dir, "LICENSE")}
+	if err := Stage(context.Background(), appPath, stagingDir, extraFiles); err != nil {
+		t.Fatalf("Stage() unexpected error: %v", err)
+	}
+
+	zipPath := filepath.Join(dir, "MyApp-1.0.0.zip")
+	if err := CreateZipFromDir(context.Background(), stagingDir, zipPath); err != nil {
+		t.Fatalf("CreateZipFromDir() unexpected error: %v", err)
+	}
+
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		t.Fatalf("failed to open ZIP: %v", err)
+	}
+	defer r.Close()
+
+	entries := make(map[string]bool)
+	for _, f := range r.File {
+		entries[f.Name] = true
+	}
+	for _, want := range []string{"README.md", "LICENSE", "MyApp.app/Contents/Info.plist"} {
+		if !entries[want] {
+			t.Errorf("ZIP missing entry %q; entries: %v", want, entries)
+		}
+	}
+}
+
+func TestCopyExtraFiles(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src", "LICENSE")
+	writeFile(t, src, "license text")
+
+	dest := filepath.Join(dir, "staging")
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyExtraFiles(dest, []string{src}); err != nil {
+		t.Fatalf("CopyExtraFiles() unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dest, "LICENSE"))
+	if err != nil {
+		t.Fatalf("extra file not copied: %v", err)
+	}
+	if string(got) != "license text" {
+		t.Errorf("copied content = %q, want %q", got, "license text")
+	}
+}
@@ -0,0 +1,144 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// licenseResourceID is the ID of the English license resources; the
+// language table refers to them by their offset from 5000.
+const licenseResourceID = 5000
+
+// licenseButtons are the language name, button titles, and prompt shown
+// with an English license, in the order the STR# resource lists them.
+var licenseButtons = []string{
+	"English",
+	"Agree",
+	"Disagree",
+	"Print",
+	"Save...",
+	`If you agree with the terms of this license, press "Agree" to install the software. If you do not agree, press "Disagree".`,
+}
+
+// IsRTF reports whether the license file at path is RTF rather than plain
+// text, judged by its extension.
+func IsRTF(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".rtf")
+}
+
+// TextToRTF converts plain UTF-8 text to an RTF document, so a plain-text
+// license is embedded the same way as an RTF one. Characters outside ASCII
+// are written as \u escapes.
+func TextToRTF(text string) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{\rtf1\ansi\ansicpg1252\deff0{\fonttbl{\f0\fswiss Helvetica;}}\f0\fs24 `)
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '{' || r == '}':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString("\\par\n")
+		case r == '\t':
+			b.WriteString(`\tab `)
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xFFFF:
+			fmt.Fprintf(&b, `\u%d?`, int16(r))
+		default:
+			// RTF escapes are 16-bit, so characters beyond the BMP are
+			// written as a UTF-16 surrogate pair
+			r -= 0x10000
+			fmt.Fprintf(&b, `\u%d?\u%d?`, int16(0xD800+(r>>10)), int16(0xDC00+(r&0x3FF)))
+		}
+	}
+	b.WriteString("}")
+	return b.Bytes()
+}
+
+// licenseResource is a resource in the plist passed to hdiutil udifrez.
+type licenseResource struct {
+	kind string // four-character resource type
+	data []byte
+}
+
+// LicenseResources returns the resource plist that hdiutil udifrez embeds
+// in a DMG so the RTF document rtf is shown as a click-through license,
+// in English, when the image is opened.
+func LicenseResources(rtf []byte) []byte {
+	// Language table: default language index, language count, then the
+	// region code, resource ID offset, and two-byte flag of each language
+	var lpic bytes.Buffer
+	for _, v := range []uint16{0, 1, 0, 0, 0} {
+		_ = binary.Write(&lpic, binary.BigEndian, v)
+	}
+
+	// String list: a count followed by Pascal strings
+	var strs bytes.Buffer
+	_ = binary.Write(&strs, binary.BigEndian, uint16(len(licenseButtons)))
+	for _, s := range licenseButtons {
+		strs.WriteByte(byte(len(s)))
+		strs.WriteString(s)
+	}
+
+	resources := []licenseResource{
+		{kind: "LPic", data: lpic.Bytes()},
+		{kind: "RTF ", data: rtf},
+		{kind: "STR#", data: strs.Bytes()},
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	for _, res := range resources {
+		fmt.Fprintf(&b, "\t<key>%s</key>\n\t<array>\n\t\t<dict>\n", res.kind)
+		b.WriteString("\t\t\t<key>Attributes</key>\n\t\t\t<string>0x0000</string>\n")
+		fmt.Fprintf(&b, "\t\t\t<key>Data</key>\n\t\t\t<data>%s</data>\n", base64.StdEncoding.EncodeToString(res.data))
+		fmt.Fprintf(&b, "\t\t\t<key>ID</key>\n\t\t\t<string>%d</string>\n", licenseResourceID)
+		b.WriteString("\t\t\t<key>Name</key>\n\t\t\t<string>English</string>\n")
+		b.WriteString("\t\t</dict>\n\t</array>\n")
+	}
+	b.WriteString("</dict>\n</plist>\n")
+	return b.Bytes()
+}
+
+// AddDMGLicense embeds the RTF or plain-text license at licensePath in the
+// DMG at dmgPath with hdiutil udifrez, so Finder asks users to agree to it
+// before the image mounts.
+func AddDMGLicense(ctx context.Context, dmgPath, licensePath string) error {
+	license, err := os.ReadFile(licensePath)
+	if err != nil {
+		return fmt.Errorf("failed to read DMG license: %w", err)
+	}
+	rtf := license
+	if !IsRTF(licensePath) {
+		rtf = TextToRTF(string(license))
+	}
+
+	plistPath := strings.TrimSuffix(dmgPath, filepath.Ext(dmgPath)) + "-license.plist"
+	if err := os.WriteFile(plistPath, LicenseResources(rtf), 0644); err != nil {
+		return fmt.Errorf("failed to write DMG license resources: %w", err)
+	}
+	defer func() { _ = os.Remove(plistPath) }()
+
+	out, err := command.Run(ctx, "hdiutil", "udifrez", "-xml", plistPath, "", "-quiet", dmgPath)
+	if command.IsNotFound(err) {
+		return fmt.Errorf("hdiutil not found — this tool is required for DMG packaging on macOS")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add license to DMG: %s: %w", out, err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+func TestTextToRTF(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain", text: "MIT License", want: "MIT License"},
+		{name: "lines", text: "Line one\r\nLine two\n", want: "Line one\\par\nLine two\\par\n"},
+		{name: "control characters escaped", text: `a\b {c}`, want: `a\\b \{c\}`},
+		{name: "non-ascii", text: "© Café", want: `\u169? Caf\u233?`},
+		{name: "beyond the bmp", text: "😀", want: `\u-10179?\u-8704?`},
+	}
+
+	const header = `{\rtf1\ansi\ansicpg1252\deff0{\fonttbl{\f0\fswiss Helvetica;}}\f0\fs24 `
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(TextToRTF(tt.text))
+			if want := header + tt.want + "}"; got != want {
+				t.Errorf("TextToRTF(%q) = %q, want %q", tt.text, got, want)
+			}
+		})
+	}
+}
+
+// licenseResourceData returns the decoded Data of the kind resource in a
+// LicenseResources plist.
+func licenseResourceData(t *testing.T, plist []byte, kind string) []byte {
+	t.Helper()
+	re := regexp.MustCompile(`<key>` + regexp.QuoteMeta(kind) + `</key>\s*<array>\s*<dict>[\s\S]*?<data>([^<]*)</data>`)
+	m := re.FindSubmatch(plist)
+	if m == nil {
+		t.Fatalf("no %q resource in plist:\n%s", kind, plist)
+	}
+	data, err := base64.StdEncoding.DecodeString(string(m[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestLicenseResources(t *testing.T) {
+	rtf := []byte(`{\rtf1 Terms}`)
+	plist := LicenseResources(rtf)
+
+	if got := licenseResourceData(t, plist, "RTF "); !bytes.Equal(got, rtf) {
+		t.Errorf("RTF resource = %q, want %q", got, rtf)
+	}
+
+	// One language, English (region 0), using the resources with ID 5000
+	wantLPic := []byte{0, 0, 0, 1, 0, 0, 0, 0, 0, 0}
+	if got := licenseResourceData(t, plist, "LPic"); !bytes.Equal(got, wantLPic) {
+		t.Errorf("LPic resource = %v, want %v", got, wantLPic)
+	}
+
+	strs := licenseResourceData(t, plist, "STR#")
+	if count := int(strs[0])<<8 | int(strs[1]); count != len(licenseButtons) {
+		t.Fatalf("STR# count = %d, want %d", count, len(licenseButtons))
+	}
+	var got []string
+	for rest := strs[2:]; len(rest) > 0; {
+		n := int(rest[0])
+		got = append(got, string(rest[1:1+n]))
+		rest = rest[1+n:]
+	}
+	if strings.Join(got, "|") != strings.Join(licenseButtons, "|") {
+		t.Errorf("STR# strings = %q, want %q", got, licenseButtons)
+	}
+
+	if n := strings.Count(string(plist), "<string>5000</string>"); n != 3 {
+		t.Errorf("plist has %d resources with ID 5000, want 3", n)
+	}
+}
+
+func TestAddDMGLicense(t *testing.T) {
+	dir := t.TempDir()
+	dmgPath := filepath.Join(dir, "MyApp-1.0.0.dmg")
+	licensePath := filepath.Join(dir, "LICENSE.txt")
+	if err := os.WriteFile(licensePath, []byte("Terms"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var plist []byte
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		data, err := os.ReadFile(args[2])
+		if err != nil {
+			t.Errorf("resource plist not readable during udifrez: %v", err)
+		}
+		plist = data
+		return "", nil
+	}}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	if err := AddDMGLicense(ctx, dmgPath, licensePath); err != nil {
+		t.Fatalf("AddDMGLicense() unexpected error: %v", err)
+	}
+
+	plistPath := filepath.Join(dir, "MyApp-1.0.0-license.plist")
+	want := "hdiutil udifrez -xml " + plistPath + "  -quiet " + dmgPath
+	if got := fake.Commands(); len(got) != 1 || got[0] != want {
+		t.Errorf("ran %q, want [%q]", got, want)
+	}
+	if _, err := os.Stat(plistPath); !os.IsNotExist(err) {
+		t.Errorf("resource plist should be removed, stat error = %v", err)
+	}
+	if got := licenseResourceData(t, plist, "RTF "); !bytes.Equal(got, TextToRTF("Terms")) {
+		t.Errorf("plain-text license embedded as %q, want it converted to RTF", got)
+	}
+}
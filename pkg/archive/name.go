@@ -0,0 +1,44 @@
+package archive
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultNameTemplate is the package file name, without extension, used
+// when an archive.formats entry has no name_template.
+const DefaultNameTemplate = "{{.Name}}-{{.Version}}"
+
+// nameData holds the fields available to a name_template
+type nameData struct {
+	Name        string
+	Version     string
+	ShortCommit string
+}
+
+// RenderName renders a package name template for the given app name,
+// version, and short commit. An empty template renders DefaultNameTemplate.
+// The result must be a plain file name.
+func RenderName(nameTemplate, name, version, shortCommit string) (string, error) {
+	if nameTemplate == "" {
+		nameTemplate = DefaultNameTemplate
+	}
+
+	tmpl, err := template.New("name").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid name_template %q: %w", nameTemplate, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nameData{Name: name, Version: version, ShortCommit: shortCommit}); err != nil {
+		return "", fmt.Errorf("invalid name_template %q: %w", nameTemplate, err)
+	}
+
+	rendered := buf.String()
+	if rendered == "" || rendered == "." || rendered == ".." || strings.ContainsAny(rendered, `/\`) {
+		return "", fmt.Errorf("name_template %q renders %q, which is not a valid file name", nameTemplate, rendered)
+	}
+	return rendered, nil
+}
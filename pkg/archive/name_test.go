@@ -0,0 +1,32 @@
+package archive
+
+import "testing"
+
+func TestRenderName(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{name: "default", template: "", want: "MyApp-v1.2.0"},
+		{name: "custom", template: "{{.Name}}-{{.Version}}-universal", want: "MyApp-v1.2.0-universal"},
+		{name: "short commit", template: "{{.Name}}-{{.ShortCommit}}", want: "MyApp-abc1234"},
+		{name: "unknown field", template: "{{.Arch}}", wantErr: true},
+		{name: "malformed", template: "{{.Name", wantErr: true},
+		{name: "path separator", template: "dist/{{.Name}}", wantErr: true},
+		{name: "empty result", template: "{{if false}}x{{end}}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderName(tt.template, "MyApp", "v1.2.0", "abc1234")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
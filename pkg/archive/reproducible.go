@@ -0,0 +1,165 @@
+package archive
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// zipEpoch is the earliest timestamp a ZIP entry can record.
+var zipEpoch = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SourceDateEpoch returns the timestamp recorded on every entry of a
+// reproducible ZIP: $SOURCE_DATE_EPOCH when set, otherwise 1980-01-01, the
+// earliest time a ZIP can hold.
+func SourceDateEpoch() (time.Time, error) {
+	value := os.Getenv("SOURCE_DATE_EPOCH")
+	if value == "" {
+		return zipEpoch, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: must be a Unix timestamp in seconds", value)
+	}
+	t := time.Unix(seconds, 0).UTC()
+	if t.Before(zipEpoch) {
+		return zipEpoch, nil
+	}
+	return t, nil
+}
+
+// CreateReproducibleZip writes the contents of dir into a ZIP at outputPath
+// that is byte-identical across rebuilds of the same tree. Entries are added
+// in lexical order, every entry records modTime, and permissions are
+// normalized to 0755 for directories and executables and 0644 otherwise.
+// Symlinks, such as those inside framework bundles, are stored as links.
+// Extended attributes are stored as ditto stores them, in AppleDouble
+// "__MACOSX/._<name>" entries, which follow the tree in lexical order.
+func CreateReproducibleZip(dir, outputPath string, modTime time.Time) (err error) {
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create ZIP archive: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("failed to close ZIP archive: %w", cerr)
+		}
+	}()
+
+	zw := zip.NewWriter(f)
+	doubles := make(map[string][]byte)
+	// WalkDir visits entries in lexical order, independent of the filesystem
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		double, err := readAppleDouble(path)
+		if err != nil {
+			return err
+		}
+		if double != nil {
+			doubles[appleDoubleName(name)] = double
+		}
+		return addReproducibleEntry(zw, path, name, info, modTime)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create ZIP archive: %w", err)
+	}
+	if err := addAppleDoubles(zw, doubles, modTime); err != nil {
+		return fmt.Errorf("failed to create ZIP archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize ZIP archive: %w", err)
+	}
+	return nil
+}
+
+// addAppleDoubles writes the AppleDouble entries in doubles, keyed by entry
+// name, in lexical order.
+func addAppleDoubles(zw *zip.Writer, doubles map[string][]byte, modTime time.Time) error {
+	names := make([]string, 0, len(doubles))
+	for name := range doubles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		header := &zip.FileHeader{Name: name, Modified: modTime, Method: zip.Deflate}
+		header.SetMode(0644)
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(doubles[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addReproducibleEntry writes a single file, directory, or symlink to zw
+// with a normalized header.
+func addReproducibleEntry(zw *zip.Writer, path, name string, info fs.FileInfo, modTime time.Time) error {
+	header := &zip.FileHeader{Name: name, Modified: modTime}
+
+	mode := info.Mode()
+	switch {
+	case mode.IsDir():
+		header.Name += "/"
+		header.SetMode(fs.ModeDir | 0755)
+		_, err := zw.CreateHeader(header)
+		return err
+
+	case mode&fs.ModeSymlink != 0:
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		header.SetMode(fs.ModeSymlink | 0755)
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, target)
+		return err
+
+	case mode.IsRegular():
+		perm := fs.FileMode(0644)
+		if mode.Perm()&0111 != 0 {
+			perm = 0755
+		}
+		header.SetMode(perm)
+		header.Method = zip.Deflate
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = src.Close() }()
+		_, err = io.Copy(w, src)
+		return err
+	}
+
+	return fmt.Errorf("unsupported file type at %s", path)
+}
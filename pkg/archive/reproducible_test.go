@@ -0,0 +1,188 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func hashFile(t *testing.T, path string) [32]byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sha256.Sum256(data)
+}
+
+func TestCreateReproducibleZipIsByteIdentical(t *testing.T) {
+	dir := t.TempDir()
+	tree := filepath.Join(dir, "staging")
+	appPath := filepath.Join(tree, "MyApp.app")
+	writeFile(t, filepath.Join(appPath, "Contents", "Info.plist"), "<plist/>")
+	writeFile(t, filepath.Join(appPath, "Contents", "MacOS", "MyApp"), "binary")
+	if err := os.Chmod(filepath.Join(appPath, "Contents", "MacOS", "MyApp"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("Contents/MacOS/MyApp", filepath.Join(appPath, "Current")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(tree, "README.md"), "readme")
+
+	epoch, err := SourceDateEpoch()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := filepath.Join(dir, "first.zip")
+	if err := CreateReproducibleZip(tree, first, epoch); err != nil {
+		t.Fatalf("CreateReproducibleZip() unexpected error: %v", err)
+	}
+
+	// Touch the tree so only modification times differ between the builds
+	later := time.Now().Add(time.Hour)
+	for _, rel := range []string{"README.md", "MyApp.app/Contents/Info.plist"} {
+		if err := os.Chtimes(filepath.Join(tree, rel), later, later); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	second := filepath.Join(dir, "second.zip")
+	if err := CreateReproducibleZip(tree, second, epoch); err != nil {
+		t.Fatalf("CreateReproducibleZip() unexpected error: %v", err)
+	}
+
+	if hashFile(t, first) != hashFile(t, second) {
+		t.Error("rebuilding the same tree produced a different ZIP")
+	}
+
+	r, err := zip.OpenReader(first)
+	if err != nil {
+		t.Fatalf("failed to open ZIP: %v", err)
+	}
+	defer r.Close()
+
+	modes := make(map[string]os.FileMode)
+	for _, f := range r.File {
+		modes[f.Name] = f.Mode()
+		if !f.Modified.Equal(epoch) {
+			t.Errorf("%s Modified = %v, want %v", f.Name, f.Modified, epoch)
+		}
+	}
+	if got := modes["MyApp.app/Contents/MacOS/MyApp"].Perm(); got != 0755 {
+		t.Errorf("executable mode = %v, want 0755", got)
+	}
+	if got := modes["README.md"].Perm(); got != 0644 {
+		t.Errorf("README.md mode = %v, want 0644", got)
+	}
+	if modes["MyApp.app/Current"]&os.ModeSymlink == 0 {
+		t.Error("symlink was not stored as a link")
+	}
+}
+
+func TestCreateReproducibleZipKeepsExtendedAttributes(t *testing.T) {
+	dir := t.TempDir()
+	tree := filepath.Join(dir, "staging")
+	script := filepath.Join(tree, "MyApp.app", "Contents", "Resources", "run.sh")
+	writeFile(t, script, "#!/bin/sh")
+	writeFile(t, filepath.Join(tree, "MyApp.app", "Contents", "Info.plist"), "<plist/>")
+
+	// Linux only allows attributes in the user namespace
+	attrs := map[string]string{"user.z.signature": "sealed", "user.a.note": "first"}
+	for name, value := range attrs {
+		if err := unix.Setxattr(script, name, []byte(value), 0); err != nil {
+			t.Skipf("extended attributes not supported here: %v", err)
+		}
+	}
+
+	output := filepath.Join(dir, "out.zip")
+	if err := CreateReproducibleZip(tree, output, zipEpoch); err != nil {
+		t.Fatalf("CreateReproducibleZip() unexpected error: %v", err)
+	}
+
+	r, err := zip.OpenReader(output)
+	if err != nil {
+		t.Fatalf("failed to open ZIP: %v", err)
+	}
+	defer r.Close()
+
+	var names []string
+	var double []byte
+	for _, f := range r.File {
+		names = append(names, f.Name)
+		if f.Name != "__MACOSX/MyApp.app/Contents/Resources/._run.sh" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		double, err = io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if double == nil {
+		t.Fatalf("no AppleDouble entry for run.sh in %v", names)
+	}
+	if last := names[len(names)-1]; last != "__MACOSX/MyApp.app/Contents/Resources/._run.sh" {
+		t.Errorf("last entry = %s, want the AppleDouble entries after the tree", last)
+	}
+	if len(names) != 6 {
+		t.Errorf("entries = %v, want an AppleDouble entry only for the file with attributes", names)
+	}
+
+	be := binary.BigEndian
+	if be.Uint32(double) != appleDoubleMagic {
+		t.Fatalf("AppleDouble magic = %#x, want %#x", be.Uint32(double), appleDoubleMagic)
+	}
+	header := double[attrHeaderOffset:]
+	if be.Uint32(header) != attrHeaderMagic {
+		t.Fatalf("attribute header magic = %#x, want %#x", be.Uint32(header), attrHeaderMagic)
+	}
+	if count := be.Uint16(header[34:]); count != 2 {
+		t.Fatalf("attribute count = %d, want 2", count)
+	}
+
+	// Attributes are stored in sorted order with their values
+	entry := double[attrEntriesStart:]
+	for _, name := range []string{"user.a.note", "user.z.signature"} {
+		offset, length := be.Uint32(entry), be.Uint32(entry[4:])
+		nameLen := int(entry[10])
+		if got := string(bytes.TrimRight(entry[11:11+nameLen], "\x00")); got != name {
+			t.Errorf("attribute name = %q, want %q", got, name)
+		}
+		if got := string(double[offset : offset+length]); got != attrs[name] {
+			t.Errorf("%s = %q, want %q", name, got, attrs[name])
+		}
+		entry = entry[attrEntrySize(name):]
+	}
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	got, err := SourceDateEpoch()
+	if err != nil || !got.Equal(zipEpoch) {
+		t.Errorf("SourceDateEpoch() unset = %v, %v; want %v", got, err, zipEpoch)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	got, err = SourceDateEpoch()
+	if err != nil || !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("SourceDateEpoch() = %v, %v; want 1700000000", got, err)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "yesterday")
+	if _, err := SourceDateEpoch(); err == nil {
+		t.Error("SourceDateEpoch() expected error for non-numeric value")
+	}
+}
@@ -0,0 +1,72 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// sbomFormat is a software bill of materials format syft can write.
+type sbomFormat struct {
+	output string // syft -o format name
+	suffix string // filename suffix of the SBOM package
+}
+
+// sbomFormats maps archive.sbom_format values to syft's output formats.
+var sbomFormats = map[string]sbomFormat{
+	"cyclonedx": {output: "cyclonedx-json", suffix: ".cdx.json"},
+	"spdx":      {output: "spdx-json", suffix: ".spdx.json"},
+}
+
+// DefaultSBOMFormat is used when archive.sbom_format is not set.
+const DefaultSBOMFormat = "cyclonedx"
+
+// CheckSBOMFormat validates an archive.sbom_format value. Empty is valid
+// and selects DefaultSBOMFormat.
+func CheckSBOMFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	if _, ok := sbomFormats[format]; !ok {
+		names := make([]string, 0, len(sbomFormats))
+		for name := range sbomFormats {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("invalid archive.sbom_format %q: must be one of %s", format, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// SBOMSuffix returns the filename suffix of an SBOM in the given format.
+func SBOMSuffix(format string) string {
+	if format == "" {
+		format = DefaultSBOMFormat
+	}
+	return sbomFormats[format].suffix
+}
+
+// CreateSBOM writes a software bill of materials for the .app at appPath to
+// outputPath using syft. If syft is not installed the returned error
+// satisfies command.IsNotFound, so callers can treat the SBOM as optional.
+func CreateSBOM(ctx context.Context, appPath, outputPath, format string) error {
+	if format == "" {
+		format = DefaultSBOMFormat
+	}
+	f, ok := sbomFormats[format]
+	if !ok {
+		return CheckSBOMFormat(format)
+	}
+
+	out, err := command.Run(ctx, "syft", "scan", "dir:"+appPath, "--quiet", "-o", f.output+"="+outputPath)
+	if command.IsNotFound(err) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate SBOM: %s: %w", strings.TrimSpace(out), err)
+	}
+	return nil
+}
@@ -1,22 +1,39 @@
 package archive
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 // CreateZip creates a ZIP archive of the given .app using ditto.
-// ditto preserves macOS resource forks and extended attributes.
-// Returns the output path of the created .zip file.
-func CreateZip(appPath, outputPath string) error {
-	if _, err := exec.LookPath("ditto"); err != nil {
+// ditto keeps resource forks and extended attributes, stored under
+// __MACOSX, since code that is not Mach-O, such as a script, carries its
+// signature in an extended attribute.
+func CreateZip(ctx context.Context, appPath, outputPath string) error {
+	out, err := command.Run(ctx, "ditto", "-c", "-k", "--sequesterRsrc", "--keepParent", appPath, outputPath)
+	if command.IsNotFound(err) {
 		return fmt.Errorf("ditto not found — this tool is required for ZIP packaging on macOS")
 	}
+	if err != nil {
+		return fmt.Errorf("failed to create ZIP archive: %s: %w", out, err)
+	}
+
+	return nil
+}
 
-	cmd := exec.Command("ditto", "-c", "-k", "--sequesterRsrc", "--keepParent", appPath, outputPath)
-	out, err := cmd.CombinedOutput()
+// CreateZipFromDir creates a ZIP archive of the contents of dir using ditto.
+// Unlike CreateZip, the directory itself is not included, so a staging
+// directory holding the .app and extra files produces an archive with those
+// entries at its root. Extended attributes are kept as in CreateZip.
+func CreateZipFromDir(ctx context.Context, dir, outputPath string) error {
+	out, err := command.Run(ctx, "ditto", "-c", "-k", "--sequesterRsrc", dir, outputPath)
+	if command.IsNotFound(err) {
+		return fmt.Errorf("ditto not found — this tool is required for ZIP packaging on macOS")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create ZIP archive: %s: %w", string(out), err)
+		return fmt.Errorf("failed to create ZIP archive: %s: %w", out, err)
 	}
 
 	return nil
@@ -0,0 +1,47 @@
+package build
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// ExportArgs holds the arguments needed to invoke xcodebuild -exportArchive.
+type ExportArgs struct {
+	ArchivePath        string // -archivePath of the .xcarchive to export
+	ExportPath         string // -exportPath directory the exported app is written to
+	ExportOptionsPlist string // -exportOptionsPlist
+
+	XcodebuildPath string // xcodebuild binary to run (default: xcodebuild on PATH)
+}
+
+// BuildExportArgs constructs the argument list for xcodebuild -exportArchive.
+func BuildExportArgs(args ExportArgs) []string {
+	return []string{
+		"-exportArchive",
+		"-archivePath", args.ArchivePath,
+		"-exportPath", args.ExportPath,
+		"-exportOptionsPlist", args.ExportOptionsPlist,
+	}
+}
+
+// RunExportArchive exports the archive with the given export options, which
+// applies distribution processing such as symbol stripping and re-signing
+// that copying the .app straight out of the archive skips.
+// Returns combined stdout/stderr output and any error.
+func RunExportArchive(ctx context.Context, args ExportArgs) (string, error) {
+	xcodebuild := args.XcodebuildPath
+	if xcodebuild == "" {
+		xcodebuild = "xcodebuild"
+	}
+
+	output, err := command.Run(ctx, xcodebuild, BuildExportArgs(args)...)
+	if command.IsNotFound(err) {
+		return "", xcodebuildNotFound(args.XcodebuildPath)
+	}
+	if err != nil {
+		return output, fmt.Errorf("xcodebuild -exportArchive failed: %w", err)
+	}
+	return output, nil
+}
@@ -0,0 +1,45 @@
+package build
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+func TestBuildExportArgs(t *testing.T) {
+	got := BuildExportArgs(ExportArgs{
+		ArchivePath:        "dist/MyApp.xcarchive",
+		ExportPath:         "dist/MyApp-export",
+		ExportOptionsPlist: "ExportOptions.plist",
+	})
+	want := []string{
+		"-exportArchive",
+		"-archivePath", "dist/MyApp.xcarchive",
+		"-exportPath", "dist/MyApp-export",
+		"-exportOptionsPlist", "ExportOptions.plist",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildExportArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestRunExportArchive(t *testing.T) {
+	fake := &command.Fake{}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	args := ExportArgs{
+		ArchivePath:        "dist/MyApp.xcarchive",
+		ExportPath:         "dist/MyApp-export",
+		ExportOptionsPlist: "ExportOptions.plist",
+		XcodebuildPath:     "/Applications/Xcode-15.4.app/Contents/Developer/usr/bin/xcodebuild",
+	}
+	if _, err := RunExportArchive(ctx, args); err != nil {
+		t.Fatalf("RunExportArchive() unexpected error: %v", err)
+	}
+	calls := fake.Calls()
+	if len(calls) != 1 || calls[0].Name != args.XcodebuildPath || !reflect.DeepEqual(calls[0].Args, BuildExportArgs(args)) {
+		t.Errorf("RunExportArchive() ran %v, want %s %v", fake.Commands(), args.XcodebuildPath, BuildExportArgs(args))
+	}
+}
@@ -0,0 +1,71 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// ValidArchitectures are the values accepted in build.architectures.
+var ValidArchitectures = []string{"arm64", "x86_64"}
+
+// ParseLipoArchs parses the output of `lipo -archs`, a space-separated list
+// such as "x86_64 arm64".
+func ParseLipoArchs(output string) []string {
+	return strings.Fields(output)
+}
+
+// MissingArchs returns the entries of want that are not in got.
+func MissingArchs(want, got []string) []string {
+	var missing []string
+	for _, arch := range want {
+		if !slices.Contains(got, arch) {
+			missing = append(missing, arch)
+		}
+	}
+	return missing
+}
+
+// MainExecutable returns the path of the .app's main executable in
+// Contents/MacOS. It is normally named after the bundle; otherwise the
+// directory must hold a single file.
+func MainExecutable(appPath string) (string, error) {
+	macOSDir := filepath.Join(appPath, "Contents", "MacOS")
+
+	named := filepath.Join(macOSDir, strings.TrimSuffix(filepath.Base(appPath), ".app"))
+	if info, err := os.Stat(named); err == nil && info.Mode().IsRegular() {
+		return named, nil
+	}
+
+	entries, err := os.ReadDir(macOSDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", macOSDir, err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			files = append(files, entry.Name())
+		}
+	}
+	if len(files) != 1 {
+		return "", fmt.Errorf("cannot determine the main executable in %s: found %d files", macOSDir, len(files))
+	}
+	return filepath.Join(macOSDir, files[0]), nil
+}
+
+// RunLipoArchs returns the architectures in the Mach-O binary at path.
+func RunLipoArchs(ctx context.Context, path string) ([]string, error) {
+	out, err := command.Run(ctx, "lipo", "-archs", path)
+	if command.IsNotFound(err) {
+		return nil, fmt.Errorf("lipo not found — install Xcode Command Line Tools with: xcode-select --install")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lipo -archs %s failed: %s: %w", filepath.Base(path), strings.TrimSpace(out), err)
+	}
+	return ParseLipoArchs(out), nil
+}
@@ -0,0 +1,80 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseLipoArchs(t *testing.T) {
+	tests := []struct {
+		output string
+		want   []string
+	}{
+		{output: "x86_64 arm64\n", want: []string{"x86_64", "arm64"}},
+		{output: "arm64\n", want: []string{"arm64"}},
+		{output: "  x86_64   arm64e  \n", want: []string{"x86_64", "arm64e"}},
+		{output: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLipoArchs(tt.output); !slices.Equal(got, tt.want) {
+			t.Errorf("ParseLipoArchs(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestMissingArchs(t *testing.T) {
+	universal := ParseLipoArchs("x86_64 arm64\n")
+	if got := MissingArchs([]string{"arm64", "x86_64"}, universal); len(got) != 0 {
+		t.Errorf("MissingArchs() on universal binary = %v, want none", got)
+	}
+
+	armOnly := ParseLipoArchs("arm64\n")
+	if got := MissingArchs([]string{"arm64", "x86_64"}, armOnly); !slices.Equal(got, []string{"x86_64"}) {
+		t.Errorf("MissingArchs() on arm64 binary = %v, want [x86_64]", got)
+	}
+}
+
+func TestMainExecutable(t *testing.T) {
+	dir := t.TempDir()
+
+	named := filepath.Join(dir, "MyApp.app")
+	writeExecutable(t, filepath.Join(named, "Contents", "MacOS", "MyApp"), "bin")
+	writeExecutable(t, filepath.Join(named, "Contents", "MacOS", "helper"), "bin")
+	if got, err := MainExecutable(named); err != nil || got != filepath.Join(named, "Contents", "MacOS", "MyApp") {
+		t.Errorf("MainExecutable() = %q, %v; want bundle-named executable", got, err)
+	}
+
+	renamed := filepath.Join(dir, "My App.app")
+	writeExecutable(t, filepath.Join(renamed, "Contents", "MacOS", "MyAppBinary"), "bin")
+	if got, err := MainExecutable(renamed); err != nil || filepath.Base(got) != "MyAppBinary" {
+		t.Errorf("MainExecutable() = %q, %v; want the single executable", got, err)
+	}
+
+	ambiguous := filepath.Join(dir, "Other.app")
+	writeExecutable(t, filepath.Join(ambiguous, "Contents", "MacOS", "a"), "bin")
+	writeExecutable(t, filepath.Join(ambiguous, "Contents", "MacOS", "b"), "bin")
+	if _, err := MainExecutable(ambiguous); err == nil || !strings.Contains(err.Error(), "found 2 files") {
+		t.Errorf("MainExecutable() error = %v, want ambiguity error", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "Empty.app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MainExecutable(filepath.Join(dir, "Empty.app")); err == nil {
+		t.Error("MainExecutable() expected error for missing Contents/MacOS")
+	}
+}
+
+func writeExecutable(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
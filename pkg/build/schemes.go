@@ -0,0 +1,88 @@
+package build
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// schemeList is the part of `xcodebuild -list -json` output that names the
+// schemes. A project reports them under "project", a workspace under
+// "workspace".
+type schemeList struct {
+	Project *struct {
+		Schemes []string `json:"schemes"`
+	} `json:"project"`
+	Workspace *struct {
+		Schemes []string `json:"schemes"`
+	} `json:"workspace"`
+}
+
+// ParseSchemeList returns the schemes in the output of
+// `xcodebuild -list -json`. Anything xcodebuild logs before the JSON
+// document, such as warnings, is ignored.
+func ParseSchemeList(output []byte) ([]string, error) {
+	start := bytes.IndexByte(output, '{')
+	if start < 0 {
+		return nil, fmt.Errorf("xcodebuild -list printed no JSON: %s", strings.TrimSpace(string(output)))
+	}
+
+	var list schemeList
+	if err := json.NewDecoder(bytes.NewReader(output[start:])).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to parse xcodebuild -list output: %w", err)
+	}
+	switch {
+	case list.Workspace != nil:
+		return list.Workspace.Schemes, nil
+	case list.Project != nil:
+		return list.Project.Schemes, nil
+	}
+	return nil, fmt.Errorf("xcodebuild -list output has neither a project nor a workspace")
+}
+
+// ListSchemes runs `xcodebuild -list -json` against the workspace or
+// project and returns its schemes.
+func ListSchemes(ctx context.Context, xcodebuildPath, workspace string, wsType WorkspaceType) ([]string, error) {
+	xcodebuild := xcodebuildPath
+	if xcodebuild == "" {
+		xcodebuild = "xcodebuild"
+	}
+
+	var args []string
+	if workspace != "" {
+		switch wsType {
+		case Workspace:
+			args = append(args, "-workspace", workspace)
+		case Project:
+			args = append(args, "-project", workspace)
+		}
+	}
+	args = append(args, "-list", "-json")
+
+	output, err := command.Run(ctx, xcodebuild, args...)
+	if command.IsNotFound(err) {
+		return nil, xcodebuildNotFound(xcodebuildPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("xcodebuild -list failed: %s: %w", strings.TrimSpace(output), err)
+	}
+	return ParseSchemeList([]byte(output))
+}
+
+// CheckScheme returns an error listing the available schemes if scheme is
+// not among them.
+func CheckScheme(scheme string, schemes []string) error {
+	for _, s := range schemes {
+		if s == scheme {
+			return nil
+		}
+	}
+	if len(schemes) == 0 {
+		return fmt.Errorf("scheme %q not found — the project has no schemes; share one in Xcode (Product > Scheme > Manage Schemes)", scheme)
+	}
+	return fmt.Errorf("scheme %q not found — check project.scheme in your config (available: %s)", scheme, strings.Join(schemes, ", "))
+}
@@ -0,0 +1,92 @@
+package build
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+func TestParseSchemeList(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+		errMsg string
+	}{
+		{
+			name:   "project",
+			output: `{"project": {"configurations": ["Debug", "Release"], "name": "MyApp", "schemes": ["MyApp", "MyAppTests"], "targets": ["MyApp"]}}`,
+			want:   []string{"MyApp", "MyAppTests"},
+		},
+		{
+			name:   "workspace",
+			output: `{"workspace": {"name": "MyApp", "schemes": ["MyApp", "Pods-MyApp"]}}`,
+			want:   []string{"MyApp", "Pods-MyApp"},
+		},
+		{
+			name:   "warnings before the JSON",
+			output: "2026-01-01 10:00:00.000 xcodebuild[123:456] warning: something\n{\"project\": {\"schemes\": [\"MyApp\"]}}\n",
+			want:   []string{"MyApp"},
+		},
+		{
+			name:   "no JSON",
+			output: "xcodebuild: error: 'MyApp.xcodeproj' does not exist.",
+			errMsg: "printed no JSON",
+		},
+		{
+			name:   "unexpected document",
+			output: `{"something": {}}`,
+			errMsg: "neither a project nor a workspace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSchemeList([]byte(tt.output))
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("ParseSchemeList() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSchemeList() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSchemeList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckScheme(t *testing.T) {
+	if err := CheckScheme("MyApp", []string{"MyApp", "MyAppTests"}); err != nil {
+		t.Errorf("CheckScheme() unexpected error: %v", err)
+	}
+
+	err := CheckScheme("MyAp", []string{"MyApp", "MyAppTests"})
+	if err == nil || !strings.Contains(err.Error(), `scheme "MyAp" not found`) || !strings.Contains(err.Error(), "available: MyApp, MyAppTests") {
+		t.Errorf("CheckScheme() error = %v, want it to list the available schemes", err)
+	}
+}
+
+func TestListSchemes(t *testing.T) {
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		return `{"workspace": {"name": "MyApp", "schemes": ["MyApp"]}}`, nil
+	}}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	schemes, err := ListSchemes(ctx, "", "MyApp.xcworkspace", Workspace)
+	if err != nil {
+		t.Fatalf("ListSchemes() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(schemes, []string{"MyApp"}) {
+		t.Errorf("ListSchemes() = %v, want [MyApp]", schemes)
+	}
+	if got := fake.Commands(); len(got) != 1 || got[0] != "xcodebuild -workspace MyApp.xcworkspace -list -json" {
+		t.Errorf("commands = %v, want a single xcodebuild -list", got)
+	}
+}
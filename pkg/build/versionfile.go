@@ -0,0 +1,100 @@
+package build
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Default keys written by SetVersionInFile when no key is configured.
+const (
+	DefaultPlistVersionKey    = "CFBundleShortVersionString"
+	DefaultXcconfigVersionKey = "MARKETING_VERSION"
+)
+
+// DefaultVersionKey returns the key updated in path when build.version_key
+// is empty, based on the file extension.
+func DefaultVersionKey(path string) (string, error) {
+	switch filepath.Ext(path) {
+	case ".plist":
+		return DefaultPlistVersionKey, nil
+	case ".xcconfig":
+		return DefaultXcconfigVersionKey, nil
+	default:
+		return "", fmt.Errorf("unsupported build.version_file %q: must be an .plist or .xcconfig file", path)
+	}
+}
+
+// SetVersionInFile writes version to key in the XML property list or
+// .xcconfig at path. Returns true if the file content changed. The key must
+// already exist; it is never added.
+func SetVersionInFile(path, key, version string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read version file: %w", err)
+	}
+
+	var updated []byte
+	switch filepath.Ext(path) {
+	case ".plist":
+		updated, err = setPlistString(data, key, version)
+	case ".xcconfig":
+		updated, err = setXcconfigValue(data, key, version)
+	default:
+		_, err = DefaultVersionKey(path)
+	}
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if bytes.Equal(data, updated) {
+		return false, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat version file: %w", err)
+	}
+	if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write version file: %w", err)
+	}
+	return true, nil
+}
+
+// setPlistString replaces the <string> value following <key>key</key> in an
+// XML property list. Binary plists are rejected rather than rewritten.
+func setPlistString(data []byte, key, value string) ([]byte, error) {
+	if bytes.HasPrefix(data, []byte("bplist")) {
+		return nil, fmt.Errorf("binary property lists are not supported — convert with: plutil -convert xml1 <file>")
+	}
+
+	re := regexp.MustCompile(`(<key>` + regexp.QuoteMeta(key) + `</key>\s*<string>)[^<]*(</string>)`)
+	if !re.Match(data) {
+		return nil, fmt.Errorf("key %q with a string value not found", key)
+	}
+
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(value)); err != nil {
+		return nil, err
+	}
+	return re.ReplaceAll(data, []byte("${1}"+escapeReplacement(escaped.String())+"${2}")), nil
+}
+
+// setXcconfigValue replaces the value of every `key = value` assignment in
+// an .xcconfig file, preserving the spacing around "=".
+func setXcconfigValue(data []byte, key, value string) ([]byte, error) {
+	re := regexp.MustCompile(`(?m)^(\s*` + regexp.QuoteMeta(key) + `\s*=[ \t]*)[^\r\n]*`)
+	if !re.Match(data) {
+		return nil, fmt.Errorf("setting %q not found", key)
+	}
+	return re.ReplaceAll(data, []byte("${1}"+escapeReplacement(value))), nil
+}
+
+// escapeReplacement escapes "$" so a value is inserted literally by
+// regexp.ReplaceAll.
+func escapeReplacement(s string) string {
+	return string(bytes.ReplaceAll([]byte(s), []byte("$"), []byte("$$")))
+}
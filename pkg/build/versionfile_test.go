@@ -0,0 +1,134 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const samplePlist = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleName</key>
+	<string>MyApp</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.0.0</string>
+	<key>CFBundleVersion</key>
+	<string>42</string>
+</dict>
+</plist>
+`
+
+func writeSample(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSetVersionInFilePlist(t *testing.T) {
+	path := writeSample(t, "Info.plist", samplePlist)
+
+	changed, err := SetVersionInFile(path, DefaultPlistVersionKey, "1.2.3")
+	if err != nil {
+		t.Fatalf("SetVersionInFile() unexpected error: %v", err)
+	}
+	if !changed {
+		t.Error("SetVersionInFile() changed = false, want true")
+	}
+
+	got, _ := os.ReadFile(path)
+	want := strings.Replace(samplePlist, "<string>1.0.0</string>", "<string>1.2.3</string>", 1)
+	if string(got) != want {
+		t.Errorf("plist content mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Other keys are untouched and a second run is a no-op
+	changed, err = SetVersionInFile(path, DefaultPlistVersionKey, "1.2.3")
+	if err != nil {
+		t.Fatalf("SetVersionInFile() unexpected error on rerun: %v", err)
+	}
+	if changed {
+		t.Error("SetVersionInFile() changed = true on rerun, want false")
+	}
+}
+
+func TestSetVersionInFilePlistCustomKey(t *testing.T) {
+	path := writeSample(t, "Info.plist", samplePlist)
+
+	if _, err := SetVersionInFile(path, "CFBundleVersion", "100"); err != nil {
+		t.Fatalf("SetVersionInFile() unexpected error: %v", err)
+	}
+
+	got, _ := os.ReadFile(path)
+	if !strings.Contains(string(got), "<key>CFBundleVersion</key>\n\t<string>100</string>") {
+		t.Errorf("CFBundleVersion not updated\ngot:\n%s", got)
+	}
+	if !strings.Contains(string(got), "<string>1.0.0</string>") {
+		t.Errorf("CFBundleShortVersionString should be untouched\ngot:\n%s", got)
+	}
+}
+
+func TestSetVersionInFilePlistEscapesValue(t *testing.T) {
+	path := writeSample(t, "Info.plist", samplePlist)
+
+	if _, err := SetVersionInFile(path, DefaultPlistVersionKey, "1.0.0-<b>&$1"); err != nil {
+		t.Fatalf("SetVersionInFile() unexpected error: %v", err)
+	}
+
+	got, _ := os.ReadFile(path)
+	if !strings.Contains(string(got), "<string>1.0.0-&lt;b&gt;&amp;$1</string>") {
+		t.Errorf("value not escaped\ngot:\n%s", got)
+	}
+}
+
+func TestSetVersionInFileXcconfig(t *testing.T) {
+	content := "// Shared settings\nPRODUCT_NAME = MyApp\nMARKETING_VERSION = 1.0.0\nCURRENT_PROJECT_VERSION=42\n"
+	path := writeSample(t, "Version.xcconfig", content)
+
+	if _, err := SetVersionInFile(path, DefaultXcconfigVersionKey, "2.0.0"); err != nil {
+		t.Fatalf("SetVersionInFile() unexpected error: %v", err)
+	}
+	if _, err := SetVersionInFile(path, "CURRENT_PROJECT_VERSION", "43"); err != nil {
+		t.Fatalf("SetVersionInFile() unexpected error: %v", err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want := "// Shared settings\nPRODUCT_NAME = MyApp\nMARKETING_VERSION = 2.0.0\nCURRENT_PROJECT_VERSION=43\n"
+	if string(got) != want {
+		t.Errorf("xcconfig content mismatch\ngot:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestSetVersionInFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		key     string
+		errMsg  string
+	}{
+		{name: "missing plist key", file: "Info.plist", content: samplePlist, key: "NoSuchKey", errMsg: "not found"},
+		{name: "binary plist", file: "Info.plist", content: "bplist00\x00\x01", key: DefaultPlistVersionKey, errMsg: "binary property lists"},
+		{name: "missing xcconfig setting", file: "Version.xcconfig", content: "PRODUCT_NAME = MyApp\n", key: DefaultXcconfigVersionKey, errMsg: "not found"},
+		{name: "unsupported extension", file: "version.txt", content: "1.0.0", key: "VERSION", errMsg: "unsupported build.version_file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeSample(t, tt.file, tt.content)
+			_, err := SetVersionInFile(path, tt.key, "1.2.3")
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("SetVersionInFile() error = %v, want error containing %q", err, tt.errMsg)
+			}
+			got, _ := os.ReadFile(path)
+			if string(got) != tt.content {
+				t.Error("SetVersionInFile() modified the file on error")
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package build
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 // XcodebuildArgs holds the arguments needed to invoke xcodebuild archive.
@@ -15,6 +17,8 @@ type XcodebuildArgs struct {
 	ArchivePath   string // -archivePath
 	Version       string // MARKETING_VERSION build setting (CFBundleShortVersionString)
 	BuildNumber   string // CURRENT_PROJECT_VERSION build setting (CFBundleVersion)
+
+	XcodebuildPath string // xcodebuild binary to run (default: xcodebuild on PATH)
 }
 
 // BuildArchiveArgs constructs the argument list for xcodebuild archive.
@@ -60,17 +64,16 @@ func BuildArchiveArgs(args XcodebuildArgs) []string {
 
 // RunXcodebuild executes xcodebuild with the given arguments.
 // Returns combined stdout/stderr output and any error.
-func RunXcodebuild(args XcodebuildArgs) (string, error) {
-	// Check that xcodebuild is available
-	if _, err := exec.LookPath("xcodebuild"); err != nil {
-		return "", fmt.Errorf("xcodebuild not found — install Xcode Command Line Tools with: xcode-select --install")
+func RunXcodebuild(ctx context.Context, args XcodebuildArgs) (string, error) {
+	xcodebuild := args.XcodebuildPath
+	if xcodebuild == "" {
+		xcodebuild = "xcodebuild"
 	}
 
-	cmdArgs := BuildArchiveArgs(args)
-	cmd := exec.Command("xcodebuild", cmdArgs...)
-
-	out, err := cmd.CombinedOutput()
-	output := string(out)
+	output, err := command.Run(ctx, xcodebuild, BuildArchiveArgs(args)...)
+	if command.IsNotFound(err) {
+		return "", xcodebuildNotFound(args.XcodebuildPath)
+	}
 
 	if err != nil {
 		// Provide actionable error messages
@@ -88,3 +91,12 @@ func RunXcodebuild(args XcodebuildArgs) (string, error) {
 
 	return output, nil
 }
+
+// xcodebuildNotFound returns the error for a missing xcodebuild binary,
+// pointing at build.xcodebuild_path when that is what was run.
+func xcodebuildNotFound(xcodebuildPath string) error {
+	if xcodebuildPath != "" {
+		return fmt.Errorf("build.xcodebuild_path %s not found", xcodebuildPath)
+	}
+	return fmt.Errorf("xcodebuild not found — install Xcode Command Line Tools with: xcode-select --install")
+}
@@ -1,7 +1,10 @@
 package build
 
 import (
+	"context"
 	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 func TestBuildArchiveArgs(t *testing.T) {
@@ -115,3 +118,29 @@ func TestBuildArchiveArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestRunXcodebuildPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		xcodebuildPath string
+		want           string
+	}{
+		{name: "default", want: "xcodebuild"},
+		{name: "configured", xcodebuildPath: "/Applications/Xcode-15.4.app/Contents/Developer/usr/bin/xcodebuild", want: "/Applications/Xcode-15.4.app/Contents/Developer/usr/bin/xcodebuild"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &command.Fake{}
+			ctx := command.WithRunner(context.Background(), fake)
+
+			if _, err := RunXcodebuild(ctx, XcodebuildArgs{Scheme: "MyApp", XcodebuildPath: tt.xcodebuildPath}); err != nil {
+				t.Fatalf("RunXcodebuild() unexpected error: %v", err)
+			}
+			calls := fake.Calls()
+			if len(calls) != 1 || calls[0].Name != tt.want {
+				t.Errorf("RunXcodebuild() ran %v, want %s", fake.Commands(), tt.want)
+			}
+		})
+	}
+}
@@ -13,17 +13,93 @@ import (
 // The version string is used as a heading; commits are filtered, sorted,
 // and optionally grouped according to cfg.
 func Generate(version string, commits []string, cfg config.ChangelogConfig) (string, error) {
+	out, _, err := generate(version, commits, cfg)
+	return out, err
+}
+
+// generate is Generate, also returning the commits the changelog lists:
+// those kept by the filters and dependencies setting, up to max_entries in
+// each list.
+func generate(version string, commits []string, cfg config.ChangelogConfig) (string, []string, error) {
 	filtered, err := filterCommits(commits, cfg.Filters)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	sorted := sortEntries(filtered, cfg.Sort)
 
+	replace, err := compileReplaceRules(cfg.Replace)
+	if err != nil {
+		return "", nil, err
+	}
+	format := entryFormat{
+		maxEntries: cfg.MaxEntries,
+		byScope:    cfg.GroupByScope,
+		replace:    replace,
+		trim:       cfg.Trim,
+	}
+
+	var deps []string
+	if cfg.Dependencies != "" {
+		sorted, deps = splitDependencies(sorted)
+		if cfg.Dependencies == DependenciesExclude {
+			deps = nil
+		}
+	}
+
+	var out string
+	var listed []string
 	if len(cfg.Groups) > 0 {
-		return formatGrouped(version, sorted, cfg.Groups)
+		if out, listed, err = formatGrouped(version, sorted, cfg.Groups, format); err != nil {
+			return "", nil, err
+		}
+	} else {
+		out, listed = formatFlat(version, sorted, format)
+	}
+	if len(deps) > 0 {
+		out = appendSection(out, "Dependencies", func(b *strings.Builder) {
+			listed = append(listed, format.write(b, deps)...)
+		})
+	}
+	return out, listed, nil
+}
+
+// changelog.dependencies values.
+const (
+	DependenciesGroup   = "group"   // list dependency updates under a Dependencies heading
+	DependenciesExclude = "exclude" // leave dependency updates out
+)
+
+// dependencyPattern matches the subjects of dependency update commits, such
+// as Dependabot's "Bump foo from 1.0 to 1.1" or, with a conventional commit
+// prefix, "build(deps): bump foo from 1.0 to 1.1".
+var dependencyPattern = regexp.MustCompile(`^((build|chore)\(deps(-dev)?\)!?:|Bump \S+ from \S+ to \S+)`)
+
+// splitDependencies separates dependency update commits from the rest,
+// keeping the order of each.
+func splitDependencies(commits []string) (rest, deps []string) {
+	for _, c := range commits {
+		if dependencyPattern.MatchString(c) {
+			deps = append(deps, c)
+		} else {
+			rest = append(rest, c)
+		}
 	}
-	return formatFlat(version, sorted), nil
+	return rest, deps
+}
+
+// appendSection adds a section titled title to the end of changelog, with
+// its entries written by write.
+func appendSection(changelog, title string, write func(b *strings.Builder)) string {
+	var b strings.Builder
+	b.WriteString(changelog)
+	// A flat changelog with no other entries ends after its heading
+	if !strings.HasSuffix(changelog, "\n\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString("### " + title + "\n\n")
+	write(&b)
+	return b.String()
 }
 
 // filterCommits applies include/exclude regex filters to commits.
@@ -100,7 +176,9 @@ func sortEntries(commits []string, sortOrder string) []string {
 
 // formatGrouped formats commits into titled groups sorted by Order.
 // A group with an empty Regexp acts as a catch-all for unmatched commits.
-func formatGrouped(version string, commits []string, groups []config.ChangelogGroupConfig) (string, error) {
+// Each group's entries are written with format. The commits written are
+// returned with the changelog.
+func formatGrouped(version string, commits []string, groups []config.ChangelogGroupConfig, format entryFormat) (string, []string, error) {
 	// Sort groups by Order
 	sortedGroups := make([]config.ChangelogGroupConfig, len(groups))
 	copy(sortedGroups, groups)
@@ -121,7 +199,7 @@ func formatGrouped(version string, commits []string, groups []config.ChangelogGr
 		if g.Regexp != "" {
 			re, err := regexp.Compile(g.Regexp)
 			if err != nil {
-				return "", fmt.Errorf("invalid group regexp %q: %w", g.Regexp, err)
+				return "", nil, fmt.Errorf("invalid group regexp %q: %w", g.Regexp, err)
 			}
 			buckets[i].re = re
 		}
@@ -153,27 +231,140 @@ func formatGrouped(version string, commits []string, groups []config.ChangelogGr
 	var b strings.Builder
 	fmt.Fprintf(&b, "## %s\n", version)
 
+	var written []string
 	for _, bucket := range buckets {
 		if len(bucket.commits) == 0 {
 			continue
 		}
 		fmt.Fprintf(&b, "\n### %s\n\n", bucket.title)
-		for _, c := range bucket.commits {
-			fmt.Fprintf(&b, "- %s\n", c)
-		}
+		written = append(written, format.write(&b, bucket.commits)...)
 	}
 
-	return b.String(), nil
+	return b.String(), written, nil
 }
 
-// formatFlat formats commits as a simple bullet list under a version heading.
-func formatFlat(version string, commits []string) string {
+// formatFlat formats commits as a simple bullet list under a version heading,
+// returning the commits written with it.
+func formatFlat(version string, commits []string, format entryFormat) (string, []string) {
 	var b strings.Builder
 	fmt.Fprintf(&b, "## %s\n\n", version)
+	written := format.write(&b, commits)
+	return b.String(), written
+}
+
+// entryFormat controls how a list of commits is written as bullets
+type entryFormat struct {
+	maxEntries int           // if positive, the number of entries written before "...and N more"
+	byScope    bool          // nest entries under a bullet per conventional commit scope
+	replace    []replaceRule // rewrites applied to each subject, in order
+	trim       bool          // trim surrounding whitespace after the rewrites
+}
+
+// replaceRule is a compiled changelog.replace entry
+type replaceRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// compileReplaceRules compiles changelog.replace entries in order.
+func compileReplaceRules(rules []config.ChangelogReplaceConfig) ([]replaceRule, error) {
+	compiled := make([]replaceRule, 0, len(rules))
+	for _, r := range rules {
+		re, err := regexp.Compile(r.Regexp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replace regexp %q: %w", r.Regexp, err)
+		}
+		compiled = append(compiled, replaceRule{re: re, replacement: r.Replacement})
+	}
+	return compiled, nil
+}
+
+// clean applies the replace rules and trimming to a commit subject.
+func (f entryFormat) clean(commit string) string {
+	for _, r := range f.replace {
+		commit = r.re.ReplaceAllString(commit, r.replacement)
+	}
+	if f.trim {
+		commit = strings.TrimSpace(commit)
+	}
+	return commit
+}
+
+// write writes commits as bullets and returns those shown, which leave out
+// any hidden by maxEntries. Filtering, grouping, and scopes work on the raw
+// subjects; only the written text is cleaned.
+func (f entryFormat) write(b *strings.Builder, commits []string) []string {
+	shown := commits
+	if f.maxEntries > 0 && len(commits) > f.maxEntries {
+		shown = commits[:f.maxEntries]
+	}
+
+	if f.byScope {
+		for _, group := range groupByScope(shown) {
+			fmt.Fprintf(b, "- **%s**\n", group.scope)
+			for _, c := range group.commits {
+				fmt.Fprintf(b, "  - %s\n", f.clean(c))
+			}
+		}
+	} else {
+		for _, c := range shown {
+			fmt.Fprintf(b, "- %s\n", f.clean(c))
+		}
+	}
+	if hidden := len(commits) - len(shown); hidden > 0 {
+		fmt.Fprintf(b, "- ...and %d more\n", hidden)
+	}
+	return shown
+}
+
+// defaultScope is the scope heading for commits without a conventional
+// commit scope when grouping by scope.
+const defaultScope = "General"
+
+// scopePattern matches a conventional commit header such as
+// "feat(ui): ..." or "fix(core)!: ...", capturing the scope.
+var scopePattern = regexp.MustCompile(`^[A-Za-z]+\(([^()]+)\)!?:`)
+
+// parseScope returns the conventional commit scope of a commit message, or
+// "" if the message has none.
+func parseScope(commit string) string {
+	matches := scopePattern.FindStringSubmatch(commit)
+	if matches == nil {
+		return ""
+	}
+	return strings.TrimSpace(matches[1])
+}
+
+// scopeGroup holds the commits sharing a scope
+type scopeGroup struct {
+	scope   string
+	commits []string
+}
+
+// groupByScope buckets commits by scope. Scopes keep the order in which
+// they first appear; unscoped commits come last under defaultScope.
+func groupByScope(commits []string) []scopeGroup {
+	var groups []scopeGroup
+	index := make(map[string]int)
+	var unscoped []string
 
 	for _, c := range commits {
-		fmt.Fprintf(&b, "- %s\n", c)
+		scope := parseScope(c)
+		if scope == "" {
+			unscoped = append(unscoped, c)
+			continue
+		}
+		i, ok := index[scope]
+		if !ok {
+			i = len(groups)
+			index[scope] = i
+			groups = append(groups, scopeGroup{scope: scope})
+		}
+		groups[i].commits = append(groups[i].commits, c)
 	}
 
-	return b.String()
+	if len(unscoped) > 0 {
+		groups = append(groups, scopeGroup{scope: defaultScope, commits: unscoped})
+	}
+	return groups
 }
@@ -1,10 +1,12 @@
 package changelog
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/macreleaser/macreleaser/pkg/config"
+	"github.com/macreleaser/macreleaser/pkg/git"
 )
 
 func TestGenerateFlat(t *testing.T) {
@@ -242,3 +244,334 @@ func TestGenerateInvalidIncludeRegex(t *testing.T) {
 		t.Errorf("error = %v, want error about invalid include filter", err)
 	}
 }
+
+func TestGenerateMaxEntriesFlat(t *testing.T) {
+	commits := []string{"feat: one", "feat: two", "fix: three", "fix: four", "docs: five"}
+	cfg := config.ChangelogConfig{
+		Filters:    config.ChangelogFiltersConfig{Exclude: []string{"^docs:"}},
+		Sort:       "asc",
+		MaxEntries: 2,
+	}
+
+	out, err := Generate("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// Truncation applies after filtering and sorting
+	want := "## v1.2.0\n\n- fix: four\n- fix: three\n- ...and 2 more\n"
+	if out != want {
+		t.Errorf("Generate() =\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestGenerateMaxEntriesGrouped(t *testing.T) {
+	commits := []string{"feat: a", "feat: b", "feat: c", "fix: d", "fix: e", "chore: f"}
+	cfg := config.ChangelogConfig{
+		Groups: []config.ChangelogGroupConfig{
+			{Title: "Features", Regexp: "^feat:", Order: 0},
+			{Title: "Bug Fixes", Regexp: "^fix:", Order: 1},
+			{Title: "Other", Order: 2},
+		},
+		MaxEntries: 2,
+	}
+
+	out, err := Generate("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// The limit applies to each group on its own
+	for _, want := range []string{
+		"### Features\n\n- feat: a\n- feat: b\n- ...and 1 more\n",
+		"### Bug Fixes\n\n- fix: d\n- fix: e\n\n",
+		"### Other\n\n- chore: f\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "feat: c") {
+		t.Errorf("truncated entry present in output:\n%s", out)
+	}
+	if got := strings.Count(out, "more\n"); got != 1 {
+		t.Errorf("found %d summary lines, want 1:\n%s", got, out)
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		commit string
+		want   string
+	}{
+		{commit: "feat(ui): add button", want: "ui"},
+		{commit: "fix(core)!: drop legacy API", want: "core"},
+		{commit: "feat: no scope", want: ""},
+		{commit: "Merge pull request #12 (from fork)", want: ""},
+		{commit: "feat(): empty scope", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseScope(tt.commit); got != tt.want {
+			t.Errorf("parseScope(%q) = %q, want %q", tt.commit, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateGroupByScope(t *testing.T) {
+	commits := []string{
+		"feat(ui): add button",
+		"feat(core): faster sync",
+		"feat: plain feature",
+		"feat(ui): dark mode",
+		"fix(core): crash on launch",
+	}
+	cfg := config.ChangelogConfig{
+		Groups: []config.ChangelogGroupConfig{
+			{Title: "Features", Regexp: "^feat", Order: 0},
+			{Title: "Bug Fixes", Regexp: "^fix", Order: 1},
+		},
+		GroupByScope: true,
+	}
+
+	out, err := Generate("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := "## v1.2.0\n" +
+		"\n### Features\n\n" +
+		"- **ui**\n  - feat(ui): add button\n  - feat(ui): dark mode\n" +
+		"- **core**\n  - feat(core): faster sync\n" +
+		"- **General**\n  - feat: plain feature\n" +
+		"\n### Bug Fixes\n\n" +
+		"- **core**\n  - fix(core): crash on launch\n"
+	if out != want {
+		t.Errorf("Generate() =\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestGenerateGroupByScopeFlat(t *testing.T) {
+	commits := []string{"update docs", "feat(ui): add button"}
+	cfg := config.ChangelogConfig{GroupByScope: true}
+
+	out, err := Generate("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := "## v1.2.0\n\n- **ui**\n  - feat(ui): add button\n- **General**\n  - update docs\n"
+	if out != want {
+		t.Errorf("Generate() =\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestGenerateReplaceAndTrim(t *testing.T) {
+	commits := []string{"JIRA-123: feat: add widget  ", "fix: resolve crash", "JIRA-9:   fix: typo"}
+	cfg := config.ChangelogConfig{
+		Replace: []config.ChangelogReplaceConfig{
+			{Regexp: `^[A-Z]+-\d+:\s*`, Replacement: ""},
+			{Regexp: `^(\w+):`, Replacement: "[$1]"},
+		},
+		Trim: true,
+	}
+
+	out, err := Generate("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := "## v1.2.0\n\n- [feat] add widget\n- [fix] resolve crash\n- [fix] typo\n"
+	if out != want {
+		t.Errorf("Generate() =\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestGenerateInvalidReplaceRegex(t *testing.T) {
+	cfg := config.ChangelogConfig{
+		Replace: []config.ChangelogReplaceConfig{{Regexp: "[invalid", Replacement: ""}},
+	}
+
+	_, err := Generate("v1.0.0", []string{"fix: something"}, cfg)
+	if err == nil || !strings.Contains(err.Error(), "invalid replace regexp") {
+		t.Errorf("Generate() error = %v, want invalid replace regexp error", err)
+	}
+}
+
+func TestGenerateDependencies(t *testing.T) {
+	commits := []string{
+		"build(deps): bump golang.org/x/net from 0.20.0 to 0.23.0",
+		"feat: add widget",
+		"Bump actions/checkout from 3 to 4",
+		"chore(deps-dev): bump eslint from 8.0.0 to 9.0.0",
+		"fix: resolve crash",
+		"chore: bump version in README",
+		"docs: explain the Bump command",
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.ChangelogConfig
+		want string
+	}{
+		{
+			name: "listed with other commits by default",
+			cfg:  config.ChangelogConfig{},
+			want: "## v1.2.0\n\n" +
+				"- build(deps): bump golang.org/x/net from 0.20.0 to 0.23.0\n" +
+				"- feat: add widget\n" +
+				"- Bump actions/checkout from 3 to 4\n" +
+				"- chore(deps-dev): bump eslint from 8.0.0 to 9.0.0\n" +
+				"- fix: resolve crash\n" +
+				"- chore: bump version in README\n" +
+				"- docs: explain the Bump command\n",
+		},
+		{
+			name: "grouped in a flat changelog",
+			cfg:  config.ChangelogConfig{Dependencies: DependenciesGroup},
+			want: "## v1.2.0\n\n" +
+				"- feat: add widget\n" +
+				"- fix: resolve crash\n" +
+				"- chore: bump version in README\n" +
+				"- docs: explain the Bump command\n" +
+				"\n### Dependencies\n\n" +
+				"- build(deps): bump golang.org/x/net from 0.20.0 to 0.23.0\n" +
+				"- Bump actions/checkout from 3 to 4\n" +
+				"- chore(deps-dev): bump eslint from 8.0.0 to 9.0.0\n",
+		},
+		{
+			name: "grouped after the configured groups",
+			cfg: config.ChangelogConfig{
+				Dependencies: DependenciesGroup,
+				Groups: []config.ChangelogGroupConfig{
+					{Title: "Features", Regexp: "^feat:"},
+					{Title: "Other", Order: 1},
+				},
+			},
+			want: "## v1.2.0\n" +
+				"\n### Features\n\n" +
+				"- feat: add widget\n" +
+				"\n### Other\n\n" +
+				"- fix: resolve crash\n" +
+				"- chore: bump version in README\n" +
+				"- docs: explain the Bump command\n" +
+				"\n### Dependencies\n\n" +
+				"- build(deps): bump golang.org/x/net from 0.20.0 to 0.23.0\n" +
+				"- Bump actions/checkout from 3 to 4\n" +
+				"- chore(deps-dev): bump eslint from 8.0.0 to 9.0.0\n",
+		},
+		{
+			name: "excluded",
+			cfg:  config.ChangelogConfig{Dependencies: DependenciesExclude},
+			want: "## v1.2.0\n\n" +
+				"- feat: add widget\n" +
+				"- fix: resolve crash\n" +
+				"- chore: bump version in README\n" +
+				"- docs: explain the Bump command\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Generate("v1.2.0", commits, tt.cfg)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if out != tt.want {
+				t.Errorf("Generate() =\n%s\nwant:\n%s", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateOnlyDependencies(t *testing.T) {
+	commits := []string{"Bump actions/checkout from 3 to 4"}
+	cfg := config.ChangelogConfig{Dependencies: DependenciesGroup}
+
+	out, err := Generate("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	want := "## v1.2.0\n\n### Dependencies\n\n- Bump actions/checkout from 3 to 4\n"
+	if out != want {
+		t.Errorf("Generate() =\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestParseTrailers(t *testing.T) {
+	body := `Rework the widget layout.
+
+Fixes #12, #14
+closes: other/repo#7
+Resolves #12
+Co-authored-by: Jane Doe <jane@example.com>
+Co-Authored-By: John Smith <john@example.com>
+co-authored-by: Jane Doe <jane@users.noreply.github.com>
+Signed-off-by: Someone <someone@example.com>`
+
+	got := ParseTrailers(body)
+	want := Trailers{
+		Issues:    []string{"#12", "#14", "other/repo#7"},
+		CoAuthors: []string{"Jane Doe", "John Smith"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTrailers() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGenerateFromCommitsTrailers(t *testing.T) {
+	commits := []git.Commit{
+		{Subject: "docs: update readme", Body: "Fixes #30"},
+		{Subject: "fix: resolve crash", Body: "Fixes #21\nCo-authored-by: John Smith <john@example.com>"},
+		{Subject: "feat: add widget", Body: "Closes #20\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: John Smith <john@example.com>"},
+	}
+	cfg := config.ChangelogConfig{
+		IncludeTrailers: true,
+		Filters:         config.ChangelogFiltersConfig{Exclude: []string{"^docs:"}},
+	}
+
+	out, err := GenerateFromCommits("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("GenerateFromCommits() error = %v", err)
+	}
+	want := "## v1.2.0\n\n- fix: resolve crash\n- feat: add widget\n" +
+		"\n### Linked Issues\n\n- #20\n- #21\n" +
+		"\n### Co-authors\n\n- Jane Doe\n- John Smith\n"
+	if out != want {
+		t.Errorf("GenerateFromCommits() =\n%s\nwant:\n%s", out, want)
+	}
+
+	cfg.IncludeTrailers = false
+	out, err = GenerateFromCommits("v1.2.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("GenerateFromCommits() error = %v", err)
+	}
+	if strings.Contains(out, "Linked Issues") || strings.Contains(out, "Co-authors") {
+		t.Errorf("GenerateFromCommits() without include_trailers listed trailers:\n%s", out)
+	}
+}
+
+func TestGenerateFromCommitsTrailersOfListedCommits(t *testing.T) {
+	commits := []git.Commit{
+		{Subject: "build(deps): bump foo from 1.0 to 1.1", Body: "Co-authored-by: dependabot <bot@example.com>"},
+		{Subject: "feat: newer feature", Body: "Closes #12"},
+		{Subject: "fix: older fix", Body: "Fixes #11"},
+	}
+	cfg := config.ChangelogConfig{
+		IncludeTrailers: true,
+		MaxEntries:      1,
+		Dependencies:    DependenciesExclude,
+	}
+
+	out, err := GenerateFromCommits("v1.3.0", commits, cfg)
+	if err != nil {
+		t.Fatalf("GenerateFromCommits() error = %v", err)
+	}
+	// Only the feature is listed: the fix is past max_entries and the
+	// dependency update is excluded
+	want := "## v1.3.0\n\n- feat: newer feature\n- ...and 1 more\n" +
+		"\n### Linked Issues\n\n- #12\n"
+	if out != want {
+		t.Errorf("GenerateFromCommits() =\n%s\nwant:\n%s", out, want)
+	}
+}
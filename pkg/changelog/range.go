@@ -0,0 +1,39 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	"github.com/macreleaser/macreleaser/pkg/git"
+)
+
+// SinceLastStable is the changelog.since value that starts the changelog
+// after the previous stable tag, skipping intermediate prereleases.
+const SinceLastStable = "last-stable"
+
+// PreviousRef returns the ref the changelog for ref starts after:
+// changelog.previous_tag when set, otherwise the tag before ref, or with
+// changelog.since: last-stable the nearest earlier tag without a prerelease
+// segment. Returns "" when there is no such tag.
+func PreviousRef(ctx context.Context, cfg config.ChangelogConfig, ref string) (string, error) {
+	if cfg.PreviousTag != "" {
+		if err := CheckPreviousTag(ctx, cfg.PreviousTag); err != nil {
+			return "", err
+		}
+		return cfg.PreviousTag, nil
+	}
+	if cfg.Since == SinceLastStable {
+		return git.PreviousStableTag(ctx, ref)
+	}
+	return git.PreviousTag(ctx, ref)
+}
+
+// CheckPreviousTag returns an error if the changelog.previous_tag override
+// does not name an existing tag.
+func CheckPreviousTag(ctx context.Context, tag string) error {
+	if !git.TagExists(ctx, tag) {
+		return fmt.Errorf("changelog.previous_tag %q does not exist — fetch tags with `git fetch --tags` or correct the name", tag)
+	}
+	return nil
+}
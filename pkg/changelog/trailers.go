@@ -0,0 +1,106 @@
+package changelog
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	"github.com/macreleaser/macreleaser/pkg/git"
+)
+
+// Trailers holds what the changelog surfaces from commit message trailers.
+type Trailers struct {
+	Issues    []string // issue references such as "#123" or "owner/repo#45"
+	CoAuthors []string // co-author names, without their email addresses
+}
+
+// issueTrailerPattern matches a line starting with one of GitHub's closing
+// keywords, as in "Fixes #123" or the trailer form "Closes: owner/repo#4".
+var issueTrailerPattern = regexp.MustCompile(`(?i)^(close[sd]?|fix(e[sd])?|resolve[sd]?):?\s+(.+)$`)
+
+// issueRefPattern matches a single issue reference.
+var issueRefPattern = regexp.MustCompile(`(?:[\w.-]+/[\w.-]+)?#\d+\b`)
+
+// coAuthorPattern matches a Co-authored-by trailer, capturing the name.
+var coAuthorPattern = regexp.MustCompile(`(?i)^co-authored-by:\s*([^<]+?)\s*(<[^>]*>)?\s*$`)
+
+// ParseTrailers returns the linked issues and co-authors named in a commit
+// body, each once, in the order they appear.
+func ParseTrailers(body string) Trailers {
+	var t Trailers
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if m := coAuthorPattern.FindStringSubmatch(line); m != nil {
+			t.CoAuthors = appendUnique(t.CoAuthors, m[1])
+			continue
+		}
+		if m := issueTrailerPattern.FindStringSubmatch(line); m != nil {
+			for _, ref := range issueRefPattern.FindAllString(m[3], -1) {
+				t.Issues = appendUnique(t.Issues, ref)
+			}
+		}
+	}
+	return t
+}
+
+// GenerateFromCommits is Generate for commits read with their bodies. With
+// changelog.include_trailers set, the issues and co-authors named in the
+// trailers of the commits the changelog lists are listed after the entries.
+func GenerateFromCommits(version string, commits []git.Commit, cfg config.ChangelogConfig) (string, error) {
+	subjects := make([]string, len(commits))
+	for i, c := range commits {
+		subjects[i] = c.Subject
+	}
+	out, listed, err := generate(version, subjects, cfg)
+	if err != nil || !cfg.IncludeTrailers {
+		return out, err
+	}
+
+	keep := make(map[string]bool, len(listed))
+	for _, s := range listed {
+		keep[s] = true
+	}
+
+	// git log lists the newest commit first; collect oldest first so the
+	// lists follow the order the work landed in
+	var all Trailers
+	for i := len(commits) - 1; i >= 0; i-- {
+		if !keep[commits[i].Subject] {
+			continue
+		}
+		t := ParseTrailers(commits[i].Body)
+		for _, issue := range t.Issues {
+			all.Issues = appendUnique(all.Issues, issue)
+		}
+		for _, name := range t.CoAuthors {
+			all.CoAuthors = appendUnique(all.CoAuthors, name)
+		}
+	}
+
+	out = appendList(out, "Linked Issues", all.Issues)
+	out = appendList(out, "Co-authors", all.CoAuthors)
+	return out, nil
+}
+
+// appendList adds a section titled title listing items to the end of
+// changelog, if there are any.
+func appendList(changelog, title string, items []string) string {
+	if len(items) == 0 {
+		return changelog
+	}
+	return appendSection(changelog, title, func(b *strings.Builder) {
+		for _, item := range items {
+			b.WriteString("- " + item + "\n")
+		}
+	})
+}
+
+// appendUnique appends s to list unless it is already there.
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
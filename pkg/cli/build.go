@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"context"
+
 	"github.com/macreleaser/macreleaser/pkg/git"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -18,16 +20,28 @@ the .app from the archive, and packages it into the configured formats.`,
 		if clean, _ := cmd.Flags().GetBool("clean"); clean {
 			opts = append(opts, withClean())
 		}
+		if skip, _ := cmd.Flags().GetBool("skip-validation"); skip {
+			opts = append(opts, withSkipValidation())
+		}
+		if tag, _ := cmd.Flags().GetString("previous-tag"); tag != "" {
+			opts = append(opts, withPreviousTag(tag))
+		}
+		if profile, _ := cmd.Flags().GetString("notarize-profile"); profile != "" {
+			opts = append(opts, withNotarizeProfile(profile))
+		}
 		if skip, _ := cmd.Flags().GetBool("skip-notarize"); skip {
 			opts = append(opts, withSkipNotarize())
 		}
+		if appOnly, _ := cmd.Flags().GetBool("app-only"); appOnly {
+			opts = append(opts, withAppOnly())
+		}
 		runPipelineCommand("Build", requireGitVersion, opts...)
 	},
 }
 
 // requireGitVersion resolves the version from git tags, exiting on failure.
 func requireGitVersion(logger *logrus.Logger) string {
-	version, err := git.ResolveVersion()
+	version, err := git.ResolveVersion(context.Background())
 	if err != nil {
 		ExitWithErrorf(logger, "Failed to resolve version: %v", err)
 	}
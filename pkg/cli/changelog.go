@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/changelog"
+	"github.com/macreleaser/macreleaser/pkg/config"
+	"github.com/macreleaser/macreleaser/pkg/git"
+	"github.com/spf13/cobra"
+)
+
+// changelogCmd represents the changelog command
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Print the changelog without building or releasing",
+	Long: `Render the changelog for the commits between two refs and print it to
+stdout, using the changelog section of the configuration. --to defaults to
+the latest tag and --from to the tag before it. Nothing is built or
+published.
+
+With --since, the changelog instead lists the commits made within the given
+duration, such as 168h for the last week, regardless of tags.`,
+	Args: cobra.NoArgs,
+	Run:  runChangelog,
+}
+
+// runChangelog executes the changelog command
+func runChangelog(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	cfg, err := config.LoadConfigProfile(findConfigPath(logger), GetProfile())
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to load configuration: %v", err)
+	}
+
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	since, _ := cmd.Flags().GetDuration("since")
+
+	var out string
+	switch {
+	case since < 0:
+		ExitWithErrorf(logger, "--since must be positive, got %s", since)
+	case since > 0 && from != "":
+		ExitWithErrorf(logger, "--since cannot be combined with --from")
+	case since > 0:
+		out, err = renderChangelogSince(cfg.Changelog, since, to)
+	default:
+		out, err = renderChangelog(cfg.Changelog, from, to)
+	}
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to render changelog: %v", err)
+	}
+	fmt.Fprint(cmd.OutOrStdout(), out)
+}
+
+// renderChangelog generates the changelog for the commits after from up to
+// to, headed by to. An empty to is the latest tag; an empty from is the tag
+// before to (honoring changelog.since), or the start of history if there is
+// none.
+func renderChangelog(cfg config.ChangelogConfig, from, to string) (string, error) {
+	if to == "" {
+		latest, err := git.ResolveVersion(context.Background())
+		if err != nil {
+			return "", err
+		}
+		to = latest
+	}
+
+	if from == "" {
+		prev, err := changelog.PreviousRef(context.Background(), cfg, to)
+		if err != nil {
+			return "", fmt.Errorf("failed to find previous tag: %w", err)
+		}
+		from = prev
+	}
+
+	commits, err := git.LogCommitsBetween(context.Background(), from, to, cfg.IncludeTrailers)
+	if err != nil {
+		return "", fmt.Errorf("failed to get git log: %w", err)
+	}
+
+	return changelog.GenerateFromCommits(to, commits, cfg)
+}
+
+// renderChangelogSince generates the changelog for the commits up to to
+// made within since of now. An empty to is HEAD, and the changelog is then
+// headed by the date the period starts on.
+func renderChangelogSince(cfg config.ChangelogConfig, since time.Duration, to string) (string, error) {
+	heading := to
+	if to == "" {
+		to = "HEAD"
+		heading = "Since " + time.Now().Add(-since).Format(time.DateOnly)
+	}
+
+	commits, err := git.LogSince(context.Background(), since, to, cfg.IncludeTrailers)
+	if err != nil {
+		return "", fmt.Errorf("failed to get git log: %w", err)
+	}
+
+	return changelog.GenerateFromCommits(heading, commits, cfg)
+}
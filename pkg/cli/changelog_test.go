@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+)
+
+// setupChangelogRepo creates a git repo with commits tagged v1.0.0 and
+// v1.1.0 and makes it the working directory. The test is skipped if git is
+// unavailable.
+func setupChangelogRepo(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Skipping: git not available")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	gitCmds := [][]string{
+		{"init", "--template="},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test"},
+		{"commit", "--allow-empty", "-m", "feat: initial release"},
+		{"tag", "v1.0.0"},
+		{"commit", "--allow-empty", "-m", "feat: add widget"},
+		{"commit", "--allow-empty", "-m", "fix: resolve crash"},
+		{"tag", "v1.1.0"},
+		{"commit", "--allow-empty", "-m", "docs: unreleased change"},
+	}
+	for _, args := range gitCmds {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("Skipping: git %s failed in this environment: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
+
+func TestRenderChangelogDefaults(t *testing.T) {
+	setupChangelogRepo(t)
+
+	cfg := config.ChangelogConfig{
+		Groups: []config.ChangelogGroupConfig{
+			{Title: "Features", Regexp: "^feat:", Order: 0},
+			{Title: "Bug Fixes", Regexp: "^fix:", Order: 1},
+		},
+	}
+
+	out, err := renderChangelog(cfg, "", "")
+	if err != nil {
+		t.Fatalf("renderChangelog() error = %v", err)
+	}
+
+	for _, want := range []string{"## v1.1.0\n", "### Features\n", "- feat: add widget\n", "### Bug Fixes\n", "- fix: resolve crash\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"initial release", "unreleased change"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("output contains %q from outside the range:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestRenderChangelogExplicitRange(t *testing.T) {
+	setupChangelogRepo(t)
+
+	out, err := renderChangelog(config.ChangelogConfig{}, "v1.1.0", "HEAD")
+	if err != nil {
+		t.Fatalf("renderChangelog() error = %v", err)
+	}
+
+	want := "## HEAD\n\n- docs: unreleased change\n"
+	if out != want {
+		t.Errorf("renderChangelog() =\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRenderChangelogSince(t *testing.T) {
+	setupChangelogRepo(t)
+
+	// Every commit in the repo is recent, so all are listed regardless of tags
+	out, err := renderChangelogSince(config.ChangelogConfig{}, 7*24*time.Hour, "")
+	if err != nil {
+		t.Fatalf("renderChangelogSince() error = %v", err)
+	}
+
+	heading := "## Since " + time.Now().Add(-7*24*time.Hour).Format(time.DateOnly) + "\n"
+	for _, want := range []string{heading, "- docs: unreleased change\n", "- fix: resolve crash\n", "- feat: initial release\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	out, err = renderChangelogSince(config.ChangelogConfig{}, 7*24*time.Hour, "v1.0.0")
+	if err != nil {
+		t.Fatalf("renderChangelogSince() error = %v", err)
+	}
+	if want := "## v1.0.0\n\n- feat: initial release\n"; out != want {
+		t.Errorf("renderChangelogSince() =\n%s\nwant:\n%s", out, want)
+	}
+}
@@ -21,11 +21,11 @@ the configuration against expected patterns and constraints.`,
 
 // runCheck executes the check command
 func runCheck(cmd *cobra.Command, args []string) {
-	logger := SetupLogger(GetDebugMode())
-	configPath := GetConfigPath()
+	logger := newCommandLogger()
+	configPath := findConfigPath(logger)
 
 	// Load configuration
-	cfg, err := config.LoadConfig(configPath)
+	cfg, err := config.LoadConfigProfile(configPath, GetProfile())
 	if err != nil {
 		ExitWithErrorf(logger, "Failed to load configuration: %v", err)
 	}
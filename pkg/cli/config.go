@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/goccy/go-yaml"
+	"github.com/macreleaser/macreleaser/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// configCmd groups configuration inspection subcommands
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect macreleaser configuration",
+}
+
+// configShowCmd represents the config show command
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration",
+	Long: `Print the effective configuration after env(...) substitution, the active
+--profile overlay, and defaults.
+Secret fields (passwords and tokens) are masked. Unresolved env(...) references
+are shown as-is so missing environment variables are easy to spot.`,
+	Run: runConfigShow,
+}
+
+// runConfigShow executes the config show command
+func runConfigShow(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	cfg, err := config.LoadConfigProfile(findConfigPath(logger), GetProfile())
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to load configuration: %v", err)
+	}
+
+	out, err := renderEffectiveConfig(cfg)
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to render configuration: %v", err)
+	}
+	fmt.Fprint(cmd.OutOrStdout(), out)
+}
+
+// renderEffectiveConfig applies defaults, masks secrets, and marshals the
+// configuration to YAML. The profiles section is omitted since the active
+// profile is already merged in. cfg is not modified.
+func renderEffectiveConfig(cfg *config.Config) (string, error) {
+	effective := *cfg
+	effective.Profiles = nil
+	config.ApplyDefaults(&effective)
+	maskSecrets(&effective)
+
+	data, err := yaml.Marshal(&effective)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return string(data), nil
+}
+
+// secretMask replaces secret values in displayed configuration
+const secretMask = "********"
+
+// unresolvedEnvPattern matches a value that is entirely an env(...) reference
+var unresolvedEnvPattern = regexp.MustCompile(`^env\([^)]+\)$`)
+
+// maskSecrets redacts passwords and tokens in place. Empty values and
+// unresolved env(...) references carry no secret and are left visible.
+func maskSecrets(cfg *config.Config) {
+	secrets := []*string{
+		&cfg.Notarize.Password,
+		&cfg.Sign.KeychainPassword,
+		&cfg.Release.GitHub.Token,
+		&cfg.Homebrew.Tap.Token,
+		&cfg.Homebrew.Tap.Beta.Token,
+		&cfg.Homebrew.Official.Token,
+	}
+	for _, s := range secrets {
+		maskSecret(s)
+	}
+
+	// The profiles map is shared with the caller's config, so it is copied
+	// before its passwords are masked
+	if len(cfg.Notarize.Profiles) > 0 {
+		profiles := make(map[string]config.NotarizeCredentials, len(cfg.Notarize.Profiles))
+		for name, creds := range cfg.Notarize.Profiles {
+			maskSecret(&creds.Password)
+			profiles[name] = creds
+		}
+		cfg.Notarize.Profiles = profiles
+	}
+
+	// The taps slice is shared with the caller's config too
+	if len(cfg.Homebrew.Taps) > 0 {
+		taps := make([]config.TapConfig, len(cfg.Homebrew.Taps))
+		for i, tap := range cfg.Homebrew.Taps {
+			maskSecret(&tap.Token)
+			maskSecret(&tap.Beta.Token)
+			taps[i] = tap
+		}
+		cfg.Homebrew.Taps = taps
+	}
+
+	// Header values often carry proxy credentials, so all of them are masked
+	if len(cfg.Release.GitHub.Headers) > 0 {
+		headers := make(map[string]string, len(cfg.Release.GitHub.Headers))
+		for name, value := range cfg.Release.GitHub.Headers {
+			maskSecret(&value)
+			headers[name] = value
+		}
+		cfg.Release.GitHub.Headers = headers
+	}
+}
+
+// maskSecret redacts *s unless it is empty or an unresolved env(...) reference
+func maskSecret(s *string) {
+	if *s != "" && !unresolvedEnvPattern.MatchString(*s) {
+		*s = secretMask
+	}
+}
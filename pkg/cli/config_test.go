@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/macreleaser/macreleaser/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+func TestRenderEffectiveConfig(t *testing.T) {
+	cfg := &config.Config{
+		Project: config.ProjectConfig{Name: "MyApp", Scheme: "MyApp"},
+		Sign:    config.SignConfig{KeychainPassword: "keychain-secret"},
+		Notarize: config.NotarizeConfig{
+			AppleID:  "dev@example.com",
+			TeamID:   "TEAM123",
+			Password: "abcd-efgh-ijkl-mnop",
+			Profiles: map[string]config.NotarizeCredentials{
+				"appstore": {AppleID: "store@example.com", TeamID: "STORE99", Password: "notarize-profile-secret"},
+			},
+		},
+		Release: config.ReleaseConfig{
+			GitHub: config.GitHubConfig{
+				Token:   "ghp_releasesecret",
+				Headers: map[string]string{"Proxy-Authorization": "Bearer proxy-secret"},
+			},
+		},
+		Homebrew: config.HomebrewConfig{
+			Tap: config.TapConfig{
+				Owner: "yourname",
+				Name:  "homebrew-tap",
+				Token: "ghp_supersecret",
+			},
+			Taps: []config.TapConfig{
+				{Owner: "me", Name: "homebrew-tap", Token: "ghp_secondtap"},
+			},
+			Official: config.OfficialConfig{
+				Token: "env(HOMEBREW_OFFICIAL_TOKEN)",
+			},
+		},
+		Profiles: map[string]any{
+			"beta": map[string]any{"notarize": map[string]any{"password": "profile-secret"}},
+		},
+	}
+
+	out, err := renderEffectiveConfig(cfg)
+	if err != nil {
+		t.Fatalf("renderEffectiveConfig() error = %v", err)
+	}
+
+	var parsed config.Config
+	if err := yaml.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, out)
+	}
+
+	for _, secret := range []string{"abcd-efgh-ijkl-mnop", "ghp_supersecret", "profile-secret", "keychain-secret", "notarize-profile-secret", "proxy-secret", "ghp_secondtap", "ghp_releasesecret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("output contains secret %q:\n%s", secret, out)
+		}
+	}
+	if parsed.Notarize.Password != secretMask {
+		t.Errorf("notarize.password = %q, want %q", parsed.Notarize.Password, secretMask)
+	}
+	if got := parsed.Notarize.Profiles["appstore"]; got.Password != secretMask || got.AppleID != "store@example.com" {
+		t.Errorf("notarize.profiles.appstore = %+v, want a masked password and the apple_id kept", got)
+	}
+	if got := parsed.Release.GitHub.Headers["Proxy-Authorization"]; got != secretMask {
+		t.Errorf("release.github.headers value = %q, want %q", got, secretMask)
+	}
+	if parsed.Homebrew.Tap.Token != secretMask {
+		t.Errorf("homebrew.tap.token = %q, want %q", parsed.Homebrew.Tap.Token, secretMask)
+	}
+	if len(parsed.Homebrew.Taps) != 1 || parsed.Homebrew.Taps[0].Token != secretMask || parsed.Homebrew.Taps[0].Owner != "me" {
+		t.Errorf("homebrew.taps = %+v, want the token masked and the owner kept", parsed.Homebrew.Taps)
+	}
+	if parsed.Homebrew.Official.Token != "env(HOMEBREW_OFFICIAL_TOKEN)" {
+		t.Errorf("unresolved env reference should stay visible, got %q", parsed.Homebrew.Official.Token)
+	}
+	if parsed.Homebrew.Tap.Beta.Token != "" {
+		t.Errorf("empty secret should stay empty, got %q", parsed.Homebrew.Tap.Beta.Token)
+	}
+
+	if parsed.Notarize.AppleID != "dev@example.com" || parsed.Homebrew.Tap.Owner != "yourname" {
+		t.Errorf("non-secret fields not preserved:\n%s", out)
+	}
+	if parsed.Changelog.Sort != "desc" {
+		t.Errorf("changelog.sort = %q, want default %q", parsed.Changelog.Sort, "desc")
+	}
+
+	// The caller's config must not be modified
+	if cfg.Notarize.Password != "abcd-efgh-ijkl-mnop" || cfg.Notarize.Profiles["appstore"].Password != "notarize-profile-secret" ||
+		cfg.Release.GitHub.Headers["Proxy-Authorization"] != "Bearer proxy-secret" ||
+		cfg.Homebrew.Taps[0].Token != "ghp_secondtap" || cfg.Changelog.Sort != "" {
+		t.Error("renderEffectiveConfig() modified its input")
+	}
+}
+
+func TestFindConfigPathFromNestedDir(t *testing.T) {
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(repo, "App", "Sources")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(repo, config.DefaultConfigFile)
+	if err := os.WriteFile(configPath, []byte("project:\n  name: MyApp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Chdir(nested)
+	logger := logrus.New()
+	logger.SetOutput(&strings.Builder{})
+
+	if got := discoverConfigPath(logger, config.DefaultConfigFile, true); got != config.DefaultConfigFile {
+		t.Errorf("discoverConfigPath() with explicit --config = %q, want it unchanged", got)
+	}
+	if got := discoverConfigPath(logger, config.DefaultConfigFile, false); got != configPath {
+		t.Errorf("discoverConfigPath() = %q, want %q", got, configPath)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wd != repo {
+		t.Errorf("working directory = %q, want config directory %q", wd, repo)
+	}
+}
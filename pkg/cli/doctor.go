@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+	gh "github.com/macreleaser/macreleaser/pkg/github"
+	"github.com/macreleaser/macreleaser/pkg/sign"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the local environment for release prerequisites",
+	Long: `Check that the tools and credentials macreleaser relies on are available:
+git, xcodebuild, the security command, a Developer ID Application signing
+identity, and a GitHub token. Exits non-zero if a required check fails.`,
+	Run: runDoctor,
+}
+
+// doctorEnv holds the lookups the doctor checks depend on; replaced in tests.
+type doctorEnv struct {
+	lookPath       func(file string) (string, error)
+	listIdentities func() ([]string, error)
+	resolveToken   func() (token, source string)
+}
+
+var defaultDoctorEnv = doctorEnv{
+	lookPath:       command.LookPath,
+	listIdentities: func() ([]string, error) { return sign.ListIdentities(context.Background()) },
+	resolveToken:   func() (string, string) { return gh.ResolveGitHubToken(context.Background()) },
+}
+
+// doctorCheck is a single environment check. A failed critical check makes
+// doctor exit non-zero; other failures are reported as warnings.
+type doctorCheck struct {
+	name     string
+	critical bool
+	run      func(env doctorEnv) (string, error)
+}
+
+// doctorResult is the outcome of a doctorCheck
+type doctorResult struct {
+	name     string
+	critical bool
+	detail   string
+	err      error
+}
+
+// doctorChecks returns the checks run by doctor, in report order
+func doctorChecks() []doctorCheck {
+	return []doctorCheck{
+		{name: "git", critical: true, run: checkTool("git", "install the Xcode Command Line Tools: xcode-select --install")},
+		{name: "xcodebuild", critical: true, run: checkTool("xcodebuild", "install Xcode and run: sudo xcode-select -s /Applications/Xcode.app")},
+		{name: "security", critical: true, run: checkTool("security", "this tool requires macOS")},
+		{name: "Developer ID identity", critical: true, run: checkDeveloperIDIdentity},
+		{name: "GitHub token", critical: false, run: checkGitHubToken},
+	}
+}
+
+// checkTool returns a check that the named command is on PATH
+func checkTool(name, hint string) func(env doctorEnv) (string, error) {
+	return func(env doctorEnv) (string, error) {
+		path, err := env.lookPath(name)
+		if err != nil {
+			return "", fmt.Errorf("%s not found on PATH — %s", name, hint)
+		}
+		return path, nil
+	}
+}
+
+// checkDeveloperIDIdentity checks that the keychain holds at least one
+// Developer ID Application identity, the kind required for distribution
+// outside the App Store.
+func checkDeveloperIDIdentity(env doctorEnv) (string, error) {
+	identities, err := env.listIdentities()
+	if err != nil {
+		return "", err
+	}
+
+	var developerIDs []string
+	for _, id := range identities {
+		if strings.HasPrefix(id, "Developer ID Application:") {
+			developerIDs = append(developerIDs, id)
+		}
+	}
+	if len(developerIDs) == 0 {
+		return "", fmt.Errorf("no Developer ID Application identity found (%d other signing identities) — create one at https://developer.apple.com/account/resources/certificates", len(identities))
+	}
+	return strings.Join(developerIDs, ", "), nil
+}
+
+// checkGitHubToken checks that a GitHub token can be resolved. The token
+// itself is never printed, only where it came from.
+func checkGitHubToken(env doctorEnv) (string, error) {
+	token, source := env.resolveToken()
+	if token == "" {
+		return "", fmt.Errorf("no token found — set GITHUB_TOKEN, run `gh auth login`, or configure a git credential helper for github.com (only needed for release)")
+	}
+	return "from " + source, nil
+}
+
+// runDoctorChecks runs every check against env
+func runDoctorChecks(env doctorEnv, checks []doctorCheck) []doctorResult {
+	results := make([]doctorResult, 0, len(checks))
+	for _, c := range checks {
+		detail, err := c.run(env)
+		results = append(results, doctorResult{name: c.name, critical: c.critical, detail: detail, err: err})
+	}
+	return results
+}
+
+// writeDoctorReport prints one line per result and reports whether any
+// critical check failed.
+func writeDoctorReport(w io.Writer, results []doctorResult) bool {
+	failed := false
+	for _, r := range results {
+		switch {
+		case r.err == nil:
+			fmt.Fprintf(w, "[PASS] %s: %s\n", r.name, r.detail)
+		case r.critical:
+			failed = true
+			fmt.Fprintf(w, "[FAIL] %s: %v\n", r.name, r.err)
+		default:
+			fmt.Fprintf(w, "[WARN] %s: %v\n", r.name, r.err)
+		}
+	}
+	return failed
+}
+
+// runDoctor executes the doctor command
+func runDoctor(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	results := runDoctorChecks(defaultDoctorEnv, doctorChecks())
+	if writeDoctorReport(cmd.OutOrStdout(), results) {
+		ExitWithErrorf(logger, "Environment is not ready for releases — fix the failed checks above")
+	}
+	logger.Info("Environment is ready for releases")
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubDoctorEnv returns an environment where every check passes
+func stubDoctorEnv() doctorEnv {
+	return doctorEnv{
+		lookPath: func(file string) (string, error) { return "/usr/bin/" + file, nil },
+		listIdentities: func() ([]string, error) {
+			return []string{
+				"Apple Development: dev@example.com (PERSONAL)",
+				"Developer ID Application: Jane Doe (TEAM123)",
+			}, nil
+		},
+		resolveToken: func() (string, string) { return "ghp_secret", "GITHUB_TOKEN environment variable" },
+	}
+}
+
+func TestDoctorAllPass(t *testing.T) {
+	var buf bytes.Buffer
+	failed := writeDoctorReport(&buf, runDoctorChecks(stubDoctorEnv(), doctorChecks()))
+	if failed {
+		t.Errorf("writeDoctorReport() reported failure:\n%s", buf.String())
+	}
+
+	out := buf.String()
+	if strings.Count(out, "[PASS]") != len(doctorChecks()) {
+		t.Errorf("report does not pass every check:\n%s", out)
+	}
+	if !strings.Contains(out, "Developer ID Application: Jane Doe (TEAM123)") {
+		t.Errorf("report does not name the Developer ID identity:\n%s", out)
+	}
+	if strings.Contains(out, "Apple Development") {
+		t.Errorf("report lists a non-Developer ID identity:\n%s", out)
+	}
+	if strings.Contains(out, "ghp_secret") {
+		t.Errorf("report leaks the GitHub token:\n%s", out)
+	}
+}
+
+func TestDoctorChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(env *doctorEnv)
+		wantFailed bool
+		wantLine   string
+	}{
+		{
+			name: "xcodebuild missing",
+			modify: func(env *doctorEnv) {
+				env.lookPath = func(file string) (string, error) {
+					if file == "xcodebuild" {
+						return "", errors.New("not found")
+					}
+					return "/usr/bin/" + file, nil
+				}
+			},
+			wantFailed: true,
+			wantLine:   "[FAIL] xcodebuild: xcodebuild not found on PATH",
+		},
+		{
+			name: "no Developer ID identity",
+			modify: func(env *doctorEnv) {
+				env.listIdentities = func() ([]string, error) {
+					return []string{"Apple Development: dev@example.com (PERSONAL)"}, nil
+				}
+			},
+			wantFailed: true,
+			wantLine:   "[FAIL] Developer ID identity: no Developer ID Application identity found (1 other",
+		},
+		{
+			name: "identities cannot be listed",
+			modify: func(env *doctorEnv) {
+				env.listIdentities = func() ([]string, error) {
+					return nil, errors.New("security command not found")
+				}
+			},
+			wantFailed: true,
+			wantLine:   "[FAIL] Developer ID identity: security command not found",
+		},
+		{
+			name: "no GitHub token is only a warning",
+			modify: func(env *doctorEnv) {
+				env.resolveToken = func() (string, string) { return "", "" }
+			},
+			wantFailed: false,
+			wantLine:   "[WARN] GitHub token: no token found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := stubDoctorEnv()
+			tt.modify(&env)
+
+			var buf bytes.Buffer
+			failed := writeDoctorReport(&buf, runDoctorChecks(env, doctorChecks()))
+			if failed != tt.wantFailed {
+				t.Errorf("writeDoctorReport() failed = %v, want %v", failed, tt.wantFailed)
+			}
+			if !strings.Contains(buf.String(), tt.wantLine) {
+				t.Errorf("report missing %q:\n%s", tt.wantLine, buf.String())
+			}
+		})
+	}
+}
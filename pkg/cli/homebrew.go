@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	homebrewpipe "github.com/macreleaser/macreleaser/internal/pipe/homebrew"
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/homebrew"
+	"github.com/spf13/cobra"
+)
+
+// homebrewCmd represents the homebrew command
+var homebrewCmd = &cobra.Command{
+	Use:   "homebrew [package...]",
+	Short: "Render the Homebrew cask for packages that are already built",
+	Long: `Render the Homebrew cask for the current version from packages built
+earlier, without building, releasing, or committing to a tap. Packages
+default to the .zip and .dmg files in dist/; the .app is looked up next to
+them. The cask is written to <token>.rb beside the packages, or printed to
+stdout with --print.`,
+	Run: runHomebrew,
+}
+
+// runHomebrew executes the homebrew command
+func runHomebrew(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	// Resolve package paths before config discovery may change directory
+	packages := make([]string, 0, len(args))
+	for _, arg := range args {
+		path, err := filepath.Abs(arg)
+		if err != nil {
+			ExitWithErrorf(logger, "Invalid path %s: %v", arg, err)
+		}
+		packages = append(packages, path)
+	}
+
+	cfg, err := config.LoadConfigProfile(findConfigPath(logger), GetProfile())
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to load configuration: %v", err)
+	}
+
+	ctx := macContext.NewContext(context.Background(), cfg, logger)
+	ctx.Version = requireGitVersion(logger)
+
+	data, content, err := renderLocalCask(ctx, "dist", packages)
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to render cask: %v", err)
+	}
+
+	if printOnly, _ := cmd.Flags().GetBool("print"); printOnly {
+		fmt.Fprint(cmd.OutOrStdout(), content)
+		return
+	}
+
+	path := filepath.Join(ctx.Artifacts.BuildOutputDir, data.Token+".rb")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		ExitWithErrorf(logger, "Failed to write cask file: %v", err)
+	}
+	logger.Infof("Generated cask file: %s", path)
+}
+
+// renderLocalCask points the context's artifacts at packages, or at the
+// packages in distDir when none are given, and at the .app next to them,
+// then renders the cask as the homebrew pipe would.
+func renderLocalCask(ctx *macContext.Context, distDir string, packages []string) (homebrew.CaskData, string, error) {
+	if len(packages) == 0 {
+		for _, pattern := range []string{"*.zip", "*.dmg"} {
+			matches, err := filepath.Glob(filepath.Join(distDir, pattern))
+			if err != nil {
+				return homebrew.CaskData{}, "", err
+			}
+			packages = append(packages, matches...)
+		}
+		if len(packages) == 0 {
+			return homebrew.CaskData{}, "", fmt.Errorf("no .zip or .dmg packages found in %s — run macreleaser build first or pass the package paths", distDir)
+		}
+	}
+	for _, path := range packages {
+		if _, err := os.Stat(path); err != nil {
+			return homebrew.CaskData{}, "", fmt.Errorf("cannot read package: %w", err)
+		}
+	}
+
+	dir := filepath.Dir(packages[0])
+	apps, err := filepath.Glob(filepath.Join(dir, "*.app"))
+	if err != nil {
+		return homebrew.CaskData{}, "", err
+	}
+	if len(apps) == 0 {
+		return homebrew.CaskData{}, "", fmt.Errorf("no .app found in %s — the cask installs the app by its bundle name", dir)
+	}
+
+	ctx.Artifacts.Packages = packages
+	ctx.Artifacts.AppPath = apps[0]
+	ctx.Artifacts.BuildOutputDir = dir
+	return homebrewpipe.RenderCask(ctx)
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/homebrew"
+	"github.com/sirupsen/logrus"
+)
+
+func newHomebrewContext() *macContext.Context {
+	cfg := &config.Config{
+		Project: config.ProjectConfig{Name: "MyApp"},
+		Release: config.ReleaseConfig{GitHub: config.GitHubConfig{Owner: "owner", Repo: "repo"}},
+		Homebrew: config.HomebrewConfig{Cask: config.CaskConfig{
+			Name:     "myapp",
+			Desc:     "My application",
+			Homepage: "https://example.com",
+		}},
+	}
+	ctx := macContext.NewContext(context.Background(), cfg, logrus.New())
+	ctx.Version = "v1.2.0"
+	return ctx
+}
+
+func TestRenderLocalCask(t *testing.T) {
+	dist := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dist, "MyApp.app"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	zipPath := filepath.Join(dist, "MyApp-v1.2.0.zip")
+	dmgPath := filepath.Join(dist, "MyApp-v1.2.0.dmg")
+	for _, path := range []string{zipPath, dmgPath} {
+		if err := os.WriteFile(path, []byte(filepath.Base(path)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, got, err := renderLocalCask(newHomebrewContext(), dist, nil)
+	if err != nil {
+		t.Fatalf("renderLocalCask() unexpected error: %v", err)
+	}
+
+	hash, err := homebrew.ComputeSHA256(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := homebrew.RenderCask(homebrew.CaskData{
+		Token:    "myapp",
+		Version:  "1.2.0",
+		SHA256:   hash,
+		URL:      homebrew.BuildAssetURL("owner", "repo", "v1.2.0", "MyApp-v1.2.0.zip"),
+		Name:     "MyApp",
+		Desc:     "My application",
+		Homepage: "https://example.com",
+		AppName:  "MyApp.app",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("renderLocalCask() cask =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderLocalCaskErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(dir string) []string
+		errMsg string
+	}{
+		{
+			name:   "no packages in dist",
+			setup:  func(dir string) []string { return nil },
+			errMsg: "no .zip or .dmg packages found",
+		},
+		{
+			name:   "missing package",
+			setup:  func(dir string) []string { return []string{filepath.Join(dir, "missing.zip")} },
+			errMsg: "cannot read package",
+		},
+		{
+			name: "no app next to the package",
+			setup: func(dir string) []string {
+				path := filepath.Join(dir, "MyApp.zip")
+				if err := os.WriteFile(path, []byte("zip"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return []string{path}
+			},
+			errMsg: "no .app found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			_, _, err := renderLocalCask(newHomebrewContext(), dir, tt.setup(dir))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("renderLocalCask() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/sign"
+	"github.com/spf13/cobra"
+)
+
+// identitiesCmd represents the identities command
+var identitiesCmd = &cobra.Command{
+	Use:   "identities",
+	Short: "List the code signing identities available for sign.identity",
+	Long: `List the valid code signing identities in the keychain search list, or in
+the keychain given with --keychain, as reported by
+security find-identity -v -p codesigning. Developer ID Application
+identities, the kind needed to distribute outside the Mac App Store, are
+marked with an asterisk.`,
+	Args: cobra.NoArgs,
+	Run:  runIdentities,
+}
+
+// developerIDPrefix starts the name of every Developer ID Application identity
+const developerIDPrefix = "Developer ID Application:"
+
+// writeIdentityList prints identities as a numbered list, marking Developer
+// ID Application entries. It returns an error if the list is empty.
+func writeIdentityList(w io.Writer, identities []string) error {
+	if len(identities) == 0 {
+		return fmt.Errorf("no valid signing identities are installed — create a Developer ID Application certificate at https://developer.apple.com/account/resources/certificates and import it into your keychain")
+	}
+
+	developerIDs := 0
+	for i, id := range identities {
+		marker := " "
+		if strings.HasPrefix(id, developerIDPrefix) {
+			marker = "*"
+			developerIDs++
+		}
+		fmt.Fprintf(w, "%s %2d) %s\n", marker, i+1, id)
+	}
+
+	fmt.Fprintln(w)
+	if developerIDs == 0 {
+		fmt.Fprintln(w, "None of these is a Developer ID Application identity, which is required to notarize and distribute outside the Mac App Store.")
+		return nil
+	}
+	fmt.Fprintln(w, "* Developer ID Application — set one of these as sign.identity")
+	return nil
+}
+
+// runIdentities executes the identities command
+func runIdentities(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	keychain, _ := cmd.Flags().GetString("keychain")
+	identities, err := sign.ListKeychainIdentities(context.Background(), keychain)
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to list signing identities: %v", err)
+	}
+	if err := writeIdentityList(cmd.OutOrStdout(), identities); err != nil {
+		ExitWithErrorf(logger, "%v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteIdentityList(t *testing.T) {
+	identities := []string{
+		"Apple Development: Jane Doe (ABCDE12345)",
+		"Developer ID Application: Jane Doe (TEAM123456)",
+	}
+
+	var out strings.Builder
+	if err := writeIdentityList(&out, identities); err != nil {
+		t.Fatalf("writeIdentityList() error = %v", err)
+	}
+
+	want := "   1) Apple Development: Jane Doe (ABCDE12345)\n" +
+		"*  2) Developer ID Application: Jane Doe (TEAM123456)\n" +
+		"\n" +
+		"* Developer ID Application — set one of these as sign.identity\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestWriteIdentityListWithoutDeveloperID(t *testing.T) {
+	var out strings.Builder
+	if err := writeIdentityList(&out, []string{"Apple Development: Jane Doe (ABCDE12345)"}); err != nil {
+		t.Fatalf("writeIdentityList() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "None of these is a Developer ID Application identity") {
+		t.Errorf("output should explain that no Developer ID identity exists:\n%s", out.String())
+	}
+}
+
+func TestWriteIdentityListEmpty(t *testing.T) {
+	var out strings.Builder
+	err := writeIdentityList(&out, nil)
+	if err == nil || !strings.Contains(err.Error(), "no valid signing identities are installed") {
+		t.Errorf("writeIdentityList() error = %v, want a no identities error", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want nothing printed", out.String())
+	}
+}
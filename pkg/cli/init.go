@@ -18,7 +18,7 @@ This file contains all the basic configuration sections with example values.`,
 
 // runInit executes the init command
 func runInit(cmd *cobra.Command, args []string) {
-	logger := SetupLogger(GetDebugMode())
+	logger := newCommandLogger()
 	configPath := ".macreleaser.yaml"
 
 	// Check if config file already exists
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/pipe"
+	"github.com/macreleaser/macreleaser/pkg/pipeline"
+	"github.com/spf13/cobra"
+)
+
+// notarizeCmd represents the notarize command
+var notarizeCmd = &cobra.Command{
+	Use:   "notarize <path-to-app-dmg-or-pkg>",
+	Short: "Notarize an already-built app, disk image, or installer",
+	Long: `Notarize, staple, and assess an existing signed .app, .dmg, or .pkg using
+the notarize credentials from the configuration. Build, archive, and release
+steps are not run.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runNotarize,
+}
+
+// notarizePipes is the pipe list run by the notarize command; replaced in tests.
+var notarizePipes = pipe.NotarizePipes
+
+// runNotarize executes the notarize command
+func runNotarize(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	// Resolve the target before config discovery may change directory
+	target, err := filepath.Abs(args[0])
+	if err != nil {
+		ExitWithErrorf(logger, "Invalid path %s: %v", args[0], err)
+	}
+
+	cfg, err := config.LoadConfigProfile(findConfigPath(logger), GetProfile())
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to load configuration: %v", err)
+	}
+
+	ctx := macContext.NewContext(context.Background(), cfg, logger)
+	if profile, _ := cmd.Flags().GetString("notarize-profile"); profile != "" {
+		withNotarizeProfile(profile)(ctx)
+	}
+
+	start := time.Now()
+	if err := notarizeArtifact(ctx, target); err != nil {
+		ExitWithErrorf(logger, "Notarize failed after %s: %v", time.Since(start).Round(time.Millisecond), err)
+	}
+
+	if GetShowTimings() {
+		printTimingSummary(ctx)
+	}
+	logger.Infof("Notarize succeeded after %s", time.Since(start).Round(time.Millisecond))
+}
+
+// notarizeArtifact points the context's artifacts at path and runs the
+// notarize pipes. A .app is notarized in place (its temporary ZIP is written
+// next to it); a .dmg or .pkg is submitted directly.
+func notarizeArtifact(ctx *macContext.Context, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("cannot notarize %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".app":
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not an app bundle directory", path)
+		}
+		ctx.Artifacts.AppPath = path
+		ctx.Artifacts.BuildOutputDir = filepath.Dir(path)
+	case ".dmg", ".pkg":
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", path)
+		}
+		ctx.Artifacts.Packages = []string{path}
+		ctx.Artifacts.BuildOutputDir = filepath.Dir(path)
+	default:
+		return fmt.Errorf("unsupported file %s: expected a .app, .dmg, or .pkg", path)
+	}
+
+	return pipeline.RunPipes(ctx, notarizePipes)
+}
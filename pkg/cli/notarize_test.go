@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/pipe"
+	"github.com/sirupsen/logrus"
+)
+
+// recordingPipe captures the artifacts it was run with.
+type recordingPipe struct {
+	ran       *bool
+	artifacts *macContext.Artifacts
+}
+
+func (recordingPipe) String() string { return "recording" }
+
+func (p recordingPipe) Run(ctx *macContext.Context) error {
+	*p.ran = true
+	*p.artifacts = *ctx.Artifacts
+	return nil
+}
+
+func TestNotarizeArtifact(t *testing.T) {
+	dir := t.TempDir()
+	appPath := filepath.Join(dir, "My App.app")
+	if err := os.MkdirAll(filepath.Join(appPath, "Contents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dmgPath := filepath.Join(dir, "MyApp-1.0.0.dmg")
+	if err := os.WriteFile(dmgPath, []byte("dmg"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	txtPath := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(txtPath, []byte("txt"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		wantAppPath  string
+		wantPackages []string
+		wantErr      string
+	}{
+		{name: "app bundle", path: appPath, wantAppPath: appPath},
+		{name: "disk image", path: dmgPath, wantPackages: []string{dmgPath}},
+		{name: "unsupported file", path: txtPath, wantErr: "expected a .app, .dmg, or .pkg"},
+		{name: "missing path", path: filepath.Join(dir, "Missing.app"), wantErr: "cannot notarize"},
+	}
+
+	orig := notarizePipes
+	t.Cleanup(func() { notarizePipes = orig })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran bool
+			var artifacts macContext.Artifacts
+			notarizePipes = []pipe.Piper{recordingPipe{ran: &ran, artifacts: &artifacts}}
+
+			logger := logrus.New()
+			logger.SetOutput(&strings.Builder{})
+			ctx := macContext.NewContext(context.Background(), &config.Config{}, logger)
+
+			err := notarizeArtifact(ctx, tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("notarizeArtifact() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				if ran {
+					t.Error("notarize pipes ran for an invalid path")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("notarizeArtifact() unexpected error: %v", err)
+			}
+			if !ran {
+				t.Fatal("notarize pipes did not run")
+			}
+			if artifacts.AppPath != tt.wantAppPath {
+				t.Errorf("AppPath = %q, want %q", artifacts.AppPath, tt.wantAppPath)
+			}
+			if strings.Join(artifacts.Packages, ",") != strings.Join(tt.wantPackages, ",") {
+				t.Errorf("Packages = %v, want %v", artifacts.Packages, tt.wantPackages)
+			}
+			if artifacts.BuildOutputDir != dir {
+				t.Errorf("BuildOutputDir = %q, want %q", artifacts.BuildOutputDir, dir)
+			}
+		})
+	}
+}
+
+func TestNotarizePipesRunOnlyNotarization(t *testing.T) {
+	for _, p := range pipe.NotarizePipes {
+		if !strings.Contains(p.String(), "notariz") {
+			t.Errorf("NotarizePipes contains non-notarization pipe %q", p.String())
+		}
+	}
+}
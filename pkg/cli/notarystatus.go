@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/macreleaser/macreleaser/pkg/notarize"
+	"github.com/spf13/cobra"
+)
+
+// notaryStatusCmd represents the notary-status command
+var notaryStatusCmd = &cobra.Command{
+	Use:   "notary-status <submission-id>",
+	Short: "Print the status of a notarization submission",
+	Long: `Look up a notarization submission with notarytool info, authenticating with a
+keychain profile saved by notarytool store-credentials, and print its status.
+No configuration file is needed. The developer log is printed too when Apple
+rejected the submission, or always with --log. The command exits non-zero
+for a rejected submission.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runNotaryStatus,
+}
+
+// submissionIDPattern matches a notarytool submission ID, which is a UUID
+var submissionIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// runNotaryStatus executes the notary-status command
+func runNotaryStatus(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	id := args[0]
+	if !submissionIDPattern.MatchString(id) {
+		ExitWithErrorf(logger, "Invalid submission ID %q: expected a UUID such as the id printed by notarytool submit", id)
+	}
+	profile, _ := cmd.Flags().GetString("keychain-profile")
+	if profile == "" {
+		ExitWithErrorf(logger, "--keychain-profile is required — save one with: xcrun notarytool store-credentials <profile>")
+	}
+	withLog, _ := cmd.Flags().GetBool("log")
+
+	creds := notarize.Credentials{KeychainProfile: profile}
+	status, err := notaryStatus(context.Background(), cmd.OutOrStdout(), id, creds, withLog)
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to get submission status: %v", err)
+	}
+	if status == notarize.StatusInvalid || status == notarize.StatusRejected {
+		ExitWithErrorf(logger, "Apple rejected submission %s — fix the issues above and submit again", id)
+	}
+}
+
+// notaryStatus prints the status of submission id to w, followed by its
+// developer log when withLog is set or Apple rejected it, and returns the
+// status.
+func notaryStatus(ctx context.Context, w io.Writer, id string, creds notarize.Credentials, withLog bool) (string, error) {
+	output, err := notarize.RunInfo(ctx, "", id, creds)
+	if err != nil {
+		return "", err
+	}
+	info := notarize.ParseInfo(output)
+	if info.Status == "" {
+		return "", fmt.Errorf("notarytool info reported no status: %s", output)
+	}
+
+	fmt.Fprintf(w, "Submission: %s\n", id)
+	if info.Name != "" {
+		fmt.Fprintf(w, "Name:       %s\n", info.Name)
+	}
+	if info.CreatedDate != "" {
+		fmt.Fprintf(w, "Created:    %s\n", info.CreatedDate)
+	}
+	fmt.Fprintf(w, "Status:     %s\n", info.Status)
+
+	rejected := info.Status == notarize.StatusInvalid || info.Status == notarize.StatusRejected
+	if !withLog && !rejected {
+		return info.Status, nil
+	}
+
+	// Apple only has a log once the submission has been processed
+	if info.Status == notarize.StatusInProgress {
+		fmt.Fprintln(w, "\nNo log yet — the submission is still in progress.")
+		return info.Status, nil
+	}
+	output, err = notarize.RunLog(ctx, "", id, creds)
+	if err != nil {
+		return "", err
+	}
+	log, err := notarize.ParseNotarizationLog(output)
+	if err != nil {
+		return "", err
+	}
+	writeNotarizationLog(w, log)
+	return info.Status, nil
+}
+
+// writeNotarizationLog prints the summary of a developer log and one line
+// per issue.
+func writeNotarizationLog(w io.Writer, log notarize.NotarizationLog) {
+	if log.StatusSummary != "" {
+		fmt.Fprintf(w, "\n%s\n", log.StatusSummary)
+	}
+	if len(log.Issues) == 0 {
+		fmt.Fprintln(w, "No issues reported.")
+		return
+	}
+	for _, issue := range log.Issues {
+		location := issue.Path
+		if issue.Architecture != "" {
+			location += " (" + issue.Architecture + ")"
+		}
+		fmt.Fprintf(w, "[%s] %s: %s\n", issue.Severity, location, issue.Message)
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+	"github.com/macreleaser/macreleaser/pkg/notarize"
+)
+
+const testNotaryID = "2efe2717-52ef-43a5-96dc-0797e4ca1041"
+
+// notaryOutputs answers notarytool info and log with canned output
+func notaryOutputs(status, log string) *command.Fake {
+	return &command.Fake{Handler: func(name string, args []string) (string, error) {
+		switch args[1] {
+		case "info":
+			return "Successfully received submission info\n  createdDate: 2026-10-16T09:00:00.000Z\n  id: " + testNotaryID + "\n  name: App.zip\n  status: " + status + "\n", nil
+		case "log":
+			return log, nil
+		}
+		return "", errors.New("unexpected command")
+	}}
+}
+
+const rejectedLog = `{
+  "jobId": "2efe2717-52ef-43a5-96dc-0797e4ca1041",
+  "status": "Invalid",
+  "statusSummary": "Archive contains critical validation errors",
+  "issues": [
+    {"severity": "error", "path": "App.zip/App.app/Contents/MacOS/App", "message": "The signature does not include a secure timestamp.", "architecture": "arm64"},
+    {"severity": "warning", "path": "App.zip/App.app", "message": "The executable requests the com.apple.security.get-task-allow entitlement."}
+  ]
+}`
+
+func TestNotaryStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    string
+		log       string
+		withLog   bool
+		want      string
+		wantCalls int
+	}{
+		{
+			name:      "accepted",
+			status:    notarize.StatusAccepted,
+			want:      "Status:     Accepted\n",
+			wantCalls: 1,
+		},
+		{
+			name:   "rejected prints the log",
+			status: notarize.StatusInvalid,
+			log:    rejectedLog,
+			want: "Status:     Invalid\n\nArchive contains critical validation errors\n" +
+				"[error] App.zip/App.app/Contents/MacOS/App (arm64): The signature does not include a secure timestamp.\n" +
+				"[warning] App.zip/App.app: The executable requests the com.apple.security.get-task-allow entitlement.\n",
+			wantCalls: 2,
+		},
+		{
+			name:      "accepted with log",
+			status:    notarize.StatusAccepted,
+			log:       `{"status": "Accepted", "statusSummary": "Ready for distribution", "issues": null}`,
+			withLog:   true,
+			want:      "Status:     Accepted\n\nReady for distribution\nNo issues reported.\n",
+			wantCalls: 2,
+		},
+		{
+			name:      "in progress has no log yet",
+			status:    notarize.StatusInProgress,
+			withLog:   true,
+			want:      "Status:     In Progress\n\nNo log yet — the submission is still in progress.\n",
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := notaryOutputs(tt.status, tt.log)
+			ctx := command.WithRunner(context.Background(), fake)
+
+			var buf bytes.Buffer
+			status, err := notaryStatus(ctx, &buf, testNotaryID, notarize.Credentials{KeychainProfile: "notary"}, tt.withLog)
+			if err != nil {
+				t.Fatalf("notaryStatus() error = %v", err)
+			}
+			if status != tt.status {
+				t.Errorf("notaryStatus() = %q, want %q", status, tt.status)
+			}
+
+			header := "Submission: " + testNotaryID + "\nName:       App.zip\nCreated:    2026-10-16T09:00:00.000Z\n"
+			if want := header + tt.want; buf.String() != want {
+				t.Errorf("output =\n%s\nwant\n%s", buf.String(), want)
+			}
+			if got := len(fake.Calls()); got != tt.wantCalls {
+				t.Errorf("ran %d commands, want %d", got, tt.wantCalls)
+			}
+			for _, call := range fake.Commands() {
+				if !strings.HasSuffix(call, "--keychain-profile notary") {
+					t.Errorf("command %q does not authenticate with the keychain profile", call)
+				}
+			}
+		})
+	}
+}
+
+func TestNotaryStatusNoStatus(t *testing.T) {
+	fake := &command.Fake{Handler: func(string, []string) (string, error) {
+		return "Successfully received submission info\n", nil
+	}}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	_, err := notaryStatus(ctx, &bytes.Buffer{}, testNotaryID, notarize.Credentials{KeychainProfile: "notary"}, false)
+	if err == nil || !strings.Contains(err.Error(), "reported no status") {
+		t.Errorf("notaryStatus() error = %v, want a missing status error", err)
+	}
+}
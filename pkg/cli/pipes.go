@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/pipe"
+	"github.com/macreleaser/macreleaser/pkg/pipeline"
+	"github.com/spf13/cobra"
+)
+
+// pipesCmd represents the pipes command
+var pipesCmd = &cobra.Command{
+	Use:   "pipes [check|build|release|snapshot|notarize]",
+	Short: "List the steps a command runs, in order",
+	Long: `List the validation and execution steps the given command runs, in
+order, marking the ones that the configuration or the --skip-notarize,
+--app-only, and --skip-validation flags skip. The command defaults to
+release. Nothing is run.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"check", "build", "release", "snapshot", "notarize"},
+	Run:       runPipes,
+}
+
+// pipeStage is a named group of pipes run together.
+type pipeStage struct {
+	name    string
+	pipes   []pipe.Piper
+	skipped string // reason the whole stage is skipped, if it is
+}
+
+// runPipes executes the pipes command
+func runPipes(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	command := "release"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	cfg, err := config.LoadConfigProfile(findConfigPath(logger), GetProfile())
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to load configuration: %v", err)
+	}
+	ctx := macContext.NewContext(context.Background(), cfg, logger)
+
+	var opts []pipelineOption
+	if skip, _ := cmd.Flags().GetBool("skip-notarize"); skip {
+		opts = append(opts, withSkipNotarize())
+	}
+	if appOnly, _ := cmd.Flags().GetBool("app-only"); appOnly {
+		opts = append(opts, withAppOnly())
+	}
+	if skip, _ := cmd.Flags().GetBool("skip-validation"); skip {
+		opts = append(opts, withSkipValidation())
+	}
+
+	stages, err := commandStages(ctx, command, opts...)
+	if err != nil {
+		ExitWithErrorf(logger, "%v", err)
+	}
+	writePipeList(cmd.OutOrStdout(), ctx, stages)
+}
+
+// commandStages applies the options the command sets on its context, along
+// with opts, and returns the stages it runs.
+func commandStages(ctx *macContext.Context, command string, opts ...pipelineOption) ([]pipeStage, error) {
+	switch command {
+	case "check":
+		return []pipeStage{{name: "Validation", pipes: pipe.ValidationPipes}}, nil
+	case "notarize":
+		return []pipeStage{{name: "Notarize", pipes: pipe.NotarizePipes}}, nil
+	case "build", "snapshot":
+		opts = append(opts, withSkipPublish())
+	case "release":
+	default:
+		return nil, fmt.Errorf("unknown command %q — expected check, build, release, snapshot, or notarize", command)
+	}
+
+	for _, opt := range opts {
+		opt(ctx)
+	}
+	validation := pipeStage{name: "Validation", pipes: pipe.ValidationPipes}
+	if ctx.SkipValidation {
+		validation.skipped = "skipped with --skip-validation"
+	}
+	return []pipeStage{validation, {name: "Execution", pipes: pipe.ExecutionPipes}}, nil
+}
+
+// writePipeList prints each stage and its numbered pipes, noting why a pipe
+// or stage is skipped.
+func writePipeList(w io.Writer, ctx *macContext.Context, stages []pipeStage) {
+	for i, stage := range stages {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if stage.skipped != "" {
+			fmt.Fprintf(w, "%s (%s):\n", stage.name, stage.skipped)
+		} else {
+			fmt.Fprintf(w, "%s:\n", stage.name)
+		}
+		for j, p := range stage.pipes {
+			line := fmt.Sprintf("  %d. %s", j+1, p.String())
+			if reason := pipeline.SkipReason(ctx, p); reason != "" && stage.skipped == "" {
+				line += " (skipped: " + reason + ")"
+			}
+			fmt.Fprintln(w, line)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/sirupsen/logrus"
+)
+
+func TestWritePipeList(t *testing.T) {
+	cfg := &config.Config{Announce: config.AnnounceConfig{Feed: config.FeedConfig{Path: "releases.xml"}}}
+	ctx := macContext.NewContext(context.Background(), cfg, logrus.New())
+
+	stages, err := commandStages(ctx, "build", withSkipNotarize())
+	if err != nil {
+		t.Fatalf("commandStages() unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	writePipeList(&buf, ctx, stages)
+
+	want := `Validation:
+  1. validating project configuration
+  2. validating build configuration
+  3. validating signing configuration
+  4. validating notarization configuration (skipped: notarization skipped via --skip-notarize)
+  5. validating archive configuration
+  6. validating changelog configuration
+  7. validating release configuration (skipped: publishing skipped)
+  8. validating homebrew configuration (skipped: homebrew publishing skipped)
+  9. validating announce configuration (skipped: publishing skipped)
+
+Execution:
+  1. building project
+  2. signing application
+  3. notarizing application (skipped: notarization skipped via --skip-notarize)
+  4. packaging archives
+  5. notarizing disk images (skipped: notarization skipped via --skip-notarize)
+  6. generating changelog
+  7. publishing GitHub release (skipped: publishing skipped)
+  8. generating Homebrew cask (skipped: homebrew publishing skipped)
+  9. writing release feed (skipped: publishing skipped)
+`
+	if got := buf.String(); got != want {
+		t.Errorf("writePipeList() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCommandStages(t *testing.T) {
+	tests := []struct {
+		command    string
+		opts       []pipelineOption
+		wantStages []string
+		errMsg     string
+	}{
+		{command: "check", wantStages: []string{"Validation"}},
+		{command: "release", wantStages: []string{"Validation", "Execution"}},
+		{command: "release", opts: []pipelineOption{withSkipValidation()}, wantStages: []string{"Validation (skipped with --skip-validation)", "Execution"}},
+		{command: "notarize", wantStages: []string{"Notarize"}},
+		{command: "publish", errMsg: `unknown command "publish"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			ctx := macContext.NewContext(context.Background(), &config.Config{}, logrus.New())
+			stages, err := commandStages(ctx, tt.command, tt.opts...)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("commandStages() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("commandStages() unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, stage := range stages {
+				name := stage.name
+				if stage.skipped != "" {
+					name += " (" + stage.skipped + ")"
+				}
+				got = append(got, name)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantStages, ",") {
+				t.Errorf("stages = %q, want %q", got, tt.wantStages)
+			}
+		})
+	}
+}
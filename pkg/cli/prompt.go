@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	homebrewpipe "github.com/macreleaser/macreleaser/internal/pipe/homebrew"
+	signpipe "github.com/macreleaser/macreleaser/internal/pipe/sign"
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/sign"
+	"github.com/mattn/go-isatty"
+)
+
+// isInteractive reports whether both stdin and stderr are terminals, so
+// prompts can be shown and answered. CI runs are never interactive.
+func isInteractive() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stderr.Fd())
+}
+
+// needsIdentity reports whether the run signs the app without a
+// sign.identity to sign it with. Runs that skip signing, such as
+// --app-only builds, need none.
+func needsIdentity(ctx *macContext.Context) bool {
+	return ctx.Config.Sign.Identity == "" && signpipe.CheckPipe{}.Skip(ctx) == ""
+}
+
+// resolveMissingIdentity offers an interactive picker when the run needs a
+// sign.identity and it is unset, on a local terminal run. The choice is
+// applied to the config and, if the user agrees, written back to the config
+// file. Non-interactive runs are left untouched so the signing check reports
+// the missing identity as usual.
+func resolveMissingIdentity(ctx *macContext.Context, configPath string) {
+	if !needsIdentity(ctx) || !isInteractive() {
+		return
+	}
+	cfg := ctx.Config
+	logger := ctx.Logger
+
+	identities, err := sign.ListKeychainIdentities(ctx.StdCtx, cfg.Sign.Keychain)
+	if err != nil {
+		logger.Warnf("Cannot offer identity picker: %v", err)
+		return
+	}
+	if len(identities) == 0 {
+		return
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	identity, err := promptIdentity(in, os.Stderr, identities)
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to select signing identity: %v", err)
+	}
+	cfg.Sign.Identity = identity
+	logger.Infof("Using signing identity: %s", identity)
+
+	save, err := promptYesNo(in, os.Stderr, fmt.Sprintf("Save this identity to %s?", configPath))
+	if err != nil || !save {
+		return
+	}
+
+	// Edit the file in place so its comments, formatting and env(...)
+	// references are kept
+	if err := config.SetString(configPath, "sign.identity", identity); err != nil {
+		logger.Warnf("Failed to save signing identity: %v", err)
+		return
+	}
+	logger.Infof("Saved sign.identity to %s", configPath)
+}
+
+// promptIdentity prints a numbered list of identities and reads the user's
+// choice. An empty answer selects the first identity.
+func promptIdentity(in *bufio.Reader, out io.Writer, identities []string) (string, error) {
+	fmt.Fprintln(out, "sign.identity is not set. Available signing identities:")
+	for i, id := range identities {
+		fmt.Fprintf(out, "  %d) %s\n", i+1, id)
+	}
+	fmt.Fprintf(out, "Select an identity [1-%d] (default 1): ", len(identities))
+
+	answer, err := readAnswer(in)
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return sign.SelectIdentity(identities, 1)
+	}
+
+	choice, err := strconv.Atoi(answer)
+	if err != nil {
+		return "", fmt.Errorf("invalid selection %q: enter a number", answer)
+	}
+	return sign.SelectIdentity(identities, choice)
+}
+
+// promptYesNo asks a yes/no question. Anything other than y/yes is a no.
+func promptYesNo(in *bufio.Reader, out io.Writer, question string) (bool, error) {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+
+	answer, err := readAnswer(in)
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// confirm asks before a destructive action such as deleting a release.
+// assumeYes (--yes) approves it without asking. Otherwise an interactive run
+// asks on out and reads the answer from in, and a non-interactive run is
+// refused since nobody can answer. A declined or refused action is an error.
+func confirm(in *bufio.Reader, out io.Writer, interactive, assumeYes bool, action string) error {
+	if assumeYes {
+		return nil
+	}
+	if !interactive {
+		return fmt.Errorf("refusing to %s without confirmation — pass --yes when not running in a terminal", action)
+	}
+
+	ok, err := promptYesNo(in, out, fmt.Sprintf("About to %s. Continue?", action))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("did not %s: cancelled", action)
+	}
+	return nil
+}
+
+// checkConfirmable refuses, before anything is built, a run that would stop
+// at a confirmation nobody can answer: one that pushes to a Homebrew tap or
+// replaces a rolling release, without a terminal and without --yes.
+func checkConfirmable(ctx *macContext.Context, interactive bool) error {
+	if ctx.ConfirmFunc == nil || ctx.AssumeYes || interactive || ctx.SkipPublish {
+		return nil
+	}
+	var actions []string
+	if tag := ctx.Config.Release.GitHub.RollingTag; tag != "" {
+		actions = append(actions, fmt.Sprintf("replace the %s release", tag))
+	}
+	if homebrewpipe.PushesToTap(ctx.Config.Homebrew) {
+		actions = append(actions, "push the cask to a Homebrew tap")
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	return fmt.Errorf("refusing to %s without confirmation — pass --yes when not running in a terminal", strings.Join(actions, " or "))
+}
+
+// readAnswer reads a single trimmed line. EOF after partial input is accepted.
+func readAnswer(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+)
+
+func TestPromptIdentity(t *testing.T) {
+	identities := []string{
+		"Developer ID Application: John Doe (TEAM123)",
+		"Developer ID Application: Other Co (OTHER99)",
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "explicit choice", input: "2\n", want: identities[1]},
+		{name: "default on empty answer", input: "\n", want: identities[0]},
+		{name: "answer without newline", input: "2", want: identities[1]},
+		{name: "out of range", input: "3\n", wantErr: true},
+		{name: "not a number", input: "abc\n", wantErr: true},
+		{name: "no input", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := promptIdentity(bufio.NewReader(strings.NewReader(tt.input)), &out, identities)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("promptIdentity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("promptIdentity() = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(out.String(), "  2) Developer ID Application: Other Co (OTHER99)") {
+				t.Errorf("prompt output missing numbered identity list:\n%s", out.String())
+			}
+		})
+	}
+}
+
+func TestPromptYesNo(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"maybe\n", false},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		got, err := promptYesNo(bufio.NewReader(strings.NewReader(tt.input)), &out, "Save?")
+		if err != nil {
+			t.Fatalf("promptYesNo(%q) unexpected error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Errorf("promptYesNo(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name        string
+		interactive bool
+		assumeYes   bool
+		input       string
+		wantErr     string
+		wantPrompt  bool
+	}{
+		{name: "yes flag in terminal", interactive: true, assumeYes: true},
+		{name: "yes flag without terminal", assumeYes: true},
+		{name: "no terminal requires yes flag", wantErr: "pass --yes"},
+		{name: "terminal answer yes", interactive: true, input: "y\n", wantPrompt: true},
+		{name: "terminal answer no", interactive: true, input: "n\n", wantErr: "cancelled", wantPrompt: true},
+		{name: "terminal empty answer", interactive: true, input: "\n", wantErr: "cancelled", wantPrompt: true},
+		{name: "terminal closed stdin", interactive: true, input: "", wantErr: "failed to read answer", wantPrompt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := confirm(bufio.NewReader(strings.NewReader(tt.input)), &out, tt.interactive, tt.assumeYes, "delete release v1.0.0")
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("confirm() unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("confirm() error = %v, want error containing %q", err, tt.wantErr)
+			}
+
+			prompted := strings.Contains(out.String(), "About to delete release v1.0.0. Continue? [y/N]")
+			if prompted != tt.wantPrompt {
+				t.Errorf("prompted = %v, want %v (output %q)", prompted, tt.wantPrompt, out.String())
+			}
+		})
+	}
+}
+
+func TestNeedsIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity string
+		appOnly  bool
+		want     bool
+	}{
+		{name: "unset identity", want: true},
+		{name: "identity set", identity: "Developer ID Application: John Doe (TEAM123)"},
+		{name: "app-only build does not sign", appOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Sign.Identity = tt.identity
+			ctx := macContext.NewContext(context.Background(), cfg, nil)
+			ctx.AppOnly = tt.appOnly
+
+			if got := needsIdentity(ctx); got != tt.want {
+				t.Errorf("needsIdentity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,12 +10,27 @@ var releaseCmd = &cobra.Command{
 	Short: "Full release process including Homebrew",
 	Long: `Run the complete release process.
 This will build, sign, notarize, package, and release your application
-to GitHub. Requires GITHUB_TOKEN environment variable for authentication.`,
+to GitHub. Requires a GitHub token, read from release.github.token,
+GITHUB_TOKEN, the gh CLI, or a git credential helper for github.com.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		var opts []pipelineOption
 		if clean, _ := cmd.Flags().GetBool("clean"); clean {
 			opts = append(opts, withClean())
 		}
+		if skip, _ := cmd.Flags().GetBool("skip-validation"); skip {
+			opts = append(opts, withSkipValidation())
+		}
+		if tag, _ := cmd.Flags().GetString("previous-tag"); tag != "" {
+			opts = append(opts, withPreviousTag(tag))
+		}
+		if profile, _ := cmd.Flags().GetString("notarize-profile"); profile != "" {
+			opts = append(opts, withNotarizeProfile(profile))
+		}
+		if cont, _ := cmd.Flags().GetBool("continue-on-error"); cont {
+			opts = append(opts, withContinueOnError())
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+		opts = append(opts, withConfirm(yes))
 		runPipelineCommand("Release", requireGitVersion, opts...)
 	},
 }
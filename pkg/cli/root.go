@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/macreleaser/macreleaser/pkg/config"
 	"github.com/macreleaser/macreleaser/pkg/version"
 	"github.com/spf13/cobra"
 )
@@ -35,8 +36,13 @@ func Execute() error {
 // registerCommands initializes flags and registers all subcommands
 func registerCommands() {
 	// Set up persistent flags
-	rootCmd.PersistentFlags().String("config", ".macreleaser.yaml", "config file path")
+	rootCmd.PersistentFlags().String("config", config.DefaultConfigFile, "config file path (when not set, searched for from the current directory up to the git root)")
 	rootCmd.PersistentFlags().Bool("debug", false, "enable debug mode")
+	rootCmd.PersistentFlags().String("color", "auto", "colorize log output: auto, always, or never (auto honors NO_COLOR)")
+	rootCmd.PersistentFlags().String("log-file", "", "also write a full debug log to this file")
+	rootCmd.PersistentFlags().Bool("timings", false, "show timestamps and per-step durations in log output")
+	rootCmd.PersistentFlags().Bool("quiet", false, "log only warnings and errors, and print just the artifact paths on success")
+	rootCmd.PersistentFlags().String("profile", "", "config profile to overlay from the profiles section (default: $MACRELEASER_PROFILE)")
 
 	// Add all subcommands
 	rootCmd.AddCommand(checkCmd)
@@ -44,12 +50,72 @@ func registerCommands() {
 	rootCmd.AddCommand(buildCmd)
 	rootCmd.AddCommand(releaseCmd)
 	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(notarizeCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(identitiesCmd)
+	rootCmd.AddCommand(homebrewCmd)
+	rootCmd.AddCommand(pipesCmd)
+	rootCmd.AddCommand(notaryStatusCmd)
+	configCmd.AddCommand(configShowCmd)
 
 	// --clean is available on build, release, and snapshot
 	buildCmd.Flags().Bool("clean", false, "remove dist/ before building")
 	releaseCmd.Flags().Bool("clean", false, "remove dist/ before building")
 	snapshotCmd.Flags().Bool("clean", false, "remove dist/ before building")
 
+	// --from, --to, and --since select the commits printed by changelog
+	changelogCmd.Flags().String("from", "", "exclusive start ref (default: the tag before --to)")
+	changelogCmd.Flags().String("to", "", "inclusive end ref and changelog heading (default: the latest tag)")
+	changelogCmd.Flags().Duration("since", 0, "list the commits made within this duration, such as 168h, instead of those after --from")
+
+	// --skip-validation is available on build, release, and snapshot
+	buildCmd.Flags().Bool("skip-validation", false, "skip configuration validation and go straight to execution (advanced; use with care)")
+	releaseCmd.Flags().Bool("skip-validation", false, "skip configuration validation and go straight to execution (advanced; use with care)")
+	snapshotCmd.Flags().Bool("skip-validation", false, "skip configuration validation and go straight to execution (advanced; use with care)")
+
+	// --dir and --port select what serve serves and where
+	serveCmd.Flags().String("dir", "dist", "directory to serve")
+	serveCmd.Flags().Int("port", 8000, "port to listen on (localhost only)")
+
+	// --previous-tag is available on build, release, and snapshot
+	buildCmd.Flags().String("previous-tag", "", "start the changelog after this tag instead of the detected previous tag")
+	releaseCmd.Flags().String("previous-tag", "", "start the changelog after this tag instead of the detected previous tag")
+	snapshotCmd.Flags().String("previous-tag", "", "start the changelog after this tag instead of the detected previous tag")
+
+	// --notarize-profile is available on build, release, snapshot, and notarize
+	buildCmd.Flags().String("notarize-profile", "", "notarize with the credentials of this notarize.profiles entry")
+	releaseCmd.Flags().String("notarize-profile", "", "notarize with the credentials of this notarize.profiles entry")
+	snapshotCmd.Flags().String("notarize-profile", "", "notarize with the credentials of this notarize.profiles entry")
+	notarizeCmd.Flags().String("notarize-profile", "", "notarize with the credentials of this notarize.profiles entry")
+
+	// --continue-on-error is release-only, since build and snapshot never publish
+	releaseCmd.Flags().Bool("continue-on-error", false, "keep going when the Homebrew or feed step fails after the GitHub release is published, and exit non-zero at the end")
+
+	// --yes is release-only, since build and snapshot never publish
+	releaseCmd.Flags().Bool("yes", false, "approve destructive actions, such as pushing to a Homebrew tap or replacing a rolling release, without asking (required when not running in a terminal)")
+
+	// --keychain limits identities to a single keychain file
+	identitiesCmd.Flags().String("keychain", "", "list the identities in this keychain file instead of the search list")
+
+	// pipes accepts the flags that change which pipes are skipped
+	pipesCmd.Flags().Bool("skip-notarize", false, "list the pipes as run with --skip-notarize")
+	pipesCmd.Flags().Bool("app-only", false, "list the pipes as run with --app-only")
+	pipesCmd.Flags().Bool("skip-validation", false, "list the pipes as run with --skip-validation")
+
+	// --print turns the homebrew command into a dry run for reviewing the cask
+	homebrewCmd.Flags().Bool("print", false, "print the cask to stdout instead of writing it")
+
+	// notary-status authenticates with a keychain profile instead of the config
+	notaryStatusCmd.Flags().String("keychain-profile", "", "notarytool keychain profile saved with store-credentials (required)")
+	notaryStatusCmd.Flags().Bool("log", false, "also print the developer log of an accepted submission")
+
+	// --app-only is build-only, for a quick unsigned .app during development
+	buildCmd.Flags().Bool("app-only", false, "only build and extract the .app, skipping signing, notarization, packaging, and the changelog")
+
 	// --skip-notarize is available on build and snapshot (not release)
 	buildCmd.Flags().Bool("skip-notarize", false, "skip notarization (for quick local pipeline validation)")
 	snapshotCmd.Flags().Bool("skip-notarize", false, "skip notarization (for quick local pipeline validation)")
@@ -61,8 +127,47 @@ func GetConfigPath() string {
 	return configPath
 }
 
+// IsConfigPathSet reports whether --config was given explicitly
+func IsConfigPathSet() bool {
+	return rootCmd.PersistentFlags().Changed("config")
+}
+
 // GetDebugMode returns debug mode flag value
 func GetDebugMode() bool {
 	debug, _ := rootCmd.PersistentFlags().GetBool("debug")
 	return debug
 }
+
+// GetColorMode returns the --color flag value
+func GetColorMode() string {
+	color, _ := rootCmd.PersistentFlags().GetString("color")
+	return color
+}
+
+// GetShowTimings returns the --timings flag value
+func GetShowTimings() bool {
+	timings, _ := rootCmd.PersistentFlags().GetBool("timings")
+	return timings
+}
+
+// GetQuiet returns the --quiet flag value
+func GetQuiet() bool {
+	quiet, _ := rootCmd.PersistentFlags().GetBool("quiet")
+	return quiet
+}
+
+// GetProfile returns the --profile flag value, falling back to the
+// MACRELEASER_PROFILE environment variable
+func GetProfile() string {
+	profile, _ := rootCmd.PersistentFlags().GetString("profile")
+	if profile == "" {
+		profile = os.Getenv("MACRELEASER_PROFILE")
+	}
+	return profile
+}
+
+// GetLogFile returns the --log-file flag value
+func GetLogFile() string {
+	path, _ := rootCmd.PersistentFlags().GetString("log-file")
+	return path
+}
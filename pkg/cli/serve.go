@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the build output directory over HTTP for local testing",
+	Long: `Serve a directory (dist/ by default) over HTTP on localhost, so a Sparkle
+appcast and its enclosures can be fetched by a local build of the app to
+smoke-test update detection. This is a development convenience and is not
+part of the release pipeline.`,
+	Args: cobra.NoArgs,
+	Run:  runServe,
+}
+
+// runServe executes the serve command
+func runServe(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	dir, _ := cmd.Flags().GetString("dir")
+	port, _ := cmd.Flags().GetInt("port")
+
+	handler, err := newServeHandler(logger, dir)
+	if err != nil {
+		ExitWithErrorf(logger, "Failed to serve %s: %v", dir, err)
+	}
+
+	addr := net.JoinHostPort("localhost", strconv.Itoa(port))
+	logger.Infof("Serving %s at http://%s/ (press Ctrl+C to stop)", dir, addr)
+	if err := http.ListenAndServe(addr, handler); err != nil {
+		ExitWithErrorf(logger, "Server failed: %v", err)
+	}
+}
+
+// newServeHandler returns a handler serving the files in dir and logging
+// each request. dir must be an existing directory.
+func newServeHandler(logger *logrus.Logger, dir string) (http.Handler, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	files := http.FileServer(http.Dir(dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.Infof("%s %s", r.Method, r.URL.Path)
+		files.ServeHTTP(w, r)
+	}), nil
+}
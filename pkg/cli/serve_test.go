@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestServeHandler(t *testing.T) {
+	dir := t.TempDir()
+	appcast := `<?xml version="1.0"?><rss version="2.0"><channel><title>MyApp</title></channel></rss>`
+	if err := os.WriteFile(filepath.Join(dir, "appcast.xml"), []byte(appcast), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	handler, err := newServeHandler(logrus.New(), dir)
+	if err != nil {
+		t.Fatalf("newServeHandler() unexpected error: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/appcast.xml")
+	if err != nil {
+		t.Fatalf("GET appcast.xml: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if string(body) != appcast {
+		t.Errorf("body = %q, want %q", body, appcast)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.Contains(ct, "xml") {
+		t.Errorf("Content-Type = %q, want an XML type", ct)
+	}
+}
+
+func TestServeHandlerMissingDir(t *testing.T) {
+	if _, err := newServeHandler(logrus.New(), filepath.Join(t.TempDir(), "dist")); err == nil {
+		t.Error("newServeHandler() expected error for a missing directory, got nil")
+	}
+}
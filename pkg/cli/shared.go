@@ -1,9 +1,12 @@
 package cli
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -12,27 +15,107 @@ import (
 	"github.com/macreleaser/macreleaser/pkg/git"
 	"github.com/macreleaser/macreleaser/pkg/logging"
 	"github.com/macreleaser/macreleaser/pkg/pipeline"
+	"github.com/mattn/go-isatty"
 	"github.com/sirupsen/logrus"
 )
 
-// SetupLogger creates and configures a logger based on debug mode
-func SetupLogger(debug bool) *logrus.Logger {
+// LoggerOptions controls how SetupLogger configures log output.
+type LoggerOptions struct {
+	Debug   bool // enable debug level with the text formatter
+	Color   bool // allow ANSI color codes; when false, output is ANSI-free
+	Timings bool // annotate action bullets with timestamps and step durations
+	Quiet   bool // log only warnings and errors; ignored with Debug
+
+	// LogFile, when non-nil, receives the full debug-level stream in plain
+	// text regardless of the console level.
+	LogFile io.Writer
+}
+
+// SetupLogger creates and configures a logger from the given options
+func SetupLogger(opts LoggerOptions) *logrus.Logger {
 	logger := logrus.New()
 
-	if debug {
+	if opts.Debug {
 		logger.SetLevel(logrus.DebugLevel)
 		logger.SetFormatter(&logrus.TextFormatter{
 			FullTimestamp: true,
-			ForceColors:   true,
+			ForceColors:   opts.Color,
+			DisableColors: !opts.Color,
 		})
 	} else {
 		logger.SetLevel(logrus.InfoLevel)
-		logger.SetFormatter(&logging.BulletFormatter{})
+		if opts.Quiet {
+			logger.SetLevel(logrus.WarnLevel)
+		}
+		logger.SetFormatter(&logging.BulletFormatter{
+			Colors:      opts.Color,
+			ShowTimings: opts.Timings,
+		})
+	}
+
+	if opts.LogFile != nil {
+		// Lower the logger to debug so the file sees everything, and filter
+		// the console back down to the level selected above
+		logger.SetFormatter(&logging.LevelFilterFormatter{
+			Formatter: logger.Formatter,
+			Level:     logger.GetLevel(),
+		})
+		logger.SetLevel(logrus.DebugLevel)
+		logger.AddHook(&logging.WriterHook{
+			Writer: opts.LogFile,
+			Formatter: &logrus.TextFormatter{
+				FullTimestamp: true,
+				DisableColors: true,
+			},
+		})
 	}
 
 	return logger
 }
 
+// newCommandLogger creates a logger configured from the persistent flags.
+// Exits with an error if the flags are invalid.
+func newCommandLogger() *logrus.Logger {
+	color, err := resolveColor(GetColorMode(), os.Getenv("NO_COLOR"), isatty.IsTerminal(os.Stderr.Fd()))
+	if err != nil {
+		ExitWithErrorNoLoggerf("%v", err)
+	}
+	opts := LoggerOptions{
+		Debug:   GetDebugMode(),
+		Color:   color,
+		Timings: GetShowTimings(),
+		Quiet:   GetQuiet(),
+	}
+
+	if path := GetLogFile(); path != "" {
+		// The file stays open for the life of the process; writes are
+		// unbuffered so nothing is lost when a command exits early.
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			ExitWithErrorNoLoggerf("failed to open log file %s: %v", path, err)
+		}
+		opts.LogFile = f
+	}
+
+	return SetupLogger(opts)
+}
+
+// resolveColor decides whether log output should be colorized.
+// "always" and "never" are absolute; "auto" colorizes only when stderr is a
+// terminal and NO_COLOR (https://no-color.org) is unset or empty.
+func resolveColor(mode, noColor string, isTerminal bool) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto", "":
+		return isTerminal && noColor == "", nil
+	default:
+		return false, fmt.Errorf("invalid value for --color: %q (must be auto, always, or never)", mode)
+	}
+}
+
 // ExitWithErrorf logs an error with the provided logger and exits with code 1
 func ExitWithErrorf(logger *logrus.Logger, format string, args ...interface{}) {
 	logger.Errorf(format, args...)
@@ -56,6 +139,14 @@ func withSkipPublish() pipelineOption {
 	}
 }
 
+// withSnapshot returns an option that sets Snapshot on the context, so
+// package names carry the short commit.
+func withSnapshot() pipelineOption {
+	return func(ctx *macContext.Context) {
+		ctx.Snapshot = true
+	}
+}
+
 // withSkipNotarize returns an option that sets SkipNotarize on the context,
 // skipping notarization and disabling hardened runtime during signing.
 func withSkipNotarize() pipelineOption {
@@ -64,6 +155,59 @@ func withSkipNotarize() pipelineOption {
 	}
 }
 
+// withAppOnly returns an option that sets AppOnly on the context,
+// stopping the pipeline once the .app is built.
+func withAppOnly() pipelineOption {
+	return func(ctx *macContext.Context) {
+		ctx.AppOnly = true
+	}
+}
+
+// withSkipValidation returns an option that sets SkipValidation on the
+// context, running the execution pipes without validating first.
+func withSkipValidation() pipelineOption {
+	return func(ctx *macContext.Context) {
+		ctx.SkipValidation = true
+	}
+}
+
+// withPreviousTag returns an option that overrides changelog.previous_tag,
+// starting the changelog after the given tag.
+func withPreviousTag(tag string) pipelineOption {
+	return func(ctx *macContext.Context) {
+		ctx.Config.Changelog.PreviousTag = tag
+	}
+}
+
+// withNotarizeProfile returns an option that overrides notarize.profile,
+// notarizing with the credentials of the named notarize.profiles entry.
+func withNotarizeProfile(name string) pipelineOption {
+	return func(ctx *macContext.Context) {
+		ctx.Config.Notarize.Profile = name
+	}
+}
+
+// withContinueOnError returns an option that sets ContinueOnError on the
+// context, so a failing Homebrew or feed step does not stop the release.
+func withContinueOnError() pipelineOption {
+	return func(ctx *macContext.Context) {
+		ctx.ContinueOnError = true
+	}
+}
+
+// withConfirm returns an option that asks before destructive actions, such
+// as pushing to a tap, on the terminal. assumeYes (--yes) approves them
+// without asking, and is required when not running in a terminal.
+func withConfirm(assumeYes bool) pipelineOption {
+	return func(ctx *macContext.Context) {
+		ctx.AssumeYes = assumeYes
+		in := bufio.NewReader(os.Stdin)
+		ctx.ConfirmFunc = func(action string) error {
+			return confirm(in, os.Stderr, isInteractive(), ctx.AssumeYes, action)
+		}
+	}
+}
+
 // withClean returns an option that sets Clean on the context,
 // causing dist/ to be removed before building.
 func withClean() pipelineOption {
@@ -72,21 +216,56 @@ func withClean() pipelineOption {
 	}
 }
 
+// findConfigPath returns the config file to load. An explicit --config is
+// used as-is. Otherwise the default file is searched for from the current
+// directory up to the git root, and when it is found in a parent directory
+// the working directory is changed to that directory so relative paths in
+// the config (dist/, workspace, extra files) resolve as they would there.
+func findConfigPath(logger *logrus.Logger) string {
+	return discoverConfigPath(logger, GetConfigPath(), IsConfigPathSet())
+}
+
+// discoverConfigPath implements findConfigPath for a given flag value.
+func discoverConfigPath(logger *logrus.Logger, configPath string, explicit bool) string {
+	if explicit {
+		return configPath
+	}
+
+	found, err := config.FindConfig(".", configPath)
+	if err != nil {
+		// Fall back to the default so loading reports the usual error
+		return configPath
+	}
+
+	dir := filepath.Dir(found)
+	if wd, err := os.Getwd(); err == nil && filepath.Clean(wd) == dir {
+		return configPath
+	}
+	if err := os.Chdir(dir); err != nil {
+		ExitWithErrorf(logger, "Failed to change to config directory %s: %v", dir, err)
+	}
+	logger.Infof("Using %s", found)
+	return found
+}
+
 // runPipelineCommand is the shared implementation for build, release, and snapshot.
 // resolveVersion returns the version string to use; commandName appears in error messages.
 func runPipelineCommand(commandName string, resolveVersion func(*logrus.Logger) string, opts ...pipelineOption) {
-	logger := SetupLogger(GetDebugMode())
-	configPath := GetConfigPath()
+	logger := newCommandLogger()
 
 	logger.WithField("action", "loading configuration").Info()
-	cfg, err := config.LoadConfig(configPath)
+	configPath := findConfigPath(logger)
+	cfg, err := config.LoadConfigProfile(configPath, GetProfile())
 	if err != nil {
 		ExitWithErrorf(logger, "Failed to load configuration: %v", err)
 	}
+	if profile := GetProfile(); profile != "" {
+		logger.Infof("Using profile %q", profile)
+	}
 
 	// Resolve git state
 	logger.WithField("action", "getting and validating git state").Info()
-	gitInfo, err := git.ResolveGitInfo()
+	gitInfo, err := git.ResolveGitInfo(context.Background())
 	if err != nil {
 		ExitWithErrorf(logger, "Failed to resolve git state: %v", err)
 	}
@@ -106,6 +285,11 @@ func runPipelineCommand(commandName string, resolveVersion func(*logrus.Logger)
 	for _, opt := range opts {
 		opt(ctx)
 	}
+	if err := checkConfirmable(ctx, isInteractive()); err != nil {
+		ExitWithErrorf(logger, "%s failed: %v", commandName, err)
+	}
+	// Options such as --app-only decide whether the run signs at all
+	resolveMissingIdentity(ctx, configPath)
 
 	// Clean dist/ if requested
 	if ctx.Clean {
@@ -121,7 +305,10 @@ func runPipelineCommand(commandName string, resolveVersion func(*logrus.Logger)
 	}
 	elapsed := time.Since(start)
 
-	printArtifactSummary(ctx)
+	printArtifactSummary(ctx, GetQuiet())
+	if GetShowTimings() {
+		printTimingSummary(ctx)
+	}
 	logger.Infof("%s succeeded after %s", strings.ToLower(commandName), formatDuration(elapsed))
 }
 
@@ -143,10 +330,17 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm%ds", m, s)
 }
 
-// printArtifactSummary prints a concise summary of produced artifacts.
-func printArtifactSummary(ctx *macContext.Context) {
+// printArtifactSummary prints a concise summary of produced artifacts. In
+// quiet mode only the artifact paths are printed, one per line on stdout,
+// for scripts to consume.
+func printArtifactSummary(ctx *macContext.Context, quiet bool) {
+	if quiet {
+		writeArtifactPaths(os.Stdout, ctx.Artifacts)
+		return
+	}
+
 	ctx.Logger.Info("---")
-	ctx.Logger.Infof("Build complete for %s %s", ctx.Config.Project.Name, ctx.Version)
+	ctx.Logger.Infof("Build complete for %s %s", ctx.Config.Project.Title(), ctx.Version)
 
 	if ctx.Artifacts.AppPath != "" {
 		ctx.Logger.Infof("  App: %s", ctx.Artifacts.AppPath)
@@ -168,6 +362,39 @@ func printArtifactSummary(ctx *macContext.Context) {
 		ctx.Logger.Infof("  Cask: %s", ctx.Artifacts.HomebrewCaskPath)
 	}
 
+	if ctx.Artifacts.FeedPath != "" {
+		ctx.Logger.Infof("  Feed: %s", ctx.Artifacts.FeedPath)
+	}
+
 	fmt.Println()
 	ctx.Logger.Infof("Artifacts in: %s", ctx.Artifacts.BuildOutputDir)
 }
+
+// writeArtifactPaths writes the path of each produced artifact to w, one
+// per line.
+func writeArtifactPaths(w io.Writer, a *macContext.Artifacts) {
+	paths := []string{a.AppPath}
+	paths = append(paths, a.Packages...)
+	paths = append(paths, a.ChangelogPath, a.HomebrewCaskPath, a.FeedPath)
+	for _, path := range paths {
+		if path != "" {
+			_, _ = fmt.Fprintln(w, path)
+		}
+	}
+}
+
+// printTimingSummary prints how long each pipe took, for profiling slow releases.
+func printTimingSummary(ctx *macContext.Context) {
+	ctx.Logger.Info("Step timings:")
+	for _, t := range ctx.Timings {
+		if t.Skipped {
+			ctx.Logger.Infof("  %s: skipped", t.Name)
+			continue
+		}
+		if t.Failed {
+			ctx.Logger.Infof("  %s: failed after %s", t.Name, formatDuration(t.Duration))
+			continue
+		}
+		ctx.Logger.Infof("  %s: %s", t.Name, formatDuration(t.Duration))
+	}
+}
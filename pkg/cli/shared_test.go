@@ -1,8 +1,17 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/config"
+	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/pipeline"
 )
 
 func TestFormatDuration(t *testing.T) {
@@ -31,3 +40,206 @@ func TestFormatDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveColor(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		noColor    string
+		isTerminal bool
+		want       bool
+		wantErr    bool
+	}{
+		{name: "auto on terminal", mode: "auto", isTerminal: true, want: true},
+		{name: "auto off terminal", mode: "auto", isTerminal: false, want: false},
+		{name: "auto with NO_COLOR", mode: "auto", noColor: "1", isTerminal: true, want: false},
+		{name: "empty mode behaves as auto", mode: "", isTerminal: true, want: true},
+		{name: "always overrides NO_COLOR", mode: "always", noColor: "1", isTerminal: false, want: true},
+		{name: "never on terminal", mode: "never", isTerminal: true, want: false},
+		{name: "invalid mode", mode: "sometimes", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveColor(tt.mode, tt.noColor, tt.isTerminal)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveColor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolveColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetupLoggerNoColor(t *testing.T) {
+	for _, debug := range []bool{false, true} {
+		var buf bytes.Buffer
+		logger := SetupLogger(LoggerOptions{Debug: debug})
+		logger.SetOutput(&buf)
+
+		logger.WithField("action", "building project").Info()
+		logger.Warn("some warning")
+		logger.Error("build failed")
+
+		if strings.Contains(buf.String(), "\x1b[") {
+			t.Errorf("SetupLogger(Debug: %v) output contains ANSI escapes: %q", debug, buf.String())
+		}
+	}
+}
+
+func TestSetupLoggerLogFile(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "macreleaser.log")
+	f, err := os.Create(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var console bytes.Buffer
+	logger := SetupLogger(LoggerOptions{Color: true, LogFile: f})
+	logger.SetOutput(&console)
+
+	cfg := &config.Config{
+		Project: config.ProjectConfig{Name: "MyApp", Scheme: "MyApp"},
+		Build:   config.BuildConfig{Configuration: "Release"},
+		Sign:    config.SignConfig{Identity: "Developer ID Application: Test (TEAM123)"},
+		Archive: config.ArchiveConfig{Formats: config.ArchiveFormats{{Type: "zip"}}},
+	}
+	ctx := macContext.NewContext(context.Background(), cfg, logger)
+	ctx.SkipPublish = true
+	ctx.SkipNotarize = true
+
+	if err := pipeline.RunValidation(ctx); err != nil {
+		t.Fatalf("RunValidation() error = %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileLog := string(data)
+
+	for _, want := range []string{
+		"validating project configuration",
+		"level=debug msg=\"Project configuration validated successfully\"",
+		"level=warning msg=\"skipped: notarization skipped via --skip-notarize\"",
+	} {
+		if !strings.Contains(fileLog, want) {
+			t.Errorf("log file missing %q\nlog file:\n%s", want, fileLog)
+		}
+	}
+	if strings.Contains(fileLog, "\x1b[") {
+		t.Errorf("log file contains ANSI escapes:\n%s", fileLog)
+	}
+
+	// The console stays at info level
+	if strings.Contains(console.String(), "validated successfully") {
+		t.Errorf("console output contains debug entries:\n%s", console.String())
+	}
+	if !strings.Contains(console.String(), "validating project configuration") {
+		t.Errorf("console output missing action entry:\n%s", console.String())
+	}
+}
+
+func TestPrintArtifactSummaryQuiet(t *testing.T) {
+	var logs bytes.Buffer
+	logger := SetupLogger(LoggerOptions{Quiet: true})
+	logger.SetOutput(&logs)
+
+	ctx := macContext.NewContext(context.Background(), &config.Config{Project: config.ProjectConfig{Name: "MyApp"}}, logger)
+	ctx.Version = "v1.2.3"
+	ctx.Artifacts.BuildOutputDir = "dist"
+	ctx.Artifacts.AppPath = "dist/MyApp.app"
+	ctx.Artifacts.Packages = []string{"dist/MyApp-v1.2.3.zip", "dist/MyApp-v1.2.3.dmg"}
+	ctx.Artifacts.ReleaseURL = "https://github.com/owner/repo/releases/tag/v1.2.3"
+	ctx.Artifacts.HomebrewCaskPath = "dist/myapp.rb"
+
+	// Capture stdout, where the paths are printed
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	printArtifactSummary(ctx, true)
+	os.Stdout = stdout
+	_ = w.Close()
+	var out bytes.Buffer
+	if _, err := out.ReadFrom(r); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "dist/MyApp.app\ndist/MyApp-v1.2.3.zip\ndist/MyApp-v1.2.3.dmg\ndist/myapp.rb\n"
+	if out.String() != want {
+		t.Errorf("stdout = %q, want %q", out.String(), want)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("quiet mode logged the summary: %q", logs.String())
+	}
+
+	logger.Warn("some warning")
+	if !strings.Contains(logs.String(), "some warning") {
+		t.Error("quiet mode suppressed a warning")
+	}
+}
+
+func TestWithConfirm(t *testing.T) {
+	if isInteractive() {
+		t.Skip("stdin is a terminal")
+	}
+
+	// Without a terminal only --yes approves
+	ctx := macContext.NewContext(context.Background(), &config.Config{}, nil)
+	withConfirm(true)(ctx)
+	if err := ctx.Confirm("push the cask"); err != nil {
+		t.Errorf("Confirm() with --yes unexpected error: %v", err)
+	}
+
+	withConfirm(false)(ctx)
+	if err := ctx.Confirm("push the cask"); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("Confirm() without --yes error = %v, want a refusal mentioning --yes", err)
+	}
+}
+
+func TestCheckConfirmable(t *testing.T) {
+	tap := config.HomebrewConfig{Tap: config.TapConfig{Owner: "owner", Name: "homebrew-tap", Token: "token"}}
+
+	tests := []struct {
+		name        string
+		homebrew    config.HomebrewConfig
+		rollingTag  string
+		assumeYes   bool
+		interactive bool
+		noConfirm   bool
+		wantErr     string
+	}{
+		{name: "tap without terminal or --yes", homebrew: tap, wantErr: "push the cask to a Homebrew tap"},
+		{name: "extra tap without terminal or --yes", homebrew: config.HomebrewConfig{Taps: []config.TapConfig{tap.Tap}}, wantErr: "pass --yes"},
+		{name: "rolling tag without terminal or --yes", rollingTag: "nightly", wantErr: "replace the nightly release"},
+		{name: "tap with --yes", homebrew: tap, assumeYes: true},
+		{name: "tap in a terminal", homebrew: tap, interactive: true},
+		{name: "nothing to confirm"},
+		{name: "command that never confirms", homebrew: tap, noConfirm: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Homebrew: tt.homebrew}
+			cfg.Release.GitHub.RollingTag = tt.rollingTag
+			ctx := macContext.NewContext(context.Background(), cfg, nil)
+			if !tt.noConfirm {
+				withConfirm(tt.assumeYes)(ctx)
+			}
+
+			err := checkConfirmable(ctx, tt.interactive)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkConfirmable() unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkConfirmable() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/macreleaser/macreleaser/pkg/git"
@@ -16,10 +17,19 @@ var snapshotCmd = &cobra.Command{
 This allows you to test the release process without affecting
 versioned releases. If no git tags exist, a snapshot version is generated.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		opts := []pipelineOption{withSkipPublish()}
+		opts := []pipelineOption{withSkipPublish(), withSnapshot()}
 		if clean, _ := cmd.Flags().GetBool("clean"); clean {
 			opts = append(opts, withClean())
 		}
+		if skip, _ := cmd.Flags().GetBool("skip-validation"); skip {
+			opts = append(opts, withSkipValidation())
+		}
+		if tag, _ := cmd.Flags().GetString("previous-tag"); tag != "" {
+			opts = append(opts, withPreviousTag(tag))
+		}
+		if profile, _ := cmd.Flags().GetString("notarize-profile"); profile != "" {
+			opts = append(opts, withNotarizeProfile(profile))
+		}
 		if skip, _ := cmd.Flags().GetBool("skip-notarize"); skip {
 			opts = append(opts, withSkipNotarize())
 		}
@@ -30,12 +40,12 @@ versioned releases. If no git tags exist, a snapshot version is generated.`,
 // snapshotVersion resolves a snapshot version in goreleaser-style format:
 // <tag>-SNAPSHOT-<shortcommit> or 0.0.0-SNAPSHOT-<shortcommit> when no tags exist.
 func snapshotVersion(logger *logrus.Logger) string {
-	short, err := git.ShortCommit()
+	short, err := git.ShortCommit(context.Background())
 	if err != nil {
 		ExitWithErrorf(logger, "Failed to resolve git commit: %v", err)
 	}
 
-	tag, tagErr := git.ResolveVersion()
+	tag, tagErr := git.ResolveVersion(context.Background())
 	if tagErr != nil {
 		tag = "0.0.0"
 	}
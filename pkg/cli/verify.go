@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/notarize"
+	"github.com/macreleaser/macreleaser/pkg/sign"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify <path-to-app-dmg-or-pkg>",
+	Short: "Check that a finished app, disk image, or installer is ready to ship",
+	Long: `Verify a finished .app, .dmg, or .pkg end to end: its code signature
+(codesign --verify --deep --strict), its Gatekeeper assessment (spctl), and
+its stapled notarization ticket (stapler validate). Every check is run and
+reported; the command exits non-zero if any of them fails.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runVerify,
+}
+
+// verifyCheck is a single check run against the artifact
+type verifyCheck struct {
+	name string
+	run  func(ctx context.Context, path string) (string, error)
+}
+
+// verifyResult is the outcome of a verifyCheck
+type verifyResult struct {
+	name   string
+	detail string
+	err    error
+}
+
+// verifyChecks returns the checks run against the artifact at path, in
+// report order. Installer packages are signed with productsign rather than
+// codesign, so the code signature check is left to spctl for them.
+func verifyChecks(path string) []verifyCheck {
+	var checks []verifyCheck
+	if notarize.AssessTypeFor(path) != notarize.AssessInstall {
+		checks = append(checks, verifyCheck{name: "code signature", run: checkCodeSignature})
+	}
+	return append(checks,
+		verifyCheck{name: "Gatekeeper", run: checkGatekeeper},
+		verifyCheck{name: "stapled ticket", run: checkStapledTicket},
+	)
+}
+
+// checkCodeSignature verifies the signature with codesign
+func checkCodeSignature(ctx context.Context, path string) (string, error) {
+	if _, err := sign.RunVerify(ctx, path); err != nil {
+		return "", err
+	}
+	return "valid", nil
+}
+
+// checkGatekeeper assesses the artifact with spctl and reports the source
+// Gatekeeper accepted it from, e.g. "Notarized Developer ID".
+func checkGatekeeper(ctx context.Context, path string) (string, error) {
+	assessType := notarize.AssessTypeFor(path)
+	output, err := notarize.RunAssess(ctx, path, assessType)
+	if err != nil {
+		return "", err
+	}
+	detail := fmt.Sprintf("accepted for %s", assessType)
+	for _, line := range strings.Split(output, "\n") {
+		if source, ok := strings.CutPrefix(strings.TrimSpace(line), "source="); ok {
+			return detail + " from " + source, nil
+		}
+	}
+	return detail, nil
+}
+
+// checkStapledTicket validates the stapled notarization ticket
+func checkStapledTicket(ctx context.Context, path string) (string, error) {
+	if _, err := notarize.StaplerValidate(ctx, path); err != nil {
+		return "", err
+	}
+	return "present", nil
+}
+
+// runVerifyChecks runs every check against path
+func runVerifyChecks(ctx context.Context, path string, checks []verifyCheck) []verifyResult {
+	results := make([]verifyResult, 0, len(checks))
+	for _, c := range checks {
+		detail, err := c.run(ctx, path)
+		results = append(results, verifyResult{name: c.name, detail: detail, err: err})
+	}
+	return results
+}
+
+// writeVerifyReport prints one line per result and reports whether any
+// check failed.
+func writeVerifyReport(w io.Writer, results []verifyResult) bool {
+	failed := false
+	for _, r := range results {
+		if r.err != nil {
+			failed = true
+			fmt.Fprintf(w, "[FAIL] %s: %v\n", r.name, r.err)
+			continue
+		}
+		fmt.Fprintf(w, "[PASS] %s: %s\n", r.name, r.detail)
+	}
+	return failed
+}
+
+// runVerify executes the verify command
+func runVerify(cmd *cobra.Command, args []string) {
+	logger := newCommandLogger()
+
+	path, err := filepath.Abs(args[0])
+	if err != nil {
+		ExitWithErrorf(logger, "Invalid path %s: %v", args[0], err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		ExitWithErrorf(logger, "Cannot verify %s: %v", args[0], err)
+	}
+
+	results := runVerifyChecks(context.Background(), path, verifyChecks(path))
+	if writeVerifyReport(cmd.OutOrStdout(), results) {
+		ExitWithErrorf(logger, "%s is not ready to ship — fix the failed checks above", filepath.Base(path))
+	}
+	logger.Infof("%s passed verification", filepath.Base(path))
+}
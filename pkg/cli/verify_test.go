@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// verifyOutputs answers the tools run by verify with canned output, keyed by
+// the tool and its first argument
+type verifyOutputs map[string]toolOutput
+
+// toolOutput is the combined output and error a tool returns
+type toolOutput struct {
+	output string
+	err    error
+}
+
+func (o verifyOutputs) fake() *command.Fake {
+	return &command.Fake{Handler: func(name string, args []string) (string, error) {
+		r := o[name+" "+args[0]]
+		return r.output, r.err
+	}}
+}
+
+// passingOutputs returns tool output for a signed, notarized, and stapled
+// disk image
+func passingOutputs() verifyOutputs {
+	return verifyOutputs{
+		"codesign --verify": {},
+		"spctl --assess":    {output: "/tmp/MyApp.dmg: accepted\nsource=Notarized Developer ID\n"},
+		"xcrun stapler":     {output: "Processing: /tmp/MyApp.dmg\nThe validate action worked!\n"},
+	}
+}
+
+func TestVerifyAllPass(t *testing.T) {
+	fake := passingOutputs().fake()
+	ctx := command.WithRunner(context.Background(), fake)
+
+	var buf bytes.Buffer
+	if writeVerifyReport(&buf, runVerifyChecks(ctx, "/tmp/MyApp.dmg", verifyChecks("/tmp/MyApp.dmg"))) {
+		t.Errorf("writeVerifyReport() reported failure:\n%s", buf.String())
+	}
+
+	want := "[PASS] code signature: valid\n" +
+		"[PASS] Gatekeeper: accepted for open from Notarized Developer ID\n" +
+		"[PASS] stapled ticket: present\n"
+	if buf.String() != want {
+		t.Errorf("report =\n%s\nwant\n%s", buf.String(), want)
+	}
+	if got := len(fake.Calls()); got != 3 {
+		t.Errorf("ran %d commands, want 3", got)
+	}
+}
+
+func TestVerifyChecks(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	tests := []struct {
+		name     string
+		path     string
+		outputs  func(o verifyOutputs)
+		wantLine string
+	}{
+		{
+			name: "modified bundle",
+			path: "/tmp/MyApp.app",
+			outputs: func(o verifyOutputs) {
+				o["codesign --verify"] = toolOutput{"/tmp/MyApp.app: a sealed resource is missing or invalid\n", exitErr}
+			},
+			wantLine: "[FAIL] code signature: signature verification failed",
+		},
+		{
+			name: "unnotarized disk image",
+			path: "/tmp/MyApp.dmg",
+			outputs: func(o verifyOutputs) {
+				o["spctl --assess"] = toolOutput{"/tmp/MyApp.dmg: rejected\nsource=Unnotarized Developer ID\n", exitErr}
+			},
+			wantLine: "[FAIL] Gatekeeper: Gatekeeper rejected the DMG: it is signed but not notarized",
+		},
+		{
+			name: "rejected app",
+			path: "/tmp/MyApp.app",
+			outputs: func(o verifyOutputs) {
+				o["spctl --assess"] = toolOutput{"/tmp/MyApp.app: rejected\n", exitErr}
+			},
+			wantLine: "[FAIL] Gatekeeper: Gatekeeper rejected the app",
+		},
+		{
+			name: "missing ticket",
+			path: "/tmp/MyApp.pkg",
+			outputs: func(o verifyOutputs) {
+				o["xcrun stapler"] = toolOutput{"Processing: /tmp/MyApp.pkg\nMyApp.pkg does not have a ticket stapled to it.\n", errors.New("exit status 65")}
+			},
+			wantLine: "[FAIL] stapled ticket: no notarization ticket is stapled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs := passingOutputs()
+			tt.outputs(outputs)
+			ctx := command.WithRunner(context.Background(), outputs.fake())
+
+			var buf bytes.Buffer
+			if !writeVerifyReport(&buf, runVerifyChecks(ctx, tt.path, verifyChecks(tt.path))) {
+				t.Errorf("writeVerifyReport() did not report failure:\n%s", buf.String())
+			}
+			if !strings.Contains(buf.String(), tt.wantLine) {
+				t.Errorf("report does not contain %q:\n%s", tt.wantLine, buf.String())
+			}
+			// Every check is reported even after one fails
+			if got := strings.Count(buf.String(), "[PASS]") + strings.Count(buf.String(), "[FAIL]"); got != len(verifyChecks(tt.path)) {
+				t.Errorf("report has %d results, want %d:\n%s", got, len(verifyChecks(tt.path)), buf.String())
+			}
+		})
+	}
+}
+
+func TestVerifyChecksSkipCodesignForPkg(t *testing.T) {
+	for _, c := range verifyChecks("/tmp/MyApp.pkg") {
+		if c.name == "code signature" {
+			t.Error("verifyChecks() runs codesign on an installer package")
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Package command runs external tools such as xcodebuild, codesign, and
+// notarytool behind the Runner interface. The Runner travels on the
+// context.Context, so the pipes that use the tools can be tested with a Fake
+// instead of the real tools.
+package command
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Runner runs external commands.
+type Runner interface {
+	// Run runs name with args and returns its combined stdout and stderr
+	// output.
+	Run(ctx context.Context, name string, args ...string) (string, error)
+
+	// Output runs name with args, writing stdin to it, and returns its
+	// stdout alone, for commands whose output is parsed, such as git. When
+	// the command fails, the error includes what it wrote to stderr.
+	Output(ctx context.Context, stdin, name string, args ...string) (string, error)
+}
+
+// runnerKey is the context key of the Runner set by WithRunner
+type runnerKey struct{}
+
+// WithRunner returns a copy of ctx that carries r.
+func WithRunner(ctx context.Context, r Runner) context.Context {
+	return context.WithValue(ctx, runnerKey{}, r)
+}
+
+// FromContext returns the Runner carried by ctx, or Exec when there is none.
+func FromContext(ctx context.Context) Runner {
+	if r, ok := ctx.Value(runnerKey{}).(Runner); ok {
+		return r
+	}
+	return Exec{}
+}
+
+// Run runs name with args using the Runner carried by ctx.
+func Run(ctx context.Context, name string, args ...string) (string, error) {
+	return FromContext(ctx).Run(ctx, name, args...)
+}
+
+// Output runs name with args using the Runner carried by ctx, writing stdin
+// to it, and returns its stdout.
+func Output(ctx context.Context, stdin, name string, args ...string) (string, error) {
+	return FromContext(ctx).Output(ctx, stdin, name, args...)
+}
+
+// LookPath returns the path of name on PATH, or a *NotFoundError when it is
+// not installed.
+func LookPath(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", &NotFoundError{Name: name}
+	}
+	return path, nil
+}
+
+// NotFoundError is returned by Exec when the command is not on PATH.
+type NotFoundError struct {
+	Name string
+}
+
+func (e *NotFoundError) Error() string { return e.Name + " not found in PATH" }
+
+// IsNotFound reports whether err means the command is not installed.
+func IsNotFound(err error) bool {
+	var nf *NotFoundError
+	return errors.As(err, &nf)
+}
+
+// Exec is the Runner that executes commands with os/exec.
+type Exec struct{}
+
+// Run executes name with args, returning a *NotFoundError without running
+// anything when name is not on PATH.
+func (Exec) Run(ctx context.Context, name string, args ...string) (string, error) {
+	if _, err := LookPath(name); err != nil {
+		return "", err
+	}
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	return string(out), err
+}
+
+// Output executes name with args like Run, but returns stdout alone. Git is
+// not allowed to prompt on the terminal, so a missing credential helper
+// fails instead of blocking.
+func (Exec) Output(ctx context.Context, stdin, name string, args ...string) (string, error) {
+	if _, err := LookPath(name); err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%w: %s", err, msg)
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExecNotFound(t *testing.T) {
+	_, err := Exec{}.Run(context.Background(), "macreleaser-no-such-tool")
+	if !IsNotFound(err) {
+		t.Errorf("Run() error = %v, want a NotFoundError", err)
+	}
+}
+
+func TestExecRun(t *testing.T) {
+	out, err := Exec{}.Run(context.Background(), "sh", "-c", "echo out; echo err >&2")
+	if IsNotFound(err) {
+		t.Skip("Skipping: sh not available")
+	}
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "out") || !strings.Contains(out, "err") {
+		t.Errorf("Run() output = %q, want combined stdout and stderr", out)
+	}
+}
+
+func TestExecOutput(t *testing.T) {
+	out, err := Exec{}.Output(context.Background(), "in\n", "sh", "-c", "cat; echo err >&2")
+	if IsNotFound(err) {
+		t.Skip("Skipping: sh not available")
+	}
+	if err != nil {
+		t.Fatalf("Output() unexpected error: %v", err)
+	}
+	if out != "in\n" {
+		t.Errorf("Output() = %q, want stdin echoed on stdout alone", out)
+	}
+
+	_, err = Exec{}.Output(context.Background(), "", "sh", "-c", "echo broken >&2; exit 3")
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Output() error = %v, want it to include stderr", err)
+	}
+}
+
+func TestFake(t *testing.T) {
+	failure := errors.New("exit status 1")
+	fake := &Fake{Handler: func(name string, args []string) (string, error) {
+		if name == "codesign" {
+			return "rejected", failure
+		}
+		return "ok", nil
+	}}
+
+	if out, err := fake.Run(context.Background(), "xcrun", "stapler", "staple", "MyApp.app"); out != "ok" || err != nil {
+		t.Errorf("Run(xcrun) = %q, %v, want ok, nil", out, err)
+	}
+	if _, err := fake.Run(context.Background(), "codesign", "--verify", "MyApp.app"); !errors.Is(err, failure) {
+		t.Errorf("Run(codesign) error = %v, want %v", err, failure)
+	}
+
+	if _, err := fake.Output(context.Background(), "host=github.com\n", "git", "credential", "fill"); err != nil {
+		t.Errorf("Output(git) unexpected error: %v", err)
+	}
+
+	want := "xcrun stapler staple MyApp.app|codesign --verify MyApp.app|git credential fill"
+	if got := strings.Join(fake.Commands(), "|"); got != want {
+		t.Errorf("Commands() = %q, want %q", got, want)
+	}
+	if got := fake.Calls()[2].Stdin; got != "host=github.com\n" {
+		t.Errorf("Calls()[2].Stdin = %q, want the credential request", got)
+	}
+}
+
+func TestRunUsesContextRunner(t *testing.T) {
+	if _, ok := FromContext(context.Background()).(Exec); !ok {
+		t.Errorf("FromContext() without a runner should return Exec")
+	}
+
+	fake := &Fake{Handler: func(name string, args []string) (string, error) { return "faked", nil }}
+	ctx := WithRunner(context.Background(), fake)
+	out, err := Run(ctx, "lipo", "-archs", "MyApp")
+	if out != "faked" || err != nil {
+		t.Errorf("Run() = %q, %v, want faked, nil", out, err)
+	}
+	if got := fake.Commands(); len(got) != 1 || got[0] != "lipo -archs MyApp" {
+		t.Errorf("Commands() = %v, want [lipo -archs MyApp]", got)
+	}
+}
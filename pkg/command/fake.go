@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Call is a command run through a Fake.
+type Call struct {
+	Name  string
+	Args  []string
+	Stdin string // written to the command by Output
+}
+
+// String formats the call as a command line.
+func (c Call) String() string {
+	return strings.TrimSpace(c.Name + " " + strings.Join(c.Args, " "))
+}
+
+// Fake is a Runner for tests. It records every call and answers it with
+// Handler, or with empty output and no error when Handler is nil. It is
+// safe for concurrent use.
+type Fake struct {
+	Handler func(name string, args []string) (string, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Run records the call and returns Handler's result.
+func (f *Fake) Run(ctx context.Context, name string, args ...string) (string, error) {
+	return f.Output(ctx, "", name, args...)
+}
+
+// Output records the call with its stdin and returns Handler's result.
+func (f *Fake) Output(ctx context.Context, stdin, name string, args ...string) (string, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Name: name, Args: append([]string(nil), args...), Stdin: stdin})
+	f.mu.Unlock()
+
+	if f.Handler == nil {
+		return "", nil
+	}
+	return f.Handler(name, args)
+}
+
+// Calls returns the calls made so far, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Commands returns the calls made so far formatted as command lines.
+func (f *Fake) Commands() []string {
+	var lines []string
+	for _, c := range f.Calls() {
+		lines = append(lines, c.String())
+	}
+	return lines
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
 	"github.com/goccy/go-yaml/parser"
 	"github.com/macreleaser/macreleaser/pkg/env"
 )
@@ -21,23 +23,72 @@ type Config struct {
 	Changelog ChangelogConfig `yaml:"changelog,omitempty"`
 	Release   ReleaseConfig   `yaml:"release"`
 	Homebrew  HomebrewConfig  `yaml:"homebrew"`
+	Announce  AnnounceConfig  `yaml:"announce,omitempty"`
+	Retry     RetryConfig     `yaml:"retry,omitempty"`
+
+	// Profiles holds named overlays selected with --profile. They are kept
+	// untyped so they round-trip through SaveConfig; the active profile is
+	// merged into the document before it is decoded.
+	Profiles map[string]any `yaml:"profiles,omitempty"`
 }
 
 // ProjectConfig contains project-specific settings
 type ProjectConfig struct {
-	Name      string `yaml:"name"`
-	Scheme    string `yaml:"scheme"`
-	Workspace string `yaml:"workspace,omitempty"`
+	Name        string `yaml:"name"`
+	DisplayName string `yaml:"display_name,omitempty"` // name shown in the release title, cask, and feed (default: name)
+	Scheme      string `yaml:"scheme"`
+	Workspace   string `yaml:"workspace,omitempty"`
+}
+
+// Title returns the human-readable project name: display_name when set,
+// otherwise name.
+func (p ProjectConfig) Title() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.Name
+}
+
+// FileName returns name reduced to characters that are safe in file names
+// and URLs: each run of anything other than letters, digits, '.', '_' and
+// '-' becomes a single '-', and leading or trailing '-' are dropped.
+func (p ProjectConfig) FileName() string {
+	var b strings.Builder
+	dash := false
+	for _, r := range p.Name {
+		if isFileNameRune(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+func isFileNameRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-'
 }
 
 // BuildConfig contains build configuration
 type BuildConfig struct {
-	Configuration string `yaml:"configuration"`
+	Configuration  string   `yaml:"configuration"`
+	VersionFile    string   `yaml:"version_file,omitempty"`    // Info.plist or .xcconfig updated with the version before building
+	VersionKey     string   `yaml:"version_key,omitempty"`     // key to update (default: CFBundleShortVersionString or MARKETING_VERSION)
+	CommitVersion  bool     `yaml:"commit_version,omitempty"`  // commit the updated version file
+	Architectures  []string `yaml:"architectures,omitempty"`   // archs the built executable must contain, e.g. [arm64, x86_64]
+	XcodebuildPath string   `yaml:"xcodebuild_path,omitempty"` // xcodebuild binary to run instead of the one on PATH
+	ExportOptions  string   `yaml:"export_options,omitempty"`  // ExportOptions.plist to export the app with xcodebuild -exportArchive instead of copying it out of the archive
 }
 
 // SignConfig contains code signing configuration
 type SignConfig struct {
-	Identity string `yaml:"identity"`
+	Identity             string   `yaml:"identity"`
+	Keychain             string   `yaml:"keychain,omitempty"`               // keychain file to find the identity in instead of the search list
+	KeychainPassword     string   `yaml:"keychain_password,omitempty"`      // unlocks the keychain before signing
+	KeepKeychainUnlocked bool     `yaml:"keep_keychain_unlocked,omitempty"` // turn off the keychain's auto-lock so it stays unlocked through notarization
+	SkipPaths            []string `yaml:"skip_paths,omitempty"`             // globs relative to the .app of nested code to leave unsigned; the rest is signed item by item
 }
 
 // NotarizeConfig contains Apple notarization configuration.
@@ -46,22 +97,126 @@ type SignConfig struct {
 // but memory should be cleared after use where possible. Always use environment
 // variable substitution (env(VAR_NAME)) instead of hardcoding passwords in config files.
 type NotarizeConfig struct {
-	AppleID  string `yaml:"apple_id"`
-	TeamID   string `yaml:"team_id"`
-	Password string `yaml:"password"`
+	AppleID           string `yaml:"apple_id"`
+	TeamID            string `yaml:"team_id"`
+	Password          string `yaml:"password"`
+	ASCKeyFile        string `yaml:"asc_key_file,omitempty"`        // JSON file with key_id, issuer_id, and key_path of an App Store Connect API key, used instead of apple_id/team_id/password
+	VerifyAfterStaple bool   `yaml:"verify_after_staple,omitempty"` // re-run codesign --verify on the .app after stapling
+	KeepSubmission    bool   `yaml:"keep_submission,omitempty"`     // keep the temporary ZIP submitted for the .app instead of deleting it
+	MaxConcurrent     int    `yaml:"max_concurrent,omitempty"`      // submissions in flight at once across parallel runs; 0 means 1
+	XcrunPath         string `yaml:"xcrun_path,omitempty"`          // xcrun binary that runs notarytool and stapler instead of the one on PATH
+	Profile           string `yaml:"profile,omitempty"`             // entry of profiles whose credentials replace the ones above
+	Async             bool   `yaml:"async,omitempty"`               // submit without --wait and poll notarytool info for the result
+	PollInterval      string `yaml:"poll_interval,omitempty"`       // how often async mode checks the submission, e.g. "1m" (default: 30s)
+	Timeout           string `yaml:"timeout,omitempty"`             // how long async mode waits for a result, e.g. "2h" (default: no limit)
+
+	Profiles map[string]NotarizeCredentials `yaml:"profiles,omitempty"`
+}
+
+// NotarizeCredentials is a named set of notarization credentials in
+// notarize.profiles, e.g. for a second Apple team. Like the top-level
+// fields, it holds either an Apple ID with team ID and app-specific
+// password, or an App Store Connect API key file.
+type NotarizeCredentials struct {
+	AppleID    string `yaml:"apple_id,omitempty"`
+	TeamID     string `yaml:"team_id,omitempty"`
+	Password   string `yaml:"password,omitempty"`
+	ASCKeyFile string `yaml:"asc_key_file,omitempty"`
+}
+
+// ActiveProfile returns the notarization configuration with the credentials
+// of the notarize.profile entry in place of the top-level ones, which are
+// ignored rather than merged. Without a profile, c is returned unchanged.
+func (c NotarizeConfig) ActiveProfile() (NotarizeConfig, error) {
+	if c.Profile == "" {
+		return c, nil
+	}
+	creds, ok := c.Profiles[c.Profile]
+	if !ok {
+		names := make([]string, 0, len(c.Profiles))
+		for name := range c.Profiles {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return c, fmt.Errorf("notarize.profile %q is set but notarize.profiles is empty", c.Profile)
+		}
+		sort.Strings(names)
+		return c, fmt.Errorf("notarize.profile %q is not defined in notarize.profiles (defined: %s)", c.Profile, strings.Join(names, ", "))
+	}
+	c.AppleID = creds.AppleID
+	c.TeamID = creds.TeamID
+	c.Password = creds.Password
+	c.ASCKeyFile = creds.ASCKeyFile
+	return c, nil
 }
 
 // ArchiveConfig contains archive creation configuration
 type ArchiveConfig struct {
-	Formats []string  `yaml:"formats"`
-	DMG     DMGConfig `yaml:"dmg,omitempty"`
-	Zip     ZipConfig `yaml:"zip,omitempty"`
+	Formats      ArchiveFormats `yaml:"formats"`
+	ExtraFiles   []string       `yaml:"extra_files,omitempty"`  // local globs bundled next to the .app in zip and dmg
+	IncludeDSYM  bool           `yaml:"include_dsym,omitempty"` // also package the archive's dSYMs as <Name>-<version>.dSYM.zip
+	Exclude      []string       `yaml:"exclude,omitempty"`      // globs left out of zip and dmg outside the .app, on top of .DS_Store, __MACOSX, and ._*
+	Reproducible bool           `yaml:"reproducible,omitempty"` // write byte-identical zips with sorted entries and fixed timestamps
+	SBOM         bool           `yaml:"sbom,omitempty"`         // also package a software bill of materials for the .app, generated with syft if installed
+	SBOMFormat   string         `yaml:"sbom_format,omitempty"`  // cyclonedx or spdx (default: cyclonedx)
+	DMG          DMGConfig      `yaml:"dmg,omitempty"`
+	Zip          ZipConfig      `yaml:"zip,omitempty"`
+}
+
+// ArchiveFormat is one entry of archive.formats. In YAML an entry is either
+// a bare format name (zip) or a mapping with per-format overrides
+// ({type: dmg, name_template: ..., background: ...}); both decode into this
+// structure.
+type ArchiveFormat struct {
+	Type         string `yaml:"type"`
+	NameTemplate string `yaml:"name_template,omitempty"` // package file name without extension; {{.Name}}, {{.Version}}, and {{.ShortCommit}} are available
+	Background   string `yaml:"background,omitempty"`    // dmg only; overrides archive.dmg.background
+}
+
+// UnmarshalYAML decodes a bare format name or a mapping of overrides
+func (f *ArchiveFormat) UnmarshalYAML(data []byte) error {
+	var name string
+	if err := yaml.Unmarshal(data, &name); err == nil {
+		*f = ArchiveFormat{Type: name}
+		return nil
+	}
+
+	// The alias drops this method so the mapping decodes field by field
+	type archiveFormat ArchiveFormat
+	var raw archiveFormat
+	if err := yaml.UnmarshalWithOptions(data, &raw, yaml.Strict()); err != nil {
+		return fmt.Errorf("archive.formats entry must be a format name or a mapping with type: %w", err)
+	}
+	*f = ArchiveFormat(raw)
+	return nil
+}
+
+// MarshalYAML writes entries without overrides back as bare format names
+func (f ArchiveFormat) MarshalYAML() (any, error) {
+	if f.NameTemplate == "" && f.Background == "" {
+		return f.Type, nil
+	}
+	type archiveFormat ArchiveFormat
+	return archiveFormat(f), nil
+}
+
+// ArchiveFormats is the normalized archive.formats list
+type ArchiveFormats []ArchiveFormat
+
+// Types returns the format name of each entry, in order
+func (fs ArchiveFormats) Types() []string {
+	types := make([]string, len(fs))
+	for i, f := range fs {
+		types[i] = f.Type
+	}
+	return types
 }
 
 // DMGConfig contains DMG-specific configuration
 type DMGConfig struct {
 	Background string `yaml:"background,omitempty"`
 	IconSize   int    `yaml:"icon_size,omitempty"`
+	License    string `yaml:"license,omitempty"` // .rtf or plain-text license users must agree to before the DMG mounts
 }
 
 // ZipConfig contains ZIP-specific configuration
@@ -71,10 +226,25 @@ type ZipConfig struct {
 
 // ChangelogConfig contains changelog generation configuration
 type ChangelogConfig struct {
-	Disable bool                   `yaml:"disable,omitempty"`
-	Sort    string                 `yaml:"sort,omitempty"`
-	Filters ChangelogFiltersConfig `yaml:"filters,omitempty"`
-	Groups  []ChangelogGroupConfig `yaml:"groups,omitempty"`
+	Disable         bool                     `yaml:"disable,omitempty"`
+	Sort            string                   `yaml:"sort,omitempty"`
+	Filters         ChangelogFiltersConfig   `yaml:"filters,omitempty"`
+	Groups          []ChangelogGroupConfig   `yaml:"groups,omitempty"`
+	MaxEntries      int                      `yaml:"max_entries,omitempty"`      // per group, or for the flat list; 0 means unlimited
+	GroupByScope    bool                     `yaml:"group_by_scope,omitempty"`   // nest entries under their conventional commit scope
+	Replace         []ChangelogReplaceConfig `yaml:"replace,omitempty"`          // rewrites applied to each subject before rendering
+	Trim            bool                     `yaml:"trim,omitempty"`             // trim whitespace from each subject after the replace rules
+	Since           string                   `yaml:"since,omitempty"`            // "last-stable" starts after the previous non-prerelease tag (default: the previous tag)
+	PreviousTag     string                   `yaml:"previous_tag,omitempty"`     // start the changelog after this tag instead of the detected previous tag
+	ReleaseBody     *bool                    `yaml:"release_body,omitempty"`     // use the changelog as the GitHub release body (default: true)
+	Dependencies    string                   `yaml:"dependencies,omitempty"`     // "group" lists dependency updates under their own heading, "exclude" leaves them out
+	IncludeTrailers bool                     `yaml:"include_trailers,omitempty"` // list the issues and co-authors named in commit trailers
+}
+
+// UseAsReleaseBody reports whether the generated changelog becomes the
+// GitHub release body. It does unless release_body is explicitly false.
+func (c ChangelogConfig) UseAsReleaseBody() bool {
+	return c.ReleaseBody == nil || *c.ReleaseBody
 }
 
 // ChangelogFiltersConfig contains commit filtering configuration
@@ -83,6 +253,13 @@ type ChangelogFiltersConfig struct {
 	Include []string `yaml:"include,omitempty"`
 }
 
+// ChangelogReplaceConfig rewrites matches of Regexp in a commit subject
+// with Replacement, which may reference capture groups as $1 or ${name}
+type ChangelogReplaceConfig struct {
+	Regexp      string `yaml:"regexp"`
+	Replacement string `yaml:"replacement"`
+}
+
 // ChangelogGroupConfig contains commit grouping configuration
 type ChangelogGroupConfig struct {
 	Title  string `yaml:"title"`
@@ -92,25 +269,75 @@ type ChangelogGroupConfig struct {
 
 // ReleaseConfig contains release configuration
 type ReleaseConfig struct {
-	GitHub GitHubConfig `yaml:"github"`
+	GitHub          GitHubConfig `yaml:"github"`
+	AllowedBranches []string     `yaml:"allowed_branches,omitempty"` // branches, or globs such as "release/*", a release may be published from (default: any)
+	AllowDetached   bool         `yaml:"allow_detached,omitempty"`   // with allowed_branches, also publish from a detached HEAD, e.g. a CI tag checkout
 }
 
 // GitHubConfig contains GitHub-specific release configuration
 type GitHubConfig struct {
-	Owner string `yaml:"owner"`
-	Repo  string `yaml:"repo"`
-	Draft bool   `yaml:"draft"`
+	Owner              string            `yaml:"owner"`
+	Repo               string            `yaml:"repo"`
+	Token              string            `yaml:"token,omitempty"` // token used to publish the release, e.g. env(RELEASE_TOKEN) (default: GITHUB_TOKEN, gh, or the git credential helper)
+	Draft              bool              `yaml:"draft"`
+	Timeout            string            `yaml:"timeout,omitempty"`              // per-request timeout, e.g. "30m" (default: 5m)
+	DiscussionCategory string            `yaml:"discussion_category,omitempty"`  // create a Discussion for the release in this category
+	Target             string            `yaml:"target,omitempty"`               // branch or commit the tag is created at if missing (default: HEAD commit)
+	ExtraAssets        []string          `yaml:"extra_assets,omitempty"`         // globs of pre-built files uploaded alongside the packages
+	PublishAfterUpload bool              `yaml:"publish_after_upload,omitempty"` // create as draft and publish only once every asset is uploaded
+	AssetNameTemplate  string            `yaml:"asset_name_template,omitempty"`  // uploaded asset name; {{.ProjectName}}, {{.Version}}, {{.Filename}}, and {{.Ext}} are available
+	Headers            map[string]string `yaml:"headers,omitempty"`              // static headers sent with every GitHub request, e.g. a proxy auth token
+	VerifyDownload     bool              `yaml:"verify_download,omitempty"`      // after publishing, check each asset's download URL serves the uploaded file
+	MaxAssetSize       int64             `yaml:"max_asset_size,omitempty"`       // assets larger than this many bytes are skipped with a warning (default: no limit)
+	RollingTag         string            `yaml:"rolling_tag,omitempty"`          // publish to this fixed tag, replacing its previous release and re-pointing it, e.g. "nightly"
+	ContentTypes       map[string]string `yaml:"content_types,omitempty"`        // content type uploaded for each file extension, e.g. ".zip": application/octet-stream
+}
+
+// AnnounceConfig contains settings for announcing a published release
+type AnnounceConfig struct {
+	Feed FeedConfig `yaml:"feed,omitempty"`
+}
+
+// FeedConfig controls the Atom feed of the repository's releases
+type FeedConfig struct {
+	Path string `yaml:"path,omitempty"` // file the feed is written to after each release; empty disables the feed
+}
+
+// RetryConfig is the retry policy for networked operations such as GitHub
+// API requests. Unset fields use the defaults from the retry package.
+type RetryConfig struct {
+	MaxAttempts int    `yaml:"max_attempts,omitempty"` // total attempts including the first (default: 3)
+	BaseDelay   string `yaml:"base_delay,omitempty"`   // delay before the first retry, doubled each attempt (default: 1s)
+	MaxDelay    string `yaml:"max_delay,omitempty"`    // upper bound on the delay between attempts (default: 30s)
 }
 
 // HomebrewConfig contains Homebrew cask configuration
 type HomebrewConfig struct {
 	Tap      TapConfig      `yaml:"tap,omitempty"`
+	Taps     []TapConfig    `yaml:"taps,omitempty"` // further taps the stable cask is committed to, alongside tap
 	Official OfficialConfig `yaml:"official,omitempty"`
 	Cask     CaskConfig     `yaml:"cask"`
 }
 
 // TapConfig contains custom tap configuration
 type TapConfig struct {
+	Owner      string        `yaml:"owner"`
+	Name       string        `yaml:"name"`
+	Token      string        `yaml:"token"`
+	Beta       BetaTapConfig `yaml:"beta,omitempty"`
+	AlsoLatest bool          `yaml:"also_latest,omitempty"` // also commit Casks/<token>@latest.rb pinned to the newest release
+
+	// CommitMessageTemplate is the message of the commit that adds or
+	// updates the cask. {{.Token}}, {{.Version}}, and {{.Action}} ("Add" or
+	// "Update") are available (default: "Add <token> <version>" or
+	// "Update <token> to <version>").
+	CommitMessageTemplate string `yaml:"commit_message_template,omitempty"`
+}
+
+// BetaTapConfig contains the tap that receives prerelease versions.
+// When set, prereleases are committed here under a "<name>@beta" cask token
+// instead of updating the stable cask.
+type BetaTapConfig struct {
 	Owner string `yaml:"owner"`
 	Name  string `yaml:"name"`
 	Token string `yaml:"token"`
@@ -126,14 +353,40 @@ type OfficialConfig struct {
 
 // CaskConfig contains cask metadata
 type CaskConfig struct {
-	Name     string `yaml:"name"`
-	Desc     string `yaml:"desc"`
-	Homepage string `yaml:"homepage"`
-	License  string `yaml:"license"`
+	Name          string        `yaml:"name"`
+	Token         string        `yaml:"token,omitempty"` // cask token override (default: normalized name)
+	Desc          string        `yaml:"desc"`
+	NormalizeDesc bool          `yaml:"normalize_desc,omitempty"` // rewrite desc to pass brew audit: no leading article or trailing period, at most 80 characters
+	Homepage      string        `yaml:"homepage"`
+	License       string        `yaml:"license"`
+	Caveats       string        `yaml:"caveats,omitempty"`      // post-install note shown by brew
+	AutoUpdates   bool          `yaml:"auto_updates,omitempty"` // app updates itself (e.g., via Sparkle), so brew upgrade skips it
+	Rolling       bool          `yaml:"rolling,omitempty"`      // render version :latest and sha256 :no_check, pointing at the latest release download
+	AppTarget     string        `yaml:"app_target,omitempty"`   // name the app is installed as, e.g. "My App.app" (default: the bundle's own name)
+	ConflictsWith CaskConflicts `yaml:"conflicts_with,omitempty"`
 }
 
-// LoadConfig loads and parses a configuration file
+// CaskConflicts lists the casks and formulae the cask cannot be installed
+// alongside, rendered as its conflicts_with stanza
+type CaskConflicts struct {
+	Cask    []string `yaml:"cask,omitempty"`
+	Formula []string `yaml:"formula,omitempty"`
+}
+
+// LoadConfig loads and parses a configuration file, substituting env(...) references
 func LoadConfig(path string) (*Config, error) {
+	return loadConfig(path, "")
+}
+
+// LoadConfigProfile loads a configuration file like LoadConfig, then overlays
+// the named entry of its profiles section onto the base configuration.
+// Mappings are merged key by key; scalars and lists in the profile replace
+// the base values. An empty profile is the same as LoadConfig.
+func LoadConfigProfile(path, profile string) (*Config, error) {
+	return loadConfig(path, profile)
+}
+
+func loadConfig(path, profile string) (*Config, error) {
 	if path == "" {
 		return nil, fmt.Errorf("config file path is required")
 	}
@@ -160,6 +413,10 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("environment variable substitution failed: %w", err)
 	}
 
+	if profile != "" {
+		return decodeWithProfile(file.Docs[0].Body, profile)
+	}
+
 	var config Config
 	if err := yaml.NodeToValue(file.Docs[0].Body, &config, yaml.Strict()); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
@@ -168,7 +425,72 @@ func LoadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
-// SaveConfig saves a configuration to a file
+// decodeWithProfile merges the named profile onto the document and decodes
+// the result. Merging happens on generic maps, so explicit zero values in a
+// profile (such as draft: false) still override the base.
+func decodeWithProfile(body ast.Node, profile string) (*Config, error) {
+	var doc map[string]any
+	if err := yaml.NodeToValue(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	profiles, _ := doc["profiles"].(map[string]any)
+	overlay, ok := profiles[profile].(map[string]any)
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown profile %q: config has no profiles section", profile)
+		}
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", profile, strings.Join(names, ", "))
+	}
+
+	merged, err := yaml.Marshal(mergeMaps(doc, overlay))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge profile %q: %w", profile, err)
+	}
+
+	var config Config
+	if err := yaml.UnmarshalWithOptions(merged, &config, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("failed to parse config with profile %q: %w", profile, err)
+	}
+
+	return &config, nil
+}
+
+// mergeMaps returns base with overlay deep-merged on top. Nested mappings
+// are merged recursively; any other overlay value replaces the base value.
+func mergeMaps(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		if ov, ok := v.(map[string]any); ok {
+			if bv, ok := merged[k].(map[string]any); ok {
+				merged[k] = mergeMaps(bv, ov)
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// ApplyDefaults fills in values that pipes otherwise assume implicitly when
+// a field is left empty, so the effective configuration can be displayed.
+func ApplyDefaults(cfg *Config) {
+	if !cfg.Changelog.Disable && cfg.Changelog.Sort == "" {
+		cfg.Changelog.Sort = "desc"
+	}
+}
+
+// SaveConfig saves a configuration to a file. The file is written from the
+// struct, so comments and key order in an existing file are lost; use
+// SetString to change a single value of an existing file.
 func SaveConfig(path string, config *Config) error {
 	if config == nil {
 		return fmt.Errorf("config cannot be nil")
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -226,7 +227,7 @@ func TestSaveConfig(t *testing.T) {
 			Password: "[TEST_PASSWORD_PLACEHOLDER]",
 		},
 		Archive: ArchiveConfig{
-			Formats: []string{"dmg"},
+			Formats: ArchiveFormats{{Type: "dmg"}},
 		},
 		Release: ReleaseConfig{
 			GitHub: GitHubConfig{
@@ -496,3 +497,275 @@ func TestLoadConfigSecurity(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigProfile(t *testing.T) {
+	t.Setenv("MACRELEASER_TEST_BETA_PASSWORD", "beta-secret")
+
+	tmpFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+project:
+  name: "MyApp"
+  scheme: "MyApp"
+notarize:
+  apple_id: "dev@example.com"
+  team_id: "TEAM123"
+  password: "base-secret"
+release:
+  github:
+    owner: "yourname"
+    repo: "myapp"
+    draft: true
+homebrew:
+  tap:
+    owner: "yourname"
+    name: "homebrew-tap"
+    token: "tap-token"
+profiles:
+  beta:
+    notarize:
+      password: env(MACRELEASER_TEST_BETA_PASSWORD)
+    release:
+      github:
+        draft: false
+    homebrew:
+      tap:
+        name: "homebrew-beta"
+  broken:
+    release:
+      github:
+        drafts: true
+`
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	base, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if !base.Release.GitHub.Draft || base.Homebrew.Tap.Name != "homebrew-tap" {
+		t.Errorf("LoadConfig() applied a profile without one being selected")
+	}
+
+	beta, err := LoadConfigProfile(tmpFile, "beta")
+	if err != nil {
+		t.Fatalf("LoadConfigProfile() error = %v", err)
+	}
+	if beta.Release.GitHub.Draft {
+		t.Error("beta profile should override draft to false")
+	}
+	if beta.Homebrew.Tap.Name != "homebrew-beta" {
+		t.Errorf("Homebrew.Tap.Name = %q, want %q", beta.Homebrew.Tap.Name, "homebrew-beta")
+	}
+	if beta.Notarize.Password != "beta-secret" {
+		t.Errorf("Notarize.Password = %q, want env-substituted %q", beta.Notarize.Password, "beta-secret")
+	}
+
+	// Fields the profile does not mention are kept from the base
+	if beta.Homebrew.Tap.Owner != "yourname" || beta.Homebrew.Tap.Token != "tap-token" {
+		t.Errorf("Homebrew.Tap = %+v, want owner and token from base", beta.Homebrew.Tap)
+	}
+	if beta.Release.GitHub.Owner != "yourname" || beta.Notarize.AppleID != "dev@example.com" {
+		t.Error("base values not preserved under beta profile")
+	}
+
+	_, err = LoadConfigProfile(tmpFile, "nightly")
+	if err == nil || !strings.Contains(err.Error(), `unknown profile "nightly" (available: beta, broken)`) {
+		t.Errorf("LoadConfigProfile() error = %v, want unknown profile error", err)
+	}
+
+	// Unknown fields in the selected profile are rejected like in the base
+	if _, err := LoadConfigProfile(tmpFile, "broken"); err == nil {
+		t.Error("LoadConfigProfile() expected error for unknown field in profile, got nil")
+	}
+}
+
+func TestLoadConfigArchiveFormats(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+project:
+  name: "MyApp"
+  scheme: "MyApp"
+archive:
+  formats:
+    - zip
+    - type: dmg
+      background: assets/background.png
+    - type: dmg
+      name_template: "{{.Name}}-{{.Version}}-plain"
+    - app
+`
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	want := ArchiveFormats{
+		{Type: "zip"},
+		{Type: "dmg", Background: "assets/background.png"},
+		{Type: "dmg", NameTemplate: "{{.Name}}-{{.Version}}-plain"},
+		{Type: "app"},
+	}
+	if !reflect.DeepEqual(cfg.Archive.Formats, want) {
+		t.Errorf("Archive.Formats = %+v, want %+v", cfg.Archive.Formats, want)
+	}
+	if got := cfg.Archive.Formats.Types(); !reflect.DeepEqual(got, []string{"zip", "dmg", "dmg", "app"}) {
+		t.Errorf("Archive.Formats.Types() = %v", got)
+	}
+
+	// Saving writes entries without overrides back as bare names
+	savedFile := filepath.Join(t.TempDir(), "saved.yaml")
+	if err := SaveConfig(savedFile, cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	data, err := os.ReadFile(savedFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "- zip\n") || !strings.Contains(string(data), "- app\n") {
+		t.Errorf("saved config does not keep bare format names:\n%s", data)
+	}
+	reloaded, err := LoadConfig(savedFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() of saved config error = %v", err)
+	}
+	if !reflect.DeepEqual(reloaded.Archive.Formats, want) {
+		t.Errorf("reloaded Archive.Formats = %+v, want %+v", reloaded.Archive.Formats, want)
+	}
+}
+
+func TestLoadConfigArchiveFormatsUnknownKey(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+archive:
+  formats:
+    - type: dmg
+      backgroud: assets/background.png
+`
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadConfig(tmpFile); err == nil {
+		t.Error("LoadConfig() expected error for unknown archive.formats key, got nil")
+	}
+}
+
+func TestLoadConfigNotarizeProfiles(t *testing.T) {
+	t.Setenv("MACRELEASER_TEST_STORE_PASSWORD", "store-secret")
+
+	tmpFile := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+project:
+  name: "MyApp"
+  scheme: "MyApp"
+notarize:
+  apple_id: "dev@example.com"
+  team_id: "TEAM123"
+  password: "base-secret"
+  profiles:
+    appstore:
+      apple_id: "store@example.com"
+      team_id: "STORE99"
+      password: env(MACRELEASER_TEST_STORE_PASSWORD)
+    internal:
+      asc_key_file: "asc.json"
+profiles:
+  store:
+    notarize:
+      profile: appstore
+`
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		configProfile string
+		override      string
+		want          NotarizeCredentials
+	}{
+		{
+			name: "top-level credentials without a profile",
+			want: NotarizeCredentials{AppleID: "dev@example.com", TeamID: "TEAM123", Password: "base-secret"},
+		},
+		{
+			name:          "profile selected by a config profile",
+			configProfile: "store",
+			want:          NotarizeCredentials{AppleID: "store@example.com", TeamID: "STORE99", Password: "store-secret"},
+		},
+		{
+			name:     "profile selected directly",
+			override: "internal",
+			want:     NotarizeCredentials{ASCKeyFile: "asc.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadConfigProfile(tmpFile, tt.configProfile)
+			if err != nil {
+				t.Fatalf("LoadConfigProfile() error = %v", err)
+			}
+			if tt.override != "" {
+				cfg.Notarize.Profile = tt.override
+			}
+
+			active, err := cfg.Notarize.ActiveProfile()
+			if err != nil {
+				t.Fatalf("ActiveProfile() error = %v", err)
+			}
+			got := NotarizeCredentials{AppleID: active.AppleID, TeamID: active.TeamID, Password: active.Password, ASCKeyFile: active.ASCKeyFile}
+			if got != tt.want {
+				t.Errorf("ActiveProfile() credentials = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotarizeActiveProfileUndefined(t *testing.T) {
+	cfg := NotarizeConfig{
+		Profile:  "missing",
+		Profiles: map[string]NotarizeCredentials{"internal": {}, "appstore": {}},
+	}
+	_, err := cfg.ActiveProfile()
+	if err == nil || !strings.Contains(err.Error(), `notarize.profile "missing" is not defined in notarize.profiles (defined: appstore, internal)`) {
+		t.Errorf("ActiveProfile() error = %v, want undefined profile error", err)
+	}
+
+	cfg.Profiles = nil
+	if _, err := cfg.ActiveProfile(); err == nil || !strings.Contains(err.Error(), "notarize.profiles is empty") {
+		t.Errorf("ActiveProfile() error = %v, want empty profiles error", err)
+	}
+}
+
+func TestProjectConfigNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		project   ProjectConfig
+		wantTitle string
+		wantFile  string
+	}{
+		{name: "plain", project: ProjectConfig{Name: "MyApp"}, wantTitle: "MyApp", wantFile: "MyApp"},
+		{name: "safe punctuation kept", project: ProjectConfig{Name: "my_app-2.0"}, wantTitle: "my_app-2.0", wantFile: "my_app-2.0"},
+		{name: "spaces", project: ProjectConfig{Name: "My App"}, wantTitle: "My App", wantFile: "My-App"},
+		{name: "runs collapsed and trimmed", project: ProjectConfig{Name: " My  App (Beta)! "}, wantTitle: " My  App (Beta)! ", wantFile: "My-App-Beta"},
+		{name: "non-ascii", project: ProjectConfig{Name: "Café & Co"}, wantTitle: "Café & Co", wantFile: "Caf-Co"},
+		{name: "display name", project: ProjectConfig{Name: "MyApp", DisplayName: "My App™"}, wantTitle: "My App™", wantFile: "MyApp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.project.Title(); got != tt.wantTitle {
+				t.Errorf("Title() = %q, want %q", got, tt.wantTitle)
+			}
+			if got := tt.project.FileName(); got != tt.wantFile {
+				t.Errorf("FileName() = %q, want %q", got, tt.wantFile)
+			}
+		})
+	}
+}
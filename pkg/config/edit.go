@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+)
+
+// SetString sets the string at key, a dotted path such as "sign.identity",
+// in the config file at path. Unlike SaveConfig, the file is edited line by
+// line instead of being re-marshalled, so its comments, key order and
+// formatting are kept. Mappings missing along the path are added. Only a
+// value written on the same line as its key can be replaced, and flow-style
+// mappings are not edited.
+func SetString(path, key, value string) error {
+	cleanPath, err := validateConfigPath(path)
+	if err != nil {
+		return err
+	}
+	data, err := readConfigFile(cleanPath)
+	if err != nil {
+		return err
+	}
+
+	edited, err := setString(string(data), key, value)
+	if err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+	// Make sure the edit reads back as intended before replacing the file
+	if got, err := lookupString([]byte(edited), key); err != nil || got != value {
+		return fmt.Errorf("failed to set %s: the edited config does not read back as expected", key)
+	}
+
+	// Use restrictive permissions (0600) since config may contain sensitive data
+	if err := os.WriteFile(cleanPath, []byte(edited), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// setString returns the YAML document src with key set to value.
+func setString(src, key, value string) (string, error) {
+	file, err := parser.ParseBytes([]byte(src), parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse config: %w", err)
+	}
+	var node ast.Node
+	if len(file.Docs) > 0 {
+		node = file.Docs[0].Body
+	}
+
+	quoted, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	scalar := strings.TrimSpace(string(quoted))
+
+	lines := strings.Split(src, "\n")
+	keys := strings.Split(key, ".")
+	// parentLine is the 1-based line of the mapping key being descended into,
+	// or 0 at the top level, where new keys are appended to the file
+	parentLine, parentIndent := 0, -2
+
+	for i, k := range keys {
+		values, err := mappingValues(node, strings.Join(keys[:i], "."))
+		if err != nil {
+			return "", err
+		}
+
+		var found *ast.MappingValueNode
+		for _, mv := range values {
+			if mv.Key.GetToken().Value == k {
+				found = mv
+				break
+			}
+		}
+
+		if found == nil {
+			indent := parentIndent + 2
+			if len(values) > 0 {
+				indent = values[0].Key.GetToken().Position.Column - 1
+			}
+			block := newKeyBlock(keys[i:], indent, scalar)
+			if parentLine == 0 {
+				// Append to the file, after any final newline
+				if n := len(lines); n > 0 && lines[n-1] == "" {
+					lines = lines[:n-1]
+				}
+				lines = append(append(lines, block...), "")
+			} else {
+				lines = append(lines[:parentLine], append(block, lines[parentLine:]...)...)
+			}
+			return strings.Join(lines, "\n"), nil
+		}
+
+		keyToken := found.Key.GetToken()
+		line := keyToken.Position.Line
+		if i < len(keys)-1 {
+			if _, ok := found.Value.(*ast.NullNode); ok && !emptyAfterColon(lines[line-1], keyToken) {
+				return "", fmt.Errorf("%s is not a mapping", strings.Join(keys[:i+1], "."))
+			}
+			node = found.Value
+			parentLine, parentIndent = line, keyToken.Position.Column-1
+			continue
+		}
+
+		rest, err := afterValue(lines[line-1], keyToken, found.Value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+		lines[line-1] = lines[line-1][:keyToken.Position.Column-1] + k + ": " + scalar + rest
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// mappingValues returns the key/value pairs of a block mapping node. A
+// missing or null node is an empty mapping.
+func mappingValues(node ast.Node, name string) ([]*ast.MappingValueNode, error) {
+	switch n := node.(type) {
+	case nil, *ast.NullNode:
+		return nil, nil
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}, nil
+	case *ast.MappingNode:
+		if n.IsFlowStyle {
+			return nil, fmt.Errorf("%s is a flow-style mapping, which cannot be edited in place", name)
+		}
+		return n.Values, nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("config is not a mapping")
+	}
+	return nil, fmt.Errorf("%s is not a mapping", name)
+}
+
+// newKeyBlock returns the lines of nested keys ending in scalar, starting at
+// indent.
+func newKeyBlock(keys []string, indent int, scalar string) []string {
+	block := make([]string, len(keys))
+	for i, k := range keys {
+		block[i] = strings.Repeat(" ", indent+2*i) + k + ":"
+	}
+	block[len(block)-1] += " " + scalar
+	return block
+}
+
+// afterValue returns what follows the scalar value of a key on its line,
+// such as a comment, so it can be kept when the value is replaced.
+func afterValue(line string, key *token.Token, value ast.Node) (string, error) {
+	colon := colonAfter(line, key)
+	if colon < 0 {
+		return "", fmt.Errorf("cannot find the value on line %d", key.Position.Line)
+	}
+	after := line[colon+1:]
+
+	switch value.(type) {
+	case *ast.NullNode:
+		if emptyAfterColon(line, key) {
+			return after, nil
+		}
+	case *ast.StringNode, *ast.IntegerNode, *ast.FloatNode, *ast.BoolNode, *ast.InfinityNode, *ast.NanNode:
+	default:
+		return "", fmt.Errorf("only a single-line value can be replaced")
+	}
+
+	tok := value.GetToken()
+	raw := strings.TrimSpace(tok.Origin)
+	i := strings.Index(after, raw)
+	if tok.Position.Line != key.Position.Line || raw == "" || i < 0 {
+		return "", fmt.Errorf("only a value on the same line as its key can be replaced")
+	}
+	return after[i+len(raw):], nil
+}
+
+// emptyAfterColon reports whether nothing but a comment follows the colon
+// after key on line.
+func emptyAfterColon(line string, key *token.Token) bool {
+	colon := colonAfter(line, key)
+	if colon < 0 {
+		return false
+	}
+	rest := strings.TrimSpace(line[colon+1:])
+	return rest == "" || strings.HasPrefix(rest, "#")
+}
+
+// colonAfter returns the index of the colon following key on line, or -1.
+func colonAfter(line string, key *token.Token) int {
+	start := key.Position.Column - 1
+	if start < 0 || start > len(line) {
+		return -1
+	}
+	i := strings.Index(line[start:], ":")
+	if i < 0 {
+		return -1
+	}
+	return start + i
+}
+
+// lookupString returns the string at the dotted key in a YAML document.
+func lookupString(data []byte, key string) (string, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+	for _, k := range strings.Split(key, ".") {
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%s not found", key)
+		}
+		doc = m[k]
+	}
+	value, ok := doc.(string)
+	if !ok {
+		return "", fmt.Errorf("%s is not a string", key)
+	}
+	return value, nil
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetString(t *testing.T) {
+	const identity = "Developer ID Application: Jane Doe (TEAM123)"
+
+	tests := []struct {
+		name   string
+		src    string
+		key    string
+		want   string
+		errMsg string
+	}{
+		{
+			name: "adds a key to an existing mapping",
+			src:  "# Release settings\nproject:\n  name: MyApp # shown in releases\n\nsign:\n  # keychain holding the certificate\n  keychain: build.keychain\n",
+			key:  "sign.identity",
+			want: "# Release settings\nproject:\n  name: MyApp # shown in releases\n\nsign:\n  identity: \"" + identity + "\"\n  # keychain holding the certificate\n  keychain: build.keychain\n",
+		},
+		{
+			name: "keeps the indentation of the mapping",
+			src:  "sign:\n    keychain: build.keychain\n    timestamp: true\n",
+			key:  "sign.identity",
+			want: "sign:\n    identity: \"" + identity + "\"\n    keychain: build.keychain\n    timestamp: true\n",
+		},
+		{
+			name: "replaces an existing value and keeps its comment",
+			src:  "sign:\n  identity: \"\" # set me\n  keychain: build.keychain\n",
+			key:  "sign.identity",
+			want: "sign:\n  identity: \"" + identity + "\" # set me\n  keychain: build.keychain\n",
+		},
+		{
+			name: "fills in an empty value",
+			src:  "sign:\n  identity: # set me\nrelease:\n  draft: true\n",
+			key:  "sign.identity",
+			want: "sign:\n  identity: \"" + identity + "\" # set me\nrelease:\n  draft: true\n",
+		},
+		{
+			name: "adds to an empty mapping",
+			src:  "project:\n  name: MyApp\nsign:\nrelease:\n  draft: true\n",
+			key:  "sign.identity",
+			want: "project:\n  name: MyApp\nsign:\n  identity: \"" + identity + "\"\nrelease:\n  draft: true\n",
+		},
+		{
+			name: "appends a missing section",
+			src:  "# MyApp\nproject:\n  name: MyApp\n",
+			key:  "sign.identity",
+			want: "# MyApp\nproject:\n  name: MyApp\nsign:\n  identity: \"" + identity + "\"\n",
+		},
+		{
+			name:   "flow-style mapping",
+			src:    "sign: {keychain: build.keychain}\n",
+			key:    "sign.identity",
+			errMsg: "flow-style",
+		},
+		{
+			name:   "scalar in the path",
+			src:    "sign: none\n",
+			key:    "sign.identity",
+			errMsg: "sign is not a mapping",
+		},
+		{
+			name:   "multi-line value",
+			src:    "sign:\n  identity: |\n    Developer ID\n",
+			key:    "sign.identity",
+			errMsg: "single-line value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			origDir, _ := os.Getwd()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = os.Chdir(origDir) }()
+
+			path := filepath.Join(dir, ".macreleaser.yaml")
+			if err := os.WriteFile(path, []byte(tt.src), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			err := SetString(".macreleaser.yaml", tt.key, identity)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("SetString() error = %v, want containing %q", err, tt.errMsg)
+				}
+				if data, _ := os.ReadFile(path); string(data) != tt.src {
+					t.Errorf("config changed after a failed edit:\n%s", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetString() unexpected error: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("config =\n%s\nwant\n%s", data, tt.want)
+			}
+		})
+	}
+}
@@ -19,7 +19,7 @@ func ExampleConfig() *Config {
 			Password: "env(APPLE_APP_SPECIFIC_PASSWORD)",
 		},
 		Archive: ArchiveConfig{
-			Formats: []string{"dmg", "zip"},
+			Formats: ArchiveFormats{{Type: "dmg"}, {Type: "zip"}},
 			DMG: DMGConfig{
 				Background: "background.png",
 				IconSize:   128,
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultConfigFile is the configuration filename used when --config is not given.
+const DefaultConfigFile = ".macreleaser.yaml"
+
+// FindConfig looks for a file called name in startDir and then in each parent
+// directory, the way git locates .git. The search stops after the directory
+// that contains .git (the repository root) or at the filesystem root.
+// Returns the absolute path of the first match.
+func FindConfig(startDir, name string) (string, error) {
+	dir, err := filepath.Abs(startDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid directory: %w", err)
+	}
+
+	for {
+		candidate := filepath.Join(dir, name)
+		if info, err := os.Stat(candidate); err == nil && info.Mode().IsRegular() {
+			return candidate, nil
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return "", fmt.Errorf("no %s found in %s or its parent directories: %w", name, startDir, os.ErrNotExist)
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindConfig(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	nested := filepath.Join(repo, "App", "Sources", "Views")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(repo, DefaultConfigFile)
+	if err := os.WriteFile(configPath, []byte("project:\n  name: MyApp\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("found from nested directory", func(t *testing.T) {
+		got, err := FindConfig(nested, DefaultConfigFile)
+		if err != nil {
+			t.Fatalf("FindConfig() unexpected error: %v", err)
+		}
+		if got != configPath {
+			t.Errorf("FindConfig() = %q, want %q", got, configPath)
+		}
+	})
+
+	t.Run("closest config wins", func(t *testing.T) {
+		closer := filepath.Join(repo, "App", DefaultConfigFile)
+		if err := os.WriteFile(closer, []byte("project:\n  name: Other\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(closer)
+
+		got, err := FindConfig(nested, DefaultConfigFile)
+		if err != nil {
+			t.Fatalf("FindConfig() unexpected error: %v", err)
+		}
+		if got != closer {
+			t.Errorf("FindConfig() = %q, want %q", got, closer)
+		}
+	})
+
+	t.Run("search stops at git root", func(t *testing.T) {
+		// A config above the repository root must not be picked up
+		if err := os.Remove(configPath); err != nil {
+			t.Fatal(err)
+		}
+		defer os.WriteFile(configPath, []byte("project:\n  name: MyApp\n"), 0644)
+		if err := os.WriteFile(filepath.Join(root, DefaultConfigFile), []byte("project:\n  name: Outer\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(filepath.Join(root, DefaultConfigFile))
+
+		_, err := FindConfig(nested, DefaultConfigFile)
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("FindConfig() error = %v, want os.ErrNotExist", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package context
 
 import (
 	"context"
+	"time"
 
 	"github.com/macreleaser/macreleaser/pkg/config"
 	"github.com/macreleaser/macreleaser/pkg/git"
@@ -12,29 +13,49 @@ import (
 // Artifacts holds runtime output state populated by execution pipes.
 // Subsequent pipes consume this data to chain build → archive → package steps.
 type Artifacts struct {
-	BuildOutputDir   string   // dist/
-	ArchivePath      string   // path to .xcarchive
-	AppPath          string   // path to extracted .app
-	Packages         []string // paths to .zip, .dmg outputs
-	ReleaseURL       string   // HTML URL of the created GitHub release
-	HomebrewCaskPath string   // local path to the generated cask .rb file
-	ChangelogPath    string   // path to dist/CHANGELOG.md
+	BuildOutputDir   string            // dist/
+	ArchivePath      string            // path to .xcarchive
+	AppPath          string            // path to extracted .app
+	Packages         []string          // paths to .zip, .dmg outputs
+	ReleaseURL       string            // HTML URL of the created GitHub release
+	HomebrewCaskPath string            // local path to the generated cask .rb file
+	ChangelogPath    string            // path to dist/CHANGELOG.md
+	FeedPath         string            // path to the written Atom feed of releases
+	AssetNames       map[string]string // release asset name by package path, for assets uploaded under a different name
+	SkippedAssets    []string          // packages left off the release, e.g. by release.github.max_asset_size
+}
+
+// StepTiming records how long a single pipe took to run.
+type StepTiming struct {
+	Name     string        // pipe name as returned by String()
+	Duration time.Duration // wall-clock time spent in Run
+	Skipped  bool          // true if the pipe returned a skip
+	Failed   bool          // true if the pipe soft-failed and the pipeline continued
 }
 
 // Context provides shared state for all pipes
 type Context struct {
-	StdCtx         context.Context        // Standard context for cancellation support
-	Config         *config.Config
-	Logger         *logrus.Logger
-	Version        string                 // derived from git tag
-	Git            git.GitInfo            // resolved git state
-	Clean          bool                   // when true, remove dist/ before building
-	Artifacts      *Artifacts             // populated by execution pipes
-	ReleaseNotes   string                 // generated changelog for GitHub release body
-	SkipPublish    bool                   // when true, release pipe skips publishing
-	SkipNotarize   bool                   // when true, notarize pipe skips notarization
-	GitHubClient   github.ClientInterface // injectable GitHub API client
-	HomebrewClient github.ClientInterface // injectable GitHub client for tap operations
+	StdCtx          context.Context // Standard context for cancellation support
+	Config          *config.Config
+	Logger          *logrus.Logger
+	Version         string                 // derived from git tag
+	Git             git.GitInfo            // resolved git state
+	Clean           bool                   // when true, remove dist/ before building
+	Artifacts       *Artifacts             // populated by execution pipes
+	ReleaseNotes    string                 // generated changelog for GitHub release body
+	SkipPublish     bool                   // when true, release pipe skips publishing
+	Snapshot        bool                   // when true, the version is a snapshot and package names carry the short commit
+	SkipNotarize    bool                   // when true, notarize pipe skips notarization
+	SkipValidation  bool                   // when true, RunAll skips the validation stage
+	AppOnly         bool                   // when true, only the build runs; signing, notarization, packaging, and the changelog are skipped
+	ContinueOnError bool                   // when true, failures of soft-failing pipes are reported at the end instead of stopping the pipeline
+	AssumeYes       bool                   // when true (--yes), destructive actions are approved without asking
+	GitHubClient    github.ClientInterface // injectable GitHub API client
+	HomebrewClient  github.ClientInterface // injectable GitHub client for tap operations
+	Timings         []StepTiming           // per-pipe durations recorded by the pipeline runner
+
+	// ConfirmFunc asks before a destructive action; see Confirm
+	ConfirmFunc func(action string) error
 }
 
 // NewContext creates a new context with the given standard context, config, and logger.
@@ -47,7 +68,7 @@ func NewContext(stdCtx context.Context, cfg *config.Config, logger *logrus.Logge
 		StdCtx:    stdCtx,
 		Config:    cfg,
 		Logger:    logger,
-		Artifacts: &Artifacts{},
+		Artifacts: &Artifacts{AssetNames: make(map[string]string)},
 	}
 }
 
@@ -56,6 +77,16 @@ func (c *Context) Done() <-chan struct{} {
 	return c.StdCtx.Done()
 }
 
+// Confirm asks before a destructive action, such as deleting a release or
+// pushing to a tap, with ConfirmFunc. It returns an error if the action was
+// declined. Without a ConfirmFunc, as in tests, the action is approved.
+func (c *Context) Confirm(action string) error {
+	if c.ConfirmFunc == nil {
+		return nil
+	}
+	return c.ConfirmFunc(action)
+}
+
 // Err returns the error from the standard context
 func (c *Context) Err() error {
 	return c.StdCtx.Err()
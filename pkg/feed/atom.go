@@ -0,0 +1,98 @@
+// Package feed renders an Atom feed of published releases.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Entry is a single release in the feed.
+type Entry struct {
+	Title     string
+	Link      string    // HTML URL of the release, also used as its ID
+	Published time.Time // when the release was published
+	Body      string    // release notes, rendered as plain text
+}
+
+// Feed describes the feed as a whole.
+type Feed struct {
+	Title string
+	Link  string // page listing the releases, also used as the feed ID
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Link    atomLink    `xml:"link"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// Atom renders f with entries, newest first, as an Atom 1.0 document. The
+// feed's updated time is that of its newest entry, or now when it has none,
+// so regenerating an unchanged feed yields the same bytes.
+func Atom(f Feed, entries []Entry, now time.Time) ([]byte, error) {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Published.After(sorted[j].Published)
+	})
+
+	updated := now
+	if len(sorted) > 0 {
+		updated = sorted[0].Published
+	}
+
+	doc := atomFeed{
+		Title:   f.Title,
+		ID:      f.Link,
+		Link:    atomLink{Href: f.Link, Rel: "alternate"},
+		Updated: formatTime(updated),
+	}
+	for _, e := range sorted {
+		if e.Link == "" {
+			return nil, fmt.Errorf("feed entry %q has no link", e.Title)
+		}
+		doc.Entries = append(doc.Entries, atomEntry{
+			Title:     e.Title,
+			ID:        e.Link,
+			Link:      atomLink{Href: e.Link, Rel: "alternate"},
+			Published: formatTime(e.Published),
+			Updated:   formatTime(e.Published),
+			Content:   atomContent{Type: "text", Body: e.Body},
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render feed: %w", err)
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// formatTime formats t as an RFC 3339 timestamp in UTC, as Atom requires.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,61 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAtom(t *testing.T) {
+	older := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 3, 4, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	entries := []Entry{
+		{Title: "MyApp v1.0.0", Link: "https://github.com/o/r/releases/tag/v1.0.0", Published: older, Body: "First <release> & more"},
+		{Title: "MyApp v1.1.0", Link: "https://github.com/o/r/releases/tag/v1.1.0", Published: newer, Body: "Second"},
+	}
+
+	out, err := Atom(Feed{Title: "MyApp releases", Link: "https://github.com/o/r/releases"}, entries, time.Now())
+	if err != nil {
+		t.Fatalf("Atom() error = %v", err)
+	}
+	if !strings.HasPrefix(string(out), `<?xml version="1.0" encoding="UTF-8"?>`) {
+		t.Errorf("output missing XML declaration:\n%s", out)
+	}
+
+	var parsed atomFeed
+	if err := xml.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out)
+	}
+	if parsed.XMLName.Space != "http://www.w3.org/2005/Atom" {
+		t.Errorf("namespace = %q, want the Atom namespace", parsed.XMLName.Space)
+	}
+	if len(parsed.Entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(parsed.Entries))
+	}
+
+	// Newest first, timestamps in UTC, and the feed updated with the newest entry
+	if parsed.Entries[0].Title != "MyApp v1.1.0" {
+		t.Errorf("first entry = %q, want the newest release", parsed.Entries[0].Title)
+	}
+	if parsed.Entries[0].Published != "2026-03-04T11:30:00Z" || parsed.Updated != "2026-03-04T11:30:00Z" {
+		t.Errorf("published = %q, updated = %q, want 2026-03-04T11:30:00Z", parsed.Entries[0].Published, parsed.Updated)
+	}
+	if parsed.Entries[1].Content.Body != "First <release> & more" {
+		t.Errorf("content = %q, want the body round-tripped", parsed.Entries[1].Content.Body)
+	}
+}
+
+func TestAtomEmpty(t *testing.T) {
+	now := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+	out, err := Atom(Feed{Title: "MyApp releases", Link: "https://github.com/o/r/releases"}, nil, now)
+	if err != nil {
+		t.Fatalf("Atom() error = %v", err)
+	}
+	if !strings.Contains(string(out), "<updated>2026-05-06T07:08:09Z</updated>") {
+		t.Errorf("empty feed should be updated at now:\n%s", out)
+	}
+	if strings.Contains(string(out), "<entry>") {
+		t.Errorf("empty feed has entries:\n%s", out)
+	}
+}
@@ -0,0 +1,25 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// CommitFiles stages the given paths and records a commit containing only
+// those paths. Other staged changes are left untouched.
+func CommitFiles(ctx context.Context, message string, paths ...string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no files to commit")
+	}
+
+	addArgs := append([]string{"add", "--"}, paths...)
+	if _, err := gitOutput(ctx, addArgs...); err != nil {
+		return err
+	}
+
+	commitArgs := append([]string{"commit", "-m", message, "--"}, paths...)
+	if _, err := gitOutput(ctx, commitArgs...); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+func TestCommitFiles(t *testing.T) {
+	fake := &command.Fake{}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	if err := CommitFiles(ctx, "Bump version to 1.2.3", "Info.plist"); err != nil {
+		t.Fatalf("CommitFiles() unexpected error: %v", err)
+	}
+
+	want := []string{
+		"git add -- Info.plist",
+		"git commit -m Bump version to 1.2.3 -- Info.plist",
+	}
+	if got := fake.Commands(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
+
+func TestCommitFilesAddFails(t *testing.T) {
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		return "", errors.New("exit status 128: pathspec did not match")
+	}}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	err := CommitFiles(ctx, "Bump version", "Info.plist")
+	if err == nil || !strings.Contains(err.Error(), "git add -- Info.plist") {
+		t.Errorf("CommitFiles() error = %v, want the failed git add", err)
+	}
+	if got := fake.Commands(); len(got) != 1 {
+		t.Errorf("commands = %q, want no commit after git add failed", got)
+	}
+
+	if err := CommitFiles(ctx, "Bump version"); err == nil {
+		t.Error("CommitFiles() expected error without files")
+	}
+}
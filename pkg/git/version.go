@@ -1,43 +1,44 @@
 package git
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 // GitInfo holds the resolved git state for the current repository.
 type GitInfo struct {
-	Commit      string // full SHA
-	ShortCommit string // abbreviated SHA
-	Branch      string // current branch name
-	Tag         string // latest tag (empty if none)
-	Dirty       bool   // true if working tree has uncommitted changes
-	CommitCount int    // total number of commits reachable from HEAD
+	Commit      string    // full SHA
+	ShortCommit string    // abbreviated SHA
+	Branch      string    // current branch name
+	Tag         string    // latest tag (empty if none)
+	TagDate     time.Time // creation date of Tag (zero if no tag)
+	TagMessage  string    // annotation of Tag (empty if none or lightweight)
+	Dirty       bool      // true if working tree has uncommitted changes
+	CommitCount int       // total number of commits reachable from HEAD
 }
 
 // ResolveVersion derives the project version from the latest git tag
 // using `git describe --tags`. Returns a clean version string.
 // Returns an actionable error if no git tags exist.
-func ResolveVersion() (string, error) {
-	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0")
-	out, err := cmd.Output()
-	if err != nil {
-		// Check if it's because no tags exist
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			stderr := string(exitErr.Stderr)
-			if strings.Contains(stderr, "No names found") || strings.Contains(stderr, "No tags") || strings.Contains(stderr, "fatal") {
-				return "", fmt.Errorf("no git tags found — tag your release with `git tag v1.0.0`")
-			}
-		}
-		// Check if git is not installed
-		if _, pathErr := exec.LookPath("git"); pathErr != nil {
-			return "", fmt.Errorf("git is not installed or not in PATH")
+func ResolveVersion(ctx context.Context) (string, error) {
+	out, err := command.Output(ctx, "", "git", "describe", "--tags", "--abbrev=0")
+	if command.IsNotFound(err) {
+		return "", fmt.Errorf("git is not installed or not in PATH")
+	}
+	if err != nil {
+		// Check if it's because no tags exist; the error carries git's stderr
+		msg := err.Error()
+		if strings.Contains(msg, "No names found") || strings.Contains(msg, "No tags") || strings.Contains(msg, "fatal") {
+			return "", fmt.Errorf("no git tags found — tag your release with `git tag v1.0.0`")
 		}
 		return "", fmt.Errorf("failed to resolve version from git tags: %w", err)
 	}
 
-	version := strings.TrimSpace(string(out))
+	version := strings.TrimSpace(out)
 	if version == "" {
 		return "", fmt.Errorf("no git tags found — tag your release with `git tag v1.0.0`")
 	}
@@ -45,19 +46,27 @@ func ResolveVersion() (string, error) {
 	return version, nil
 }
 
+// IsPrerelease reports whether a SemVer version (with or without a "v"
+// prefix) has a prerelease segment, e.g. "v1.2.0-beta.1". Build metadata
+// after "+" is ignored.
+func IsPrerelease(version string) bool {
+	core, _, _ := strings.Cut(version, "+")
+	return strings.Contains(core, "-")
+}
+
 // FullCommit returns the full SHA of HEAD.
-func FullCommit() (string, error) {
-	return gitOutput("rev-parse", "HEAD")
+func FullCommit(ctx context.Context) (string, error) {
+	return gitOutput(ctx, "rev-parse", "HEAD")
 }
 
 // ShortCommit returns the abbreviated SHA of HEAD.
-func ShortCommit() (string, error) {
-	return gitOutput("rev-parse", "--short", "HEAD")
+func ShortCommit(ctx context.Context) (string, error) {
+	return gitOutput(ctx, "rev-parse", "--short", "HEAD")
 }
 
 // Branch returns the current branch name, or empty string if detached.
-func Branch() (string, error) {
-	out, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+func Branch(ctx context.Context) (string, error) {
+	out, err := gitOutput(ctx, "rev-parse", "--abbrev-ref", "HEAD")
 	if err != nil {
 		return "", err
 	}
@@ -68,8 +77,8 @@ func Branch() (string, error) {
 }
 
 // IsDirty returns true if the working tree has uncommitted changes.
-func IsDirty() (bool, error) {
-	out, err := gitOutput("status", "--porcelain")
+func IsDirty(ctx context.Context) (bool, error) {
+	out, err := gitOutput(ctx, "status", "--porcelain")
 	if err != nil {
 		return false, err
 	}
@@ -77,8 +86,8 @@ func IsDirty() (bool, error) {
 }
 
 // CommitCount returns the number of commits reachable from HEAD.
-func CommitCount() (int, error) {
-	out, err := gitOutput("rev-list", "--count", "HEAD")
+func CommitCount(ctx context.Context) (int, error) {
+	out, err := gitOutput(ctx, "rev-list", "--count", "HEAD")
 	if err != nil {
 		return 0, err
 	}
@@ -90,37 +99,51 @@ func CommitCount() (int, error) {
 }
 
 // ResolveGitInfo gathers the full git state for the current repository.
-func ResolveGitInfo() (GitInfo, error) {
+func ResolveGitInfo(ctx context.Context) (GitInfo, error) {
 	info := GitInfo{}
 
-	commit, err := FullCommit()
+	commit, err := FullCommit(ctx)
 	if err != nil {
 		return info, fmt.Errorf("failed to resolve git commit: %w", err)
 	}
 	info.Commit = commit
 
-	short, err := ShortCommit()
+	short, err := ShortCommit(ctx)
 	if err != nil {
 		return info, fmt.Errorf("failed to resolve short commit: %w", err)
 	}
 	info.ShortCommit = short
 
-	branch, err := Branch()
+	branch, err := Branch(ctx)
 	if err != nil {
 		return info, fmt.Errorf("failed to resolve git branch: %w", err)
 	}
 	info.Branch = branch
 
-	dirty, err := IsDirty()
+	dirty, err := IsDirty(ctx)
 	if err != nil {
 		return info, fmt.Errorf("failed to check dirty state: %w", err)
 	}
 	info.Dirty = dirty
 
-	tag, _ := ResolveVersion() // ignore error — no tag is fine
+	tag, _ := ResolveVersion(ctx) // ignore error — no tag is fine
 	info.Tag = tag
 
-	count, err := CommitCount()
+	if tag != "" {
+		date, err := TagDate(ctx, tag)
+		if err != nil {
+			return info, fmt.Errorf("failed to resolve tag date: %w", err)
+		}
+		info.TagDate = date
+
+		message, err := TagMessage(ctx, tag)
+		if err != nil {
+			return info, fmt.Errorf("failed to resolve tag message: %w", err)
+		}
+		info.TagMessage = message
+	}
+
+	count, err := CommitCount(ctx)
 	if err != nil {
 		return info, fmt.Errorf("failed to resolve commit count: %w", err)
 	}
@@ -129,10 +152,55 @@ func ResolveGitInfo() (GitInfo, error) {
 	return info, nil
 }
 
+// TagDate returns when the given tag was created: the tagger date for an
+// annotated tag, or the date of the tagged commit for a lightweight tag.
+func TagDate(ctx context.Context, tag string) (time.Time, error) {
+	out, err := tagField(ctx, tag, "%(creatordate:iso-strict)")
+	if err != nil {
+		return time.Time{}, err
+	}
+	date, err := time.Parse(time.RFC3339, out)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse date %q of tag %s: %w", out, tag, err)
+	}
+	return date, nil
+}
+
+// TagMessage returns the annotation of the given tag, without any signature.
+// Lightweight tags have no message and return an empty string.
+func TagMessage(ctx context.Context, tag string) (string, error) {
+	objectType, err := tagField(ctx, tag, "%(objecttype)")
+	if err != nil {
+		return "", err
+	}
+	if objectType != "tag" {
+		return "", nil
+	}
+	return tagField(ctx, tag, "%(contents:subject)%0a%0a%(contents:body)")
+}
+
+// tagField formats a single field of refs/tags/<tag> with git for-each-ref.
+func tagField(ctx context.Context, tag, format string) (string, error) {
+	out, err := gitOutput(ctx, "for-each-ref", "--format="+format, "refs/tags/"+tag)
+	if err != nil {
+		return "", err
+	}
+	if out == "" {
+		return "", fmt.Errorf("tag %s not found", tag)
+	}
+	return out, nil
+}
+
+// TagExists reports whether a tag with the given name exists locally.
+func TagExists(ctx context.Context, tag string) bool {
+	_, err := gitOutput(ctx, "rev-parse", "--verify", "--quiet", "refs/tags/"+tag)
+	return err == nil
+}
+
 // PreviousTag returns the tag immediately before the given tag.
 // Returns "" if no previous tag exists (i.e., the given tag is the first).
-func PreviousTag(tag string) (string, error) {
-	out, err := gitOutput("describe", "--tags", "--abbrev=0", tag+"^")
+func PreviousTag(ctx context.Context, tag string) (string, error) {
+	out, err := gitOutput(ctx, "describe", "--tags", "--abbrev=0", tag+"^")
 	if err != nil {
 		// No previous tag — this is the first tag
 		return "", nil
@@ -140,17 +208,26 @@ func PreviousTag(tag string) (string, error) {
 	return out, nil
 }
 
-// LogBetween returns commit subject lines between two refs.
-// If fromRef is empty, returns all commits up to toRef.
-func LogBetween(fromRef, toRef string) ([]string, error) {
-	var revRange string
-	if fromRef == "" {
-		revRange = toRef
-	} else {
-		revRange = fromRef + ".." + toRef
+// PreviousStableTag returns the nearest tag before the given tag that has
+// no prerelease segment, skipping intermediate prereleases such as betas.
+// Returns "" if there is no earlier stable tag.
+func PreviousStableTag(ctx context.Context, tag string) (string, error) {
+	for ref := tag; ; {
+		prev, err := PreviousTag(ctx, ref)
+		if err != nil || prev == "" {
+			return prev, err
+		}
+		if !IsPrerelease(prev) {
+			return prev, nil
+		}
+		ref = prev
 	}
+}
 
-	out, err := gitOutput("log", "--pretty=format:%s", revRange)
+// LogBetween returns commit subject lines between two refs.
+// If fromRef is empty, returns all commits up to toRef.
+func LogBetween(ctx context.Context, fromRef, toRef string) ([]string, error) {
+	out, err := gitOutput(ctx, "log", "--pretty=format:%s", logRange(fromRef, toRef))
 	if err != nil {
 		return nil, err
 	}
@@ -160,12 +237,66 @@ func LogBetween(fromRef, toRef string) ([]string, error) {
 	return strings.Split(out, "\n"), nil
 }
 
-// gitOutput runs a git command and returns its trimmed stdout.
-func gitOutput(args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
-	out, err := cmd.Output()
+// Commit is a commit's subject line and the rest of its message.
+type Commit struct {
+	Subject string
+	Body    string // empty unless requested, trailers included
+}
+
+// LogCommitsBetween returns the commits between two refs, newest first, like
+// LogBetween. With withBody set each commit's body is read as well.
+func LogCommitsBetween(ctx context.Context, fromRef, toRef string, withBody bool) ([]Commit, error) {
+	return logCommits(ctx, withBody, logRange(fromRef, toRef))
+}
+
+// LogSince returns the commits up to toRef made within d of now, newest
+// first, by committer date. git stops at the first commit older than that,
+// so a recent commit below it, such as one rebased out of date order, is not
+// listed. With withBody set each commit's body is read as well.
+func LogSince(ctx context.Context, d time.Duration, toRef string, withBody bool) ([]Commit, error) {
+	since := time.Now().Add(-d).Format(time.RFC3339)
+	return logCommits(ctx, withBody, "--since="+since, toRef)
+}
+
+// logCommits runs git log with args and parses the commits it lists.
+func logCommits(ctx context.Context, withBody bool, args ...string) ([]Commit, error) {
+	// Bodies span several lines, so separate the subject from the body with
+	// a unit separator and each commit from the next with a record separator
+	format := "%s%x1f%x1e"
+	if withBody {
+		format = "%s%x1f%b%x1e"
+	}
+	out, err := gitOutput(ctx, append([]string{"log", "--pretty=format:" + format}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	var commits []Commit
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		subject, body, _ := strings.Cut(record, "\x1f")
+		commits = append(commits, Commit{Subject: subject, Body: strings.TrimSpace(body)})
+	}
+	return commits, nil
+}
+
+// logRange returns the git log revision range for the commits after fromRef
+// up to toRef, or all commits up to toRef if fromRef is empty.
+func logRange(fromRef, toRef string) string {
+	if fromRef == "" {
+		return toRef
+	}
+	return fromRef + ".." + toRef
+}
+
+// gitOutput runs a git command with the Runner carried by ctx and returns
+// its trimmed stdout.
+func gitOutput(ctx context.Context, args ...string) (string, error) {
+	out, err := command.Output(ctx, "", "git", args...)
 	if err != nil {
 		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
 	}
-	return strings.TrimSpace(string(out)), nil
+	return strings.TrimSpace(out), nil
 }
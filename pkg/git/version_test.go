@@ -1,11 +1,15 @@
 package git
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestResolveVersion(t *testing.T) {
@@ -20,7 +24,7 @@ func TestResolveVersion(t *testing.T) {
 	}
 	defer func() { _ = os.Chdir(original) }()
 
-	version, err := ResolveVersion()
+	version, err := ResolveVersion(context.Background())
 	if err != nil {
 		t.Fatalf("ResolveVersion() error = %v", err)
 	}
@@ -41,7 +45,7 @@ func TestResolveVersionNoTags(t *testing.T) {
 	}
 	defer func() { _ = os.Chdir(original) }()
 
-	_, err = ResolveVersion()
+	_, err = ResolveVersion(context.Background())
 	if err == nil {
 		t.Fatal("ResolveVersion() expected error for repo with no tags")
 	}
@@ -54,7 +58,7 @@ func TestFullCommit(t *testing.T) {
 	dir := setupGitRepo(t, "v1.0.0")
 	chdir(t, dir)
 
-	commit, err := FullCommit()
+	commit, err := FullCommit(context.Background())
 	if err != nil {
 		t.Fatalf("FullCommit() error = %v", err)
 	}
@@ -67,7 +71,7 @@ func TestShortCommit(t *testing.T) {
 	dir := setupGitRepo(t, "v1.0.0")
 	chdir(t, dir)
 
-	short, err := ShortCommit()
+	short, err := ShortCommit(context.Background())
 	if err != nil {
 		t.Fatalf("ShortCommit() error = %v", err)
 	}
@@ -80,7 +84,7 @@ func TestBranch(t *testing.T) {
 	dir := setupGitRepo(t, "")
 	chdir(t, dir)
 
-	branch, err := Branch()
+	branch, err := Branch(context.Background())
 	if err != nil {
 		t.Fatalf("Branch() error = %v", err)
 	}
@@ -95,7 +99,7 @@ func TestIsDirty(t *testing.T) {
 	chdir(t, dir)
 
 	// Clean repo
-	dirty, err := IsDirty()
+	dirty, err := IsDirty(context.Background())
 	if err != nil {
 		t.Fatalf("IsDirty() error = %v", err)
 	}
@@ -106,7 +110,7 @@ func TestIsDirty(t *testing.T) {
 	// Make it dirty
 	writeFile(t, filepath.Join(dir, "dirty.txt"), "dirty")
 
-	dirty, err = IsDirty()
+	dirty, err = IsDirty(context.Background())
 	if err != nil {
 		t.Fatalf("IsDirty() error = %v", err)
 	}
@@ -119,7 +123,7 @@ func TestCommitCount(t *testing.T) {
 	dir := setupGitRepo(t, "")
 	chdir(t, dir)
 
-	count, err := CommitCount()
+	count, err := CommitCount(context.Background())
 	if err != nil {
 		t.Fatalf("CommitCount() error = %v", err)
 	}
@@ -132,7 +136,7 @@ func TestCommitCount(t *testing.T) {
 	runGit(t, dir, "add", ".")
 	runGit(t, dir, "commit", "-m", "second commit")
 
-	count, err = CommitCount()
+	count, err = CommitCount(context.Background())
 	if err != nil {
 		t.Fatalf("CommitCount() error = %v", err)
 	}
@@ -145,7 +149,7 @@ func TestResolveGitInfo(t *testing.T) {
 	dir := setupGitRepo(t, "v1.0.0")
 	chdir(t, dir)
 
-	info, err := ResolveGitInfo()
+	info, err := ResolveGitInfo(context.Background())
 	if err != nil {
 		t.Fatalf("ResolveGitInfo() error = %v", err)
 	}
@@ -173,13 +177,83 @@ func TestResolveGitInfoNoTags(t *testing.T) {
 	dir := setupGitRepo(t, "")
 	chdir(t, dir)
 
-	info, err := ResolveGitInfo()
+	info, err := ResolveGitInfo(context.Background())
 	if err != nil {
 		t.Fatalf("ResolveGitInfo() error = %v", err)
 	}
 	if info.Tag != "" {
 		t.Errorf("Tag = %q, want empty", info.Tag)
 	}
+	if !info.TagDate.IsZero() || info.TagMessage != "" {
+		t.Errorf("TagDate = %v, TagMessage = %q, want zero values", info.TagDate, info.TagMessage)
+	}
+}
+
+func TestTagDateAndMessage(t *testing.T) {
+	dir := setupGitRepo(t, "v1.0.0")
+	chdir(t, dir)
+
+	runGit(t, dir, "tag", "-a", "v1.1.0", "-m", "Release 1.1.0\n\nAdds the widget.")
+	// The annotated tag's date comes from the tagger, not the commit
+	cmd := exec.Command("git", "tag", "-a", "v1.2.0", "-m", "Release 1.2.0")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE=2024-03-01T12:00:00Z")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git tag failed: %v\n%s", err, out)
+	}
+
+	date, err := TagDate(context.Background(), "v1.2.0")
+	if err != nil {
+		t.Fatalf("TagDate() error = %v", err)
+	}
+	if want := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC); !date.Equal(want) {
+		t.Errorf("TagDate(annotated) = %v, want %v", date, want)
+	}
+
+	date, err = TagDate(context.Background(), "v1.0.0")
+	if err != nil {
+		t.Fatalf("TagDate() error = %v", err)
+	}
+	if date.IsZero() {
+		t.Error("TagDate(lightweight) returned zero time, want commit date")
+	}
+
+	message, err := TagMessage(context.Background(), "v1.1.0")
+	if err != nil {
+		t.Fatalf("TagMessage() error = %v", err)
+	}
+	if message != "Release 1.1.0\n\nAdds the widget." {
+		t.Errorf("TagMessage(annotated) = %q, want subject and body", message)
+	}
+
+	message, err = TagMessage(context.Background(), "v1.0.0")
+	if err != nil {
+		t.Fatalf("TagMessage() error = %v", err)
+	}
+	if message != "" {
+		t.Errorf("TagMessage(lightweight) = %q, want empty", message)
+	}
+
+	if _, err := TagDate(context.Background(), "v9.9.9"); err == nil {
+		t.Error("TagDate() expected error for missing tag")
+	}
+}
+
+func TestResolveGitInfoAnnotatedTag(t *testing.T) {
+	dir := setupGitRepo(t, "")
+	chdir(t, dir)
+	runGit(t, dir, "tag", "-a", "v1.0.0", "-m", "First release")
+
+	info, err := ResolveGitInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ResolveGitInfo() error = %v", err)
+	}
+	if info.TagMessage != "First release" {
+		t.Errorf("TagMessage = %q, want %q", info.TagMessage, "First release")
+	}
+	if info.TagDate.IsZero() {
+		t.Error("TagDate is zero, want tag creation date")
+	}
 }
 
 func TestResolveVersionLatestTag(t *testing.T) {
@@ -200,7 +274,7 @@ func TestResolveVersionLatestTag(t *testing.T) {
 	runGit(t, dir, "commit", "-m", "second commit")
 	runGit(t, dir, "tag", "v2.0.0")
 
-	version, err := ResolveVersion()
+	version, err := ResolveVersion(context.Background())
 	if err != nil {
 		t.Fatalf("ResolveVersion() error = %v", err)
 	}
@@ -219,7 +293,7 @@ func TestPreviousTag(t *testing.T) {
 	runGit(t, dir, "commit", "-m", "second commit")
 	runGit(t, dir, "tag", "v2.0.0")
 
-	prev, err := PreviousTag("v2.0.0")
+	prev, err := PreviousTag(context.Background(), "v2.0.0")
 	if err != nil {
 		t.Fatalf("PreviousTag() error = %v", err)
 	}
@@ -232,7 +306,7 @@ func TestPreviousTagNoPrevious(t *testing.T) {
 	dir := setupGitRepo(t, "v1.0.0")
 	chdir(t, dir)
 
-	prev, err := PreviousTag("v1.0.0")
+	prev, err := PreviousTag(context.Background(), "v1.0.0")
 	if err != nil {
 		t.Fatalf("PreviousTag() error = %v", err)
 	}
@@ -241,6 +315,47 @@ func TestPreviousTagNoPrevious(t *testing.T) {
 	}
 }
 
+func TestTagExists(t *testing.T) {
+	dir := setupGitRepo(t, "v1.0.0")
+	chdir(t, dir)
+
+	if !TagExists(context.Background(), "v1.0.0") {
+		t.Error("TagExists(\"v1.0.0\") = false, want true")
+	}
+	if TagExists(context.Background(), "v9.9.9") {
+		t.Error("TagExists(\"v9.9.9\") = true, want false")
+	}
+}
+
+func TestPreviousStableTag(t *testing.T) {
+	dir := setupGitRepo(t, "v1.0.0")
+	chdir(t, dir)
+
+	for i, tag := range []string{"v1.1.0-beta.1", "v1.1.0-beta.2", "v1.1.0", "v1.2.0-rc.1", "v1.2.0"} {
+		writeFile(t, filepath.Join(dir, fmt.Sprintf("file%d.txt", i)), tag)
+		runGit(t, dir, "add", ".")
+		runGit(t, dir, "commit", "-m", "commit for "+tag)
+		runGit(t, dir, "tag", tag)
+	}
+
+	tests := map[string]string{
+		"v1.2.0":        "v1.1.0",
+		"v1.2.0-rc.1":   "v1.1.0",
+		"v1.1.0":        "v1.0.0",
+		"v1.1.0-beta.2": "v1.0.0",
+		"v1.0.0":        "",
+	}
+	for tag, want := range tests {
+		got, err := PreviousStableTag(context.Background(), tag)
+		if err != nil {
+			t.Fatalf("PreviousStableTag(%q) error = %v", tag, err)
+		}
+		if got != want {
+			t.Errorf("PreviousStableTag(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
 func TestLogBetween(t *testing.T) {
 	dir := setupGitRepo(t, "v1.0.0")
 	chdir(t, dir)
@@ -255,7 +370,7 @@ func TestLogBetween(t *testing.T) {
 	runGit(t, dir, "commit", "-m", "fix: resolve crash")
 	runGit(t, dir, "tag", "v2.0.0")
 
-	logs, err := LogBetween("v1.0.0", "v2.0.0")
+	logs, err := LogBetween(context.Background(), "v1.0.0", "v2.0.0")
 	if err != nil {
 		t.Fatalf("LogBetween() error = %v", err)
 	}
@@ -275,7 +390,7 @@ func TestLogBetweenEmptyFrom(t *testing.T) {
 	dir := setupGitRepo(t, "v1.0.0")
 	chdir(t, dir)
 
-	logs, err := LogBetween("", "v1.0.0")
+	logs, err := LogBetween(context.Background(), "", "v1.0.0")
 	if err != nil {
 		t.Fatalf("LogBetween() error = %v", err)
 	}
@@ -287,6 +402,80 @@ func TestLogBetweenEmptyFrom(t *testing.T) {
 	}
 }
 
+func TestLogCommitsBetween(t *testing.T) {
+	dir := setupGitRepo(t, "v1.0.0")
+	chdir(t, dir)
+
+	writeFile(t, filepath.Join(dir, "file2.txt"), "content2")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "feat: add widget", "-m", "Adds the widget.\n\nFixes #12\nCo-authored-by: Jane Doe <jane@example.com>")
+
+	writeFile(t, filepath.Join(dir, "file3.txt"), "content3")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "fix: resolve crash")
+	runGit(t, dir, "tag", "v2.0.0")
+
+	commits, err := LogCommitsBetween(context.Background(), "v1.0.0", "v2.0.0", true)
+	if err != nil {
+		t.Fatalf("LogCommitsBetween() error = %v", err)
+	}
+	want := []Commit{
+		{Subject: "fix: resolve crash"},
+		{Subject: "feat: add widget", Body: "Adds the widget.\n\nFixes #12\nCo-authored-by: Jane Doe <jane@example.com>"},
+	}
+	if !reflect.DeepEqual(commits, want) {
+		t.Errorf("LogCommitsBetween() = %q, want %q", commits, want)
+	}
+
+	commits, err = LogCommitsBetween(context.Background(), "v1.0.0", "v2.0.0", false)
+	if err != nil {
+		t.Fatalf("LogCommitsBetween() error = %v", err)
+	}
+	if len(commits) != 2 || commits[1].Subject != "feat: add widget" || commits[1].Body != "" {
+		t.Errorf("LogCommitsBetween() without bodies = %q", commits)
+	}
+}
+
+func TestLogSince(t *testing.T) {
+	// commitAt makes the following commits look as if they were made ago
+	commitAt := func(ago time.Duration) {
+		date := time.Now().Add(-ago).Format(time.RFC3339)
+		t.Setenv("GIT_AUTHOR_DATE", date)
+		t.Setenv("GIT_COMMITTER_DATE", date)
+	}
+
+	commitAt(30 * 24 * time.Hour)
+	dir := setupGitRepo(t, "v1.0.0")
+	chdir(t, dir)
+
+	commitAt(10 * 24 * time.Hour)
+	runGit(t, dir, "commit", "--allow-empty", "-m", "feat: ten days ago")
+	commitAt(3 * 24 * time.Hour)
+	runGit(t, dir, "commit", "--allow-empty", "-m", "fix: three days ago", "-m", "Fixes #3")
+	commitAt(time.Hour)
+	runGit(t, dir, "commit", "--allow-empty", "-m", "docs: an hour ago")
+
+	commits, err := LogSince(context.Background(), 7*24*time.Hour, "HEAD", true)
+	if err != nil {
+		t.Fatalf("LogSince() error = %v", err)
+	}
+	want := []Commit{
+		{Subject: "docs: an hour ago"},
+		{Subject: "fix: three days ago", Body: "Fixes #3"},
+	}
+	if !reflect.DeepEqual(commits, want) {
+		t.Errorf("LogSince() = %q, want %q", commits, want)
+	}
+
+	commits, err = LogSince(context.Background(), 30*time.Minute, "HEAD", false)
+	if err != nil {
+		t.Fatalf("LogSince() error = %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("LogSince() = %q, want no commits", commits)
+	}
+}
+
 // setupGitRepo creates a temporary git repo and returns its path.
 // If git init is not possible (e.g., in a restricted sandbox), the test is skipped.
 func setupGitRepo(t *testing.T, tag string) string {
@@ -349,3 +538,24 @@ func writeFile(t *testing.T, path, content string) {
 		t.Fatal(err)
 	}
 }
+
+func TestIsPrerelease(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"v1.2.3", false},
+		{"1.2.3", false},
+		{"v1.2.3-beta.1", true},
+		{"1.0.0-rc1", true},
+		{"v1.2.3+build.5", false},
+		{"v1.2.3-alpha+build-5", true},
+		{"v1.2.3+build-5", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPrerelease(tt.version); got != tt.want {
+			t.Errorf("IsPrerelease(%q) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
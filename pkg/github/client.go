@@ -4,17 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/github"
+	"github.com/macreleaser/macreleaser/pkg/retry"
+	"github.com/macreleaser/macreleaser/pkg/version"
 	"golang.org/x/oauth2"
 )
 
 // NotFoundError represents a resource not found condition.
-// Used by the mock client and checked by IsNotFound.
+// Used by the mock client, and for missing resources GitHub reports with a
+// status other than 404, and checked by IsNotFound.
 type NotFoundError struct {
 	Message string
 }
@@ -37,12 +43,16 @@ type ClientInterface interface {
 	GetRepository(ctx context.Context, owner, repo string) (*github.Repository, error)
 	GetRelease(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, error)
 	ListReleases(ctx context.Context, owner, repo string) ([]*github.RepositoryRelease, error)
-	CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, error)
-	UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, assetPath, contentType string) (*github.ReleaseAsset, error)
+	CreateRelease(ctx context.Context, owner, repo string, release *ReleaseRequest) (*github.RepositoryRelease, error)
+	UpdateRelease(ctx context.Context, owner, repo string, id int64, release *ReleaseRequest) (*github.RepositoryRelease, error)
+	DeleteRelease(ctx context.Context, owner, repo string, id int64) error
+	DeleteTag(ctx context.Context, owner, repo, tag string) error
+	UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, assetPath, name, contentType string, progress ProgressFunc) (*github.ReleaseAsset, error)
 	GetAuthenticatedUser(ctx context.Context) (*github.User, error)
 	ForkRepository(ctx context.Context, owner, repo string) (*github.Repository, error)
 	CreatePullRequest(ctx context.Context, owner, repo string, pr *github.NewPullRequest) (*github.PullRequest, error)
 	GetFileContents(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, error)
+	GetBlob(ctx context.Context, owner, repo, sha string) ([]byte, error)
 	CreateFile(ctx context.Context, owner, repo, path, message string, content []byte) error
 	UpdateFile(ctx context.Context, owner, repo, path, message string, content []byte, sha string) error
 }
@@ -52,27 +62,147 @@ var _ ClientInterface = (*Client)(nil)
 
 // Client wraps the GitHub client with convenience methods
 type Client struct {
-	client *github.Client
+	client     *github.Client
+	httpClient *http.Client
 }
 
-// NewClient creates a new GitHub client with the provided token for authentication.
+// DefaultTimeout bounds each GitHub request, including asset uploads, when
+// release.github.timeout is not set.
+const DefaultTimeout = 5 * time.Minute
+
+// ParseTimeout parses a release.github.timeout value such as "30m".
+// An empty value yields DefaultTimeout.
+func ParseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return DefaultTimeout, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid release.github.timeout %q: %w", value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid release.github.timeout %q: must be positive", value)
+	}
+	return d, nil
+}
+
+// NewClient creates a new GitHub client with the provided token for authentication
+// and the default request timeout.
 // If token is empty, an error is returned since GitHub operations require authentication.
 func NewClient(token string) (*Client, error) {
+	return NewClientWithTimeout(token, DefaultTimeout)
+}
+
+// NewClientWithTimeout creates a new GitHub client whose requests are bounded
+// by timeout. A non-positive timeout falls back to DefaultTimeout.
+func NewClientWithTimeout(token string, timeout time.Duration) (*Client, error) {
+	return NewClientWithOptions(token, ClientOptions{Timeout: timeout})
+}
+
+// ClientOptions configures a client created with NewClientWithOptions.
+type ClientOptions struct {
+	// Timeout bounds each request, including its retries and the waits
+	// between them. A non-positive value falls back to DefaultTimeout.
+	Timeout time.Duration
+
+	// Retry is the policy for resending requests that fail transiently.
+	// A zero value falls back to retry.Default.
+	Retry retry.Policy
+
+	// Headers are set on every request, and on the CONNECT request to an
+	// HTTPS_PROXY, for example to authenticate with a corporate proxy.
+	Headers map[string]string
+}
+
+// NewClientWithOptions creates a new GitHub client configured by opts.
+func NewClientWithOptions(token string, opts ClientOptions) (*Client, error) {
 	if token == "" {
 		return nil, fmt.Errorf("GitHub token is required")
 	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	policy := opts.Retry
+	if policy.MaxAttempts <= 0 {
+		policy = retry.Default
+	}
 
-	// Configure HTTP client with timeouts to prevent hanging
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	httpClient := oauth2.NewClient(context.Background(), ts)
-	// oauth2.NewClient returns a client without timeout, so we set it explicitly
-	httpClient.Timeout = 5 * time.Minute
+	httpClient := &http.Client{
+		Transport: &oauth2.Transport{Source: ts, Base: newRetryTransport(newTransport(timeout, opts.Headers), policy)},
+		Timeout:   timeout,
+	}
+
+	// Identify the release tool in GitHub's audit logs instead of go-github
+	client := github.NewClient(httpClient)
+	client.UserAgent = version.UserAgent()
 
 	return &Client{
-		client: github.NewClient(httpClient),
+		client:     client,
+		httpClient: httpClient,
 	}, nil
 }
 
+// NewDownloadClient creates an unauthenticated HTTP client for fetching
+// release assets. Like the API client, it goes through HTTPS_PROXY and sets
+// headers on every request and on the proxy CONNECT request, so downloads
+// pass the same proxy or gateway as uploads. It follows redirects, such as
+// the one from github.com to the CDN that serves an asset.
+func NewDownloadClient(timeout time.Duration, headers map[string]string) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Transport: newTransport(timeout, headers), Timeout: timeout}
+}
+
+// newTransport returns the transport shared by the API and download clients.
+func newTransport(timeout time.Duration, headers map[string]string) http.RoundTripper {
+	// Use a dedicated transport rather than http.DefaultTransport so the
+	// response header wait is bounded by the same timeout as the request
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.ResponseHeaderTimeout = timeout
+	base.Proxy = http.ProxyFromEnvironment
+
+	if len(headers) == 0 {
+		return base
+	}
+	base.ProxyConnectHeader = make(http.Header, len(headers))
+	for name, value := range headers {
+		base.ProxyConnectHeader.Set(name, value)
+	}
+	return &headerTransport{base: base, headers: headers}
+}
+
+// headerTransport sets static headers on each request before sending it.
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// ValidateHeaders checks release.github.headers. Authorization is rejected
+// because it would replace the GitHub token.
+func ValidateHeaders(headers map[string]string) error {
+	for name := range headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return fmt.Errorf("release.github.headers contains an invalid header name %q", name)
+		}
+		if strings.EqualFold(name, "Authorization") {
+			return fmt.Errorf("release.github.headers cannot set Authorization — the GitHub token is sent in it")
+		}
+	}
+	return nil
+}
+
 // GetGitHubToken retrieves GitHub token from environment
 func GetGitHubToken() string {
 	return os.Getenv("GITHUB_TOKEN")
@@ -117,17 +247,70 @@ func (c *Client) ListReleases(ctx context.Context, owner, repo string) ([]*githu
 	return allReleases, nil
 }
 
-// CreateRelease creates a new release
-func (c *Client) CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, error) {
-	newRelease, _, err := c.client.Repositories.CreateRelease(ctx, owner, repo, release)
+// CreateRelease creates a new release. The request is sent directly rather
+// than through Repositories.CreateRelease so that ReleaseRequest's extra
+// fields reach the API.
+func (c *Client) CreateRelease(ctx context.Context, owner, repo string, release *ReleaseRequest) (*github.RepositoryRelease, error) {
+	req, err := c.client.NewRequest("POST", fmt.Sprintf("repos/%s/%s/releases", owner, repo), release)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create release %s/%s: %w", owner, repo, err)
 	}
+	newRelease := new(github.RepositoryRelease)
+	if _, err := c.client.Do(ctx, req, newRelease); err != nil {
+		return nil, fmt.Errorf("failed to create release %s/%s: %w", owner, repo, err)
+	}
 	return newRelease, nil
 }
 
-// UploadReleaseAsset uploads an asset to a release
-func (c *Client) UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, assetPath, contentType string) (*github.ReleaseAsset, error) {
+// UpdateRelease edits an existing release, for example to publish a draft.
+// Only the fields set in release are changed.
+func (c *Client) UpdateRelease(ctx context.Context, owner, repo string, id int64, release *ReleaseRequest) (*github.RepositoryRelease, error) {
+	req, err := c.client.NewRequest("PATCH", fmt.Sprintf("repos/%s/%s/releases/%d", owner, repo, id), release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update release %d in %s/%s: %w", id, owner, repo, err)
+	}
+	updated := new(github.RepositoryRelease)
+	if _, err := c.client.Do(ctx, req, updated); err != nil {
+		return nil, fmt.Errorf("failed to update release %d in %s/%s: %w", id, owner, repo, err)
+	}
+	return updated, nil
+}
+
+// DeleteRelease deletes a release and its assets. The release's tag is left
+// in place.
+func (c *Client) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
+	if _, err := c.client.Repositories.DeleteRelease(ctx, owner, repo, id); err != nil {
+		return fmt.Errorf("failed to delete release %d in %s/%s: %w", id, owner, repo, err)
+	}
+	return nil
+}
+
+// DeleteTag deletes a tag from the repository. GitHub answers 422
+// "Reference does not exist" rather than 404 for a missing tag, which is
+// returned as a NotFoundError.
+func (c *Client) DeleteTag(ctx context.Context, owner, repo, tag string) error {
+	if _, err := c.client.Git.DeleteRef(ctx, owner, repo, "tags/"+tag); err != nil {
+		if isMissingReference(err) {
+			return &NotFoundError{Message: fmt.Sprintf("tag %s does not exist in %s/%s", tag, owner, repo)}
+		}
+		return fmt.Errorf("failed to delete tag %s in %s/%s: %w", tag, owner, repo, err)
+	}
+	return nil
+}
+
+// isMissingReference returns true if err is GitHub's 422 response to
+// deleting a git reference that does not exist.
+func isMissingReference(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil &&
+		ghErr.Response.StatusCode == http.StatusUnprocessableEntity &&
+		strings.Contains(ghErr.Message, "Reference does not exist")
+}
+
+// UploadReleaseAsset uploads the file at assetPath to a release under name,
+// or under the file's own name when name is empty. A non-nil progress is
+// called periodically while a long upload is in flight.
+func (c *Client) UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, assetPath, name, contentType string, progress ProgressFunc) (*github.ReleaseAsset, error) {
 	// Validate asset path to prevent directory traversal attacks
 	absPath, err := filepath.Abs(assetPath)
 	if err != nil {
@@ -165,18 +348,37 @@ func (c *Client) UploadReleaseAsset(ctx context.Context, owner, repo string, rel
 		return nil, fmt.Errorf("opened asset file cannot be a symbolic link")
 	}
 
-	uploadOpts := &github.UploadOptions{
-		Name: filepath.Base(assetPath),
+	// The request is built here rather than through
+	// Repositories.UploadReleaseAsset, which only accepts an *os.File and
+	// so cannot report progress
+	var body io.Reader = file
+	if progress != nil {
+		body = newProgressReader(file, openedInfo.Size(), progress)
 	}
-
-	asset, _, err := c.client.Repositories.UploadReleaseAsset(ctx, owner, repo, releaseID, uploadOpts, file)
+	query := url.Values{"name": {uploadOptions(assetPath, name).Name}}
+	u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", owner, repo, releaseID, query.Encode())
+	req, err := c.client.NewUploadRequest(u, body, openedInfo.Size(), contentType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload asset to release %d: %w", releaseID, err)
 	}
 
+	asset := new(github.ReleaseAsset)
+	if _, err := c.client.Do(ctx, req, asset); err != nil {
+		return nil, fmt.Errorf("failed to upload asset to release %d: %w", releaseID, err)
+	}
+
 	return asset, nil
 }
 
+// uploadOptions returns the options an asset is uploaded with, naming it after
+// the base name of assetPath when name is empty.
+func uploadOptions(assetPath, name string) *github.UploadOptions {
+	if name == "" {
+		name = filepath.Base(assetPath)
+	}
+	return &github.UploadOptions{Name: name}
+}
+
 // GetAuthenticatedUser returns the authenticated GitHub user
 func (c *Client) GetAuthenticatedUser(ctx context.Context) (*github.User, error) {
 	user, _, err := c.client.Users.Get(ctx, "")
@@ -204,15 +406,61 @@ func (c *Client) CreatePullRequest(ctx context.Context, owner, repo string, pr *
 	return newPR, nil
 }
 
-// GetFileContents retrieves the contents of a file in a repository
+// GetFileContents retrieves the contents of a file in a repository.
+// Files too large for the Contents API are fetched through the Git blobs API.
 func (c *Client) GetFileContents(ctx context.Context, owner, repo, path string) (*github.RepositoryContent, error) {
 	content, _, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get contents of %s in %s/%s: %w", path, owner, repo, err)
 	}
+	if err := resolveLargeContent(ctx, c, owner, repo, content); err != nil {
+		return nil, fmt.Errorf("failed to get contents of %s in %s/%s: %w", path, owner, repo, err)
+	}
 	return content, nil
 }
 
+// GetBlob retrieves the raw content of a Git blob by SHA
+func (c *Client) GetBlob(ctx context.Context, owner, repo, sha string) ([]byte, error) {
+	data, _, err := c.client.Git.GetBlobRaw(ctx, owner, repo, sha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s in %s/%s: %w", sha, owner, repo, err)
+	}
+	return data, nil
+}
+
+// maxBlobSize is the largest blob GetFileContents will fetch. It matches the
+// Git blobs API limit; anything larger cannot be retrieved through the API.
+const maxBlobSize = 100 * 1024 * 1024 // 100MB
+
+// blobGetter fetches raw Git blob content by SHA.
+type blobGetter interface {
+	GetBlob(ctx context.Context, owner, repo, sha string) ([]byte, error)
+}
+
+// resolveLargeContent fills in the content of a file that the Contents API
+// returned without a body. GitHub omits content for files above 1MB and
+// reports encoding "none"; the raw content is fetched from the blobs API and
+// stored unencoded so GetContent returns it as-is.
+func resolveLargeContent(ctx context.Context, bg blobGetter, owner, repo string, content *github.RepositoryContent) error {
+	if content == nil || content.GetEncoding() != "none" {
+		return nil
+	}
+	if content.GetSize() > maxBlobSize {
+		return fmt.Errorf("file is %d bytes, larger than the %d byte blob API limit", content.GetSize(), maxBlobSize)
+	}
+
+	data, err := bg.GetBlob(ctx, owner, repo, content.GetSHA())
+	if err != nil {
+		return err
+	}
+
+	raw := string(data)
+	encoding := ""
+	content.Content = &raw
+	content.Encoding = &encoding
+	return nil
+}
+
 // CreateFile creates a new file in a repository via the Contents API
 func (c *Client) CreateFile(ctx context.Context, owner, repo, path, message string, content []byte) error {
 	opts := &github.RepositoryContentFileOptions{
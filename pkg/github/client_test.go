@@ -0,0 +1,359 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/github"
+	"github.com/macreleaser/macreleaser/pkg/retry"
+	"golang.org/x/oauth2"
+)
+
+func TestGetFileContentsSmallFile(t *testing.T) {
+	mock := NewMockClient()
+	encoded := base64.StdEncoding.EncodeToString([]byte("cask \"myapp\" do\nend\n"))
+	mock.AddFileContent("owner", "homebrew-tap", "Casks/myapp.rb", &github.RepositoryContent{
+		SHA:      github.String("abc123"),
+		Encoding: github.String("base64"),
+		Content:  github.String(encoded),
+		Size:     github.Int(21),
+	})
+
+	content, err := mock.GetFileContents(context.Background(), "owner", "homebrew-tap", "Casks/myapp.rb")
+	if err != nil {
+		t.Fatalf("GetFileContents() unexpected error: %v", err)
+	}
+
+	got, err := content.GetContent()
+	if err != nil {
+		t.Fatalf("GetContent() unexpected error: %v", err)
+	}
+	if got != "cask \"myapp\" do\nend\n" {
+		t.Errorf("GetContent() = %q, want cask content", got)
+	}
+	if content.GetSHA() != "abc123" {
+		t.Errorf("SHA = %q, want %q", content.GetSHA(), "abc123")
+	}
+}
+
+func TestGetFileContentsLargeFile(t *testing.T) {
+	mock := NewMockClient()
+	large := strings.Repeat("x", 2*1024*1024)
+
+	// GitHub omits content above 1MB and reports encoding "none"
+	mock.AddFileContent("owner", "homebrew-tap", "Casks/myapp.rb", &github.RepositoryContent{
+		SHA:      github.String("def456"),
+		Encoding: github.String("none"),
+		Size:     github.Int(len(large)),
+	})
+	mock.AddBlob("owner", "homebrew-tap", "def456", []byte(large))
+
+	content, err := mock.GetFileContents(context.Background(), "owner", "homebrew-tap", "Casks/myapp.rb")
+	if err != nil {
+		t.Fatalf("GetFileContents() unexpected error: %v", err)
+	}
+
+	got, err := content.GetContent()
+	if err != nil {
+		t.Fatalf("GetContent() unexpected error: %v", err)
+	}
+	if got != large {
+		t.Errorf("GetContent() returned %d bytes, want %d", len(got), len(large))
+	}
+	if content.GetSHA() != "def456" {
+		t.Errorf("SHA = %q, want %q", content.GetSHA(), "def456")
+	}
+}
+
+func TestGetFileContentsLargeFileMissingBlob(t *testing.T) {
+	mock := NewMockClient()
+	mock.AddFileContent("owner", "homebrew-tap", "Casks/myapp.rb", &github.RepositoryContent{
+		SHA:      github.String("def456"),
+		Encoding: github.String("none"),
+		Size:     github.Int(2 * 1024 * 1024),
+	})
+
+	_, err := mock.GetFileContents(context.Background(), "owner", "homebrew-tap", "Casks/myapp.rb")
+	if err == nil {
+		t.Fatal("GetFileContents() expected error for missing blob, got nil")
+	}
+	if !strings.Contains(err.Error(), "blob def456 not found") {
+		t.Errorf("GetFileContents() error = %q, want error containing %q", err.Error(), "blob def456 not found")
+	}
+}
+
+func TestGetFileContentsExceedsBlobLimit(t *testing.T) {
+	mock := NewMockClient()
+	mock.AddFileContent("owner", "homebrew-tap", "Casks/myapp.rb", &github.RepositoryContent{
+		SHA:      github.String("def456"),
+		Encoding: github.String("none"),
+		Size:     github.Int(maxBlobSize + 1),
+	})
+
+	_, err := mock.GetFileContents(context.Background(), "owner", "homebrew-tap", "Casks/myapp.rb")
+	if err == nil {
+		t.Fatal("GetFileContents() expected error for oversized file, got nil")
+	}
+	if !strings.Contains(err.Error(), "blob API limit") {
+		t.Errorf("GetFileContents() error = %q, want error containing %q", err.Error(), "blob API limit")
+	}
+}
+
+func TestNewClientWithTimeout(t *testing.T) {
+	c, err := NewClientWithTimeout("ghp_test", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewClientWithTimeout() unexpected error: %v", err)
+	}
+	if c.httpClient.Timeout != 50*time.Millisecond {
+		t.Errorf("http client Timeout = %v, want %v", c.httpClient.Timeout, 50*time.Millisecond)
+	}
+	transport, ok := c.httpClient.Transport.(*oauth2.Transport)
+	if !ok {
+		t.Fatalf("http client Transport = %T, want *oauth2.Transport", c.httpClient.Transport)
+	}
+	rt, ok := transport.Base.(*retryTransport)
+	if !ok {
+		t.Fatalf("oauth2 base transport = %T, want *retryTransport", transport.Base)
+	}
+	if rt.policy != retry.Default {
+		t.Errorf("retry policy = %+v, want %+v", rt.policy, retry.Default)
+	}
+	base, ok := rt.base.(*http.Transport)
+	if !ok {
+		t.Fatalf("retry base transport = %T, want *http.Transport", rt.base)
+	}
+	if base.ResponseHeaderTimeout != 50*time.Millisecond {
+		t.Errorf("ResponseHeaderTimeout = %v, want %v", base.ResponseHeaderTimeout, 50*time.Millisecond)
+	}
+
+	c, err = NewClient("ghp_test")
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	if c.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("default Timeout = %v, want %v", c.httpClient.Timeout, DefaultTimeout)
+	}
+}
+
+func TestNewClientUserAgent(t *testing.T) {
+	c, err := NewClient("ghp_test")
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error: %v", err)
+	}
+	if c.client.UserAgent != "macreleaser/dev" {
+		t.Errorf("UserAgent = %q, want %q", c.client.UserAgent, "macreleaser/dev")
+	}
+
+	req, err := c.client.NewRequest("GET", "user", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() unexpected error: %v", err)
+	}
+	if got := req.Header.Get("User-Agent"); got != "macreleaser/dev" {
+		t.Errorf("request User-Agent = %q, want %q", got, "macreleaser/dev")
+	}
+}
+
+func TestNewClientWithOptionsHeadersAndProxy(t *testing.T) {
+	headers := map[string]string{"Proxy-Authorization": "Bearer proxy-token", "X-Corp-Route": "github"}
+	c, err := NewClientWithOptions("ghp_test", ClientOptions{Headers: headers})
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() unexpected error: %v", err)
+	}
+
+	rt := c.httpClient.Transport.(*oauth2.Transport).Base.(*retryTransport)
+	ht, ok := rt.base.(*headerTransport)
+	if !ok {
+		t.Fatalf("retry base transport = %T, want *headerTransport", rt.base)
+	}
+	base := ht.base.(*http.Transport)
+	if base.Proxy == nil || reflect.ValueOf(base.Proxy).Pointer() != reflect.ValueOf(http.ProxyFromEnvironment).Pointer() {
+		t.Error("transport does not take its proxy from HTTPS_PROXY/NO_PROXY")
+	}
+	if got := base.ProxyConnectHeader.Get("Proxy-Authorization"); got != "Bearer proxy-token" {
+		t.Errorf("ProxyConnectHeader Proxy-Authorization = %q, want %q", got, "Bearer proxy-token")
+	}
+
+	// Swap in a recording RoundTripper to see what reaches the network
+	var got *http.Request
+	ht.base = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		return statusResponse(http.StatusOK), nil
+	})
+	req, _ := http.NewRequest("GET", "https://api.github.com/user", nil)
+	if _, err := c.httpClient.Do(req); err != nil {
+		t.Fatalf("Do() unexpected error: %v", err)
+	}
+	for name, value := range headers {
+		if got.Header.Get(name) != value {
+			t.Errorf("header %s = %q, want %q", name, got.Header.Get(name), value)
+		}
+	}
+	if got.Header.Get("Authorization") != "Bearer ghp_test" {
+		t.Errorf("Authorization = %q, want the GitHub token", got.Header.Get("Authorization"))
+	}
+	if req.Header.Get("X-Corp-Route") != "" {
+		t.Error("headerTransport modified the caller's request")
+	}
+}
+
+func TestValidateHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		errMsg  string
+	}{
+		{name: "none"},
+		{name: "valid", headers: map[string]string{"Proxy-Authorization": "Basic abc"}},
+		{name: "invalid name", headers: map[string]string{"Bad Header": "x"}, errMsg: "invalid header name"},
+		{name: "authorization", headers: map[string]string{"authorization": "token x"}, errMsg: "cannot set Authorization"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHeaders(tt.headers)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("ValidateHeaders() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ValidateHeaders() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: DefaultTimeout},
+		{value: "30m", want: 30 * time.Minute},
+		{value: "45s", want: 45 * time.Second},
+		{value: "0s", wantErr: true},
+		{value: "-1m", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeout(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTimeout(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeout(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestUploadOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "", want: "MyApp-v1.2.0.dmg"},
+		{name: "MyApp-latest.dmg", want: "MyApp-latest.dmg"},
+	}
+
+	for _, tt := range tests {
+		got := uploadOptions("dist/MyApp-v1.2.0.dmg", tt.name)
+		if got.Name != tt.want {
+			t.Errorf("uploadOptions(%q).Name = %q, want %q", tt.name, got.Name, tt.want)
+		}
+	}
+}
+
+func TestUploadReleaseAsset(t *testing.T) {
+	var gotPath, gotName, gotType string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotName, gotType = r.URL.Path, r.URL.Query().Get("name"), r.Header.Get("Content-Type")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name": "MyApp-latest.dmg"}`))
+	}))
+	defer srv.Close()
+
+	gh := github.NewClient(nil)
+	base, _ := url.Parse(srv.URL + "/")
+	gh.BaseURL, gh.UploadURL = base, base
+	client := &Client{client: gh}
+
+	assetPath := filepath.Join(t.TempDir(), "MyApp-v1.2.0.dmg")
+	if err := os.WriteFile(assetPath, []byte("disk image"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	asset, err := client.UploadReleaseAsset(context.Background(), "owner", "repo", 42, assetPath, "MyApp-latest.dmg", "application/x-apple-diskimage", func(int64, int64) {})
+	if err != nil {
+		t.Fatalf("UploadReleaseAsset() unexpected error: %v", err)
+	}
+	if asset.GetName() != "MyApp-latest.dmg" {
+		t.Errorf("asset name = %q, want %q", asset.GetName(), "MyApp-latest.dmg")
+	}
+	if gotPath != "/repos/owner/repo/releases/42/assets" || gotName != "MyApp-latest.dmg" {
+		t.Errorf("uploaded to %s?name=%s, want /repos/owner/repo/releases/42/assets?name=MyApp-latest.dmg", gotPath, gotName)
+	}
+	if gotType != "application/x-apple-diskimage" {
+		t.Errorf("Content-Type = %q, want %q", gotType, "application/x-apple-diskimage")
+	}
+	if string(gotBody) != "disk image" {
+		t.Errorf("body = %q, want %q", gotBody, "disk image")
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{name: "deleted", status: http.StatusNoContent},
+		{name: "missing tag", status: http.StatusUnprocessableEntity, body: `{"message": "Reference does not exist"}`, wantErr: true, wantNotFound: true},
+		{name: "other validation failure", status: http.StatusUnprocessableEntity, body: `{"message": "Validation Failed"}`, wantErr: true},
+		{name: "forbidden", status: http.StatusForbidden, body: `{"message": "Resource not accessible by integration"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotMethod, gotPath = r.Method, r.URL.Path
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			gh := github.NewClient(nil)
+			gh.BaseURL, _ = url.Parse(srv.URL + "/")
+			client := &Client{client: gh}
+
+			err := client.DeleteTag(context.Background(), "owner", "repo", "nightly")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsNotFound(err) != tt.wantNotFound {
+				t.Errorf("IsNotFound(%v) = %v, want %v", err, IsNotFound(err), tt.wantNotFound)
+			}
+			if gotMethod != http.MethodDelete || gotPath != "/repos/owner/repo/git/refs/tags/nightly" {
+				t.Errorf("request = %s %s, want DELETE /repos/owner/repo/git/refs/tags/nightly", gotMethod, gotPath)
+			}
+		})
+	}
+}
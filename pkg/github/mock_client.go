@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/google/go-github/github"
 )
@@ -12,27 +13,44 @@ var _ ClientInterface = (*MockClient)(nil)
 
 // MockClient is a mock implementation of the GitHub client for testing
 type MockClient struct {
-	Repositories  map[string]*github.Repository
-	Releases      map[string][]*github.RepositoryRelease
-	Users         map[string]*github.User
-	UploadedAssets []string // tracks asset paths passed to UploadReleaseAsset
-	FileContents   map[string]*github.RepositoryContent // key: "owner/repo/path"
-	CreatedFiles   map[string][]byte                    // key: "owner/repo/path", value: content
-	UpdatedFiles   map[string][]byte                    // key: "owner/repo/path", value: content
-	ErrorToReturn  error
-	UploadError    error // if non-nil, returned by UploadReleaseAsset instead of ErrorToReturn
-	ContentsError  error // if non-nil, returned by GetFileContents instead of ErrorToReturn
+	Repositories    map[string]*github.Repository
+	Releases        map[string][]*github.RepositoryRelease
+	ReleaseRequests []*ReleaseRequest // tracks requests passed to CreateRelease
+	ReleaseUpdates  []*ReleaseRequest // tracks requests passed to UpdateRelease
+	DeletedReleases []int64           // tracks IDs passed to DeleteRelease
+	DeletedTags     []string          // tracks tags passed to DeleteTag
+	Users           map[string]*github.User
+	UploadedAssets  []string                             // tracks asset paths passed to UploadReleaseAsset
+	ContentTypes    map[string]string                    // key: asset path, value: content type passed to UploadReleaseAsset
+	AssetNames      map[string]string                    // key: asset path, value: name the asset was uploaded as
+	FileContents    map[string]*github.RepositoryContent // key: "owner/repo/path"
+	Blobs           map[string][]byte                    // key: "owner/repo/sha", value: raw content
+	CreatedFiles    map[string][]byte                    // key: "owner/repo/path", value: content
+	UpdatedFiles    map[string][]byte                    // key: "owner/repo/path", value: content
+	CommitMessages  map[string]string                    // key: "owner/repo/path", value: message passed to CreateFile or UpdateFile
+	ErrorToReturn   error
+	UploadError     error // if non-nil, returned by UploadReleaseAsset instead of ErrorToReturn
+	ContentsError   error // if non-nil, returned by GetFileContents instead of ErrorToReturn
+	DeleteTagError  error // if non-nil, returned by DeleteTag instead of ErrorToReturn
+
+	// DownloadBaseURL, if set, is the prefix of the BrowserDownloadURL
+	// returned for uploaded assets, followed by "/<name>".
+	DownloadBaseURL string
 }
 
 // NewMockClient creates a new mock GitHub client
 func NewMockClient() *MockClient {
 	return &MockClient{
-		Repositories: make(map[string]*github.Repository),
-		Releases:     make(map[string][]*github.RepositoryRelease),
-		Users:        make(map[string]*github.User),
-		FileContents: make(map[string]*github.RepositoryContent),
-		CreatedFiles: make(map[string][]byte),
-		UpdatedFiles: make(map[string][]byte),
+		Repositories:   make(map[string]*github.Repository),
+		Releases:       make(map[string][]*github.RepositoryRelease),
+		ContentTypes:   make(map[string]string),
+		AssetNames:     make(map[string]string),
+		Users:          make(map[string]*github.User),
+		FileContents:   make(map[string]*github.RepositoryContent),
+		Blobs:          make(map[string][]byte),
+		CreatedFiles:   make(map[string][]byte),
+		UpdatedFiles:   make(map[string][]byte),
+		CommitMessages: make(map[string]string),
 	}
 }
 
@@ -59,7 +77,7 @@ func (m *MockClient) GetRelease(ctx context.Context, owner, repo, tag string) (*
 	key := fmt.Sprintf("%s/%s", owner, repo)
 	releases, exists := m.Releases[key]
 	if !exists {
-		return nil, fmt.Errorf("no releases found for %s", key)
+		return nil, &NotFoundError{Message: fmt.Sprintf("no releases found for %s", key)}
 	}
 
 	for _, release := range releases {
@@ -68,7 +86,7 @@ func (m *MockClient) GetRelease(ctx context.Context, owner, repo, tag string) (*
 		}
 	}
 
-	return nil, fmt.Errorf("release %s not found in %s", tag, key)
+	return nil, &NotFoundError{Message: fmt.Sprintf("release %s not found in %s", tag, key)}
 }
 
 // ListReleases fetches all releases from mock data
@@ -87,18 +105,23 @@ func (m *MockClient) ListReleases(ctx context.Context, owner, repo string) ([]*g
 }
 
 // CreateRelease creates a new release in mock data
-func (m *MockClient) CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, error) {
+func (m *MockClient) CreateRelease(ctx context.Context, owner, repo string, req *ReleaseRequest) (*github.RepositoryRelease, error) {
 	if m.ErrorToReturn != nil {
 		return nil, m.ErrorToReturn
 	}
+	m.ReleaseRequests = append(m.ReleaseRequests, req)
+	// Copy so later updates don't alter the recorded request
+	created := *req.RepositoryRelease
+	release := &created
 
 	key := fmt.Sprintf("%s/%s", owner, repo)
 	if _, exists := m.Releases[key]; !exists {
 		m.Releases[key] = []*github.RepositoryRelease{}
 	}
 
-	// Assign synthetic ID and URL for testing
-	id := int64(len(m.Releases[key]) + 1)
+	// Assign synthetic ID and URL for testing, never reusing the ID of a
+	// deleted release
+	id := int64(len(m.Releases[key]) + len(m.DeletedReleases) + 1)
 	release.ID = &id
 	htmlURL := fmt.Sprintf("https://github.com/%s/releases/tag/%s", key, release.GetTagName())
 	release.HTMLURL = &htmlURL
@@ -107,9 +130,68 @@ func (m *MockClient) CreateRelease(ctx context.Context, owner, repo string, rele
 	return release, nil
 }
 
+// UpdateRelease applies the set fields of req to the mock release with the
+// given ID
+func (m *MockClient) UpdateRelease(ctx context.Context, owner, repo string, id int64, req *ReleaseRequest) (*github.RepositoryRelease, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	m.ReleaseUpdates = append(m.ReleaseUpdates, req)
+
+	key := fmt.Sprintf("%s/%s", owner, repo)
+	for _, release := range m.Releases[key] {
+		if release.GetID() != id {
+			continue
+		}
+		if req.Draft != nil {
+			release.Draft = req.Draft
+		}
+		if req.Name != nil {
+			release.Name = req.Name
+		}
+		if req.Body != nil {
+			release.Body = req.Body
+		}
+		return release, nil
+	}
+
+	return nil, &NotFoundError{Message: fmt.Sprintf("release %d not found in %s", id, key)}
+}
+
+// DeleteRelease removes the mock release with the given ID
+func (m *MockClient) DeleteRelease(ctx context.Context, owner, repo string, id int64) error {
+	if m.ErrorToReturn != nil {
+		return m.ErrorToReturn
+	}
+
+	key := fmt.Sprintf("%s/%s", owner, repo)
+	for i, release := range m.Releases[key] {
+		if release.GetID() == id {
+			m.Releases[key] = append(m.Releases[key][:i], m.Releases[key][i+1:]...)
+			m.DeletedReleases = append(m.DeletedReleases, id)
+			return nil
+		}
+	}
+
+	return &NotFoundError{Message: fmt.Sprintf("release %d not found in %s", id, key)}
+}
+
+// DeleteTag records the deleted tag.
+// If DeleteTagError is set, it is returned instead of ErrorToReturn.
+func (m *MockClient) DeleteTag(ctx context.Context, owner, repo, tag string) error {
+	if m.DeleteTagError != nil {
+		return m.DeleteTagError
+	}
+	if m.ErrorToReturn != nil {
+		return m.ErrorToReturn
+	}
+	m.DeletedTags = append(m.DeletedTags, tag)
+	return nil
+}
+
 // UploadReleaseAsset simulates uploading an asset to a release.
 // If UploadError is set, it is returned instead of ErrorToReturn.
-func (m *MockClient) UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, assetPath, contentType string) (*github.ReleaseAsset, error) {
+func (m *MockClient) UploadReleaseAsset(ctx context.Context, owner, repo string, releaseID int64, assetPath, name, contentType string, progress ProgressFunc) (*github.ReleaseAsset, error) {
 	if m.UploadError != nil {
 		return nil, m.UploadError
 	}
@@ -118,10 +200,20 @@ func (m *MockClient) UploadReleaseAsset(ctx context.Context, owner, repo string,
 	}
 
 	m.UploadedAssets = append(m.UploadedAssets, assetPath)
+	m.ContentTypes[assetPath] = contentType
+	assetName := uploadOptions(assetPath, name).Name
+	m.AssetNames[assetPath] = assetName
 
-	name := assetPath
 	asset := &github.ReleaseAsset{
-		Name: &name,
+		Name: &assetName,
+	}
+	if info, err := os.Stat(assetPath); err == nil {
+		size := int(info.Size())
+		asset.Size = &size
+	}
+	if m.DownloadBaseURL != "" {
+		downloadURL := m.DownloadBaseURL + "/" + assetName
+		asset.BrowserDownloadURL = &downloadURL
 	}
 
 	return asset, nil
@@ -219,9 +311,26 @@ func (m *MockClient) GetFileContents(ctx context.Context, owner, repo, path stri
 	if !exists {
 		return nil, &NotFoundError{Message: fmt.Sprintf("file %s not found in %s/%s", path, owner, repo)}
 	}
+	if err := resolveLargeContent(ctx, m, owner, repo, content); err != nil {
+		return nil, fmt.Errorf("failed to get contents of %s in %s/%s: %w", path, owner, repo, err)
+	}
 	return content, nil
 }
 
+// GetBlob retrieves raw blob content from mock data
+func (m *MockClient) GetBlob(ctx context.Context, owner, repo, sha string) ([]byte, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", owner, repo, sha)
+	data, exists := m.Blobs[key]
+	if !exists {
+		return nil, &NotFoundError{Message: fmt.Sprintf("blob %s not found in %s/%s", sha, owner, repo)}
+	}
+	return data, nil
+}
+
 // CreateFile simulates creating a file in a repository
 func (m *MockClient) CreateFile(ctx context.Context, owner, repo, path, message string, content []byte) error {
 	if m.ErrorToReturn != nil {
@@ -230,6 +339,7 @@ func (m *MockClient) CreateFile(ctx context.Context, owner, repo, path, message
 
 	key := fmt.Sprintf("%s/%s/%s", owner, repo, path)
 	m.CreatedFiles[key] = content
+	m.CommitMessages[key] = message
 	return nil
 }
 
@@ -241,6 +351,7 @@ func (m *MockClient) UpdateFile(ctx context.Context, owner, repo, path, message
 
 	key := fmt.Sprintf("%s/%s/%s", owner, repo, path)
 	m.UpdatedFiles[key] = content
+	m.CommitMessages[key] = message
 	return nil
 }
 
@@ -249,3 +360,9 @@ func (m *MockClient) AddFileContent(owner, repo, path string, content *github.Re
 	key := fmt.Sprintf("%s/%s/%s", owner, repo, path)
 	m.FileContents[key] = content
 }
+
+// AddBlob adds raw blob content to mock data for GetBlob
+func (m *MockClient) AddBlob(owner, repo, sha string, data []byte) {
+	key := fmt.Sprintf("%s/%s/%s", owner, repo, sha)
+	m.Blobs[key] = data
+}
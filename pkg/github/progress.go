@@ -0,0 +1,51 @@
+package github
+
+import (
+	"io"
+	"time"
+)
+
+// ProgressFunc receives the bytes sent so far and the total size of an
+// upload.
+type ProgressFunc func(sent, total int64)
+
+// progressInterval is how often an upload in flight reports its progress.
+const progressInterval = 5 * time.Second
+
+// progressReader counts the bytes read through it and reports them to
+// report at most once per interval. Completion is not reported, so uploads
+// that finish within the first interval stay silent.
+type progressReader struct {
+	r        io.Reader
+	total    int64
+	sent     int64
+	interval time.Duration
+	last     time.Time
+	now      func() time.Time
+	report   ProgressFunc
+}
+
+// newProgressReader wraps r, an upload of total bytes, reporting to report
+// every progressInterval.
+func newProgressReader(r io.Reader, total int64, report ProgressFunc) *progressReader {
+	return &progressReader{
+		r:        r,
+		total:    total,
+		interval: progressInterval,
+		last:     time.Now(),
+		now:      time.Now,
+		report:   report,
+	}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.sent += int64(n)
+	if n > 0 && p.sent < p.total {
+		if now := p.now(); now.Sub(p.last) >= p.interval {
+			p.last = now
+			p.report(p.sent, p.total)
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+// chunkReader returns at most chunk bytes per Read
+type chunkReader struct {
+	r     io.Reader
+	chunk int
+}
+
+func (c *chunkReader) Read(b []byte) (int, error) {
+	if len(b) > c.chunk {
+		b = b[:c.chunk]
+	}
+	return c.r.Read(b)
+}
+
+func TestProgressReader(t *testing.T) {
+	const total = 10 * 100
+	var reports []int64
+	p := newProgressReader(&chunkReader{r: bytes.NewReader(make([]byte, total)), chunk: 100}, total, func(sent, size int64) {
+		if size != total {
+			t.Errorf("report total = %d, want %d", size, total)
+		}
+		reports = append(reports, sent)
+	})
+
+	// Each read takes two seconds on the fake clock, so with a five second
+	// interval every third read reports, and the final read does not
+	clock := p.last
+	p.now = func() time.Time {
+		clock = clock.Add(2 * time.Second)
+		return clock
+	}
+
+	n, err := io.Copy(io.Discard, p)
+	if err != nil || n != total {
+		t.Fatalf("io.Copy() = %d, %v, want %d, nil", n, err, total)
+	}
+
+	want := []int64{300, 600, 900}
+	if len(reports) != len(want) {
+		t.Fatalf("reports = %v, want %v", reports, want)
+	}
+	for i := range want {
+		if reports[i] != want[i] {
+			t.Errorf("reports = %v, want %v", reports, want)
+			break
+		}
+	}
+}
+
+func TestProgressReaderFastUpload(t *testing.T) {
+	reported := false
+	p := newProgressReader(bytes.NewReader(make([]byte, 4096)), 4096, func(sent, total int64) { reported = true })
+	if _, err := io.Copy(io.Discard, p); err != nil {
+		t.Fatal(err)
+	}
+	if reported {
+		t.Error("an upload finishing within the first interval reported progress")
+	}
+}
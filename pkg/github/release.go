@@ -1,11 +1,33 @@
 package github
 
-import "path/filepath"
+import (
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// ReleaseRequest is the body of a create-release call. It embeds go-github's
+// RepositoryRelease and adds the fields that the pinned go-github predates.
+type ReleaseRequest struct {
+	*github.RepositoryRelease
+	DiscussionCategoryName *string `json:"discussion_category_name,omitempty"`
+}
 
 // ContentTypeForAsset returns the MIME content type for a release asset
-// based on its file extension. Unknown extensions default to application/octet-stream.
-func ContentTypeForAsset(path string) string {
-	switch filepath.Ext(path) {
+// based on its file extension. overrides maps extensions, including the
+// dot, to the content type used in place of the default. Unknown extensions
+// default to application/octet-stream.
+func ContentTypeForAsset(path string, overrides map[string]string) string {
+	ext := filepath.Ext(path)
+	for override, contentType := range overrides {
+		if strings.EqualFold(override, ext) {
+			return contentType
+		}
+	}
+	switch ext {
 	case ".zip":
 		return "application/zip"
 	case ".dmg":
@@ -14,3 +36,17 @@ func ContentTypeForAsset(path string) string {
 		return "application/octet-stream"
 	}
 }
+
+// ValidateContentTypes checks release.github.content_types: each key must be
+// a file extension including the dot, and each value a valid media type.
+func ValidateContentTypes(contentTypes map[string]string) error {
+	for ext, contentType := range contentTypes {
+		if !strings.HasPrefix(ext, ".") || len(ext) < 2 || strings.ContainsAny(ext, "/\\ ") {
+			return fmt.Errorf("release.github.content_types key %q must be a file extension such as .zip", ext)
+		}
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			return fmt.Errorf("release.github.content_types.%s %q is not a valid content type: %w", ext, contentType, err)
+		}
+	}
+	return nil
+}
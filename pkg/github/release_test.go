@@ -1,6 +1,16 @@
 package github
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
 
 func TestContentTypeForAsset(t *testing.T) {
 	tests := []struct {
@@ -17,10 +27,94 @@ func TestContentTypeForAsset(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ContentTypeForAsset(tt.path)
+			got := ContentTypeForAsset(tt.path, nil)
 			if got != tt.want {
 				t.Errorf("ContentTypeForAsset(%q) = %q, want %q", tt.path, got, tt.want)
 			}
 		})
 	}
 }
+
+func TestContentTypeForAssetOverrides(t *testing.T) {
+	overrides := map[string]string{".zip": "application/octet-stream", ".PKG": "application/x-newton-compatible-pkg"}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "dist/MyApp-1.0.0.zip", want: "application/octet-stream"},
+		{path: "dist/MyApp-1.0.0.pkg", want: "application/x-newton-compatible-pkg"},
+		{path: "dist/MyApp-1.0.0.dmg", want: "application/x-apple-diskimage"},
+	}
+
+	for _, tt := range tests {
+		if got := ContentTypeForAsset(tt.path, overrides); got != tt.want {
+			t.Errorf("ContentTypeForAsset(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidateContentTypes(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentTypes map[string]string
+		errMsg       string
+	}{
+		{name: "none"},
+		{name: "valid", contentTypes: map[string]string{".zip": "application/octet-stream", ".json": "application/json; charset=utf-8"}},
+		{name: "missing dot", contentTypes: map[string]string{"zip": "application/zip"}, errMsg: `key "zip" must be a file extension`},
+		{name: "bare dot", contentTypes: map[string]string{".": "application/zip"}, errMsg: `key "." must be a file extension`},
+		{name: "invalid type", contentTypes: map[string]string{".zip": "not a type"}, errMsg: "is not a valid content type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateContentTypes(tt.contentTypes)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("ValidateContentTypes() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ValidateContentTypes() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestCreateReleaseSendsDiscussionCategory(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/owner/repo/releases" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 1, "tag_name": "v1.0.0"}`))
+	}))
+	defer srv.Close()
+
+	gc := github.NewClient(srv.Client())
+	baseURL, err := url.Parse(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gc.BaseURL = baseURL
+	client := &Client{client: gc, httpClient: srv.Client()}
+
+	release, err := client.CreateRelease(context.Background(), "owner", "repo", &ReleaseRequest{
+		RepositoryRelease:      &github.RepositoryRelease{TagName: github.String("v1.0.0")},
+		DiscussionCategoryName: github.String("Announcements"),
+	})
+	if err != nil {
+		t.Fatalf("CreateRelease() unexpected error: %v", err)
+	}
+	if release.GetID() != 1 {
+		t.Errorf("release ID = %d, want 1", release.GetID())
+	}
+	if body["tag_name"] != "v1.0.0" || body["discussion_category_name"] != "Announcements" {
+		t.Errorf("request body = %v, want tag_name and discussion_category_name", body)
+	}
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/retry"
+)
+
+// retryTransport resends requests that fail with a network error or a
+// response GitHub documents as transient, waiting between attempts as the
+// policy dictates. Requests whose body cannot be replayed are sent once, and
+// a POST is only resent when GitHub cannot have acted on it (see retryable).
+// The http.Client timeout spans all attempts and the waits between them.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy retry.Policy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// newRetryTransport wraps base with policy.
+func newRetryTransport(base http.RoundTripper, policy retry.Policy) *retryTransport {
+	return &retryTransport{base: base, policy: policy, sleep: retry.Sleep}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.policy.MaxAttempts || !retryable(req, resp, err) || !replayable(req) {
+			return resp, err
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		if err := t.sleep(req.Context(), t.policy.Delay(attempt)); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryable returns true if the outcome of a request is worth retrying.
+// Idempotent requests are retried on any network error and on 429, 502, 503
+// and 504. Other requests, such as the POST that creates a release, may have
+// been carried out even though the response was lost, and resending them
+// would create a duplicate; they are only retried when the connection could
+// not be made or GitHub turned the request away with 429 or 503.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	idempotent := isIdempotent(req.Method)
+	if err != nil {
+		return idempotent || isDialError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// isIdempotent returns true if sending a request with method twice has the
+// same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isDialError returns true if err means the connection was never made, so
+// the request cannot have reached GitHub.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// replayable returns true if req can be sent again, which needs either no
+// body or a way to recreate it.
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
@@ -0,0 +1,151 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/retry"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func statusResponse(code int) *http.Response {
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(""))}
+}
+
+func TestRetryTransport(t *testing.T) {
+	policy := retry.Policy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		name      string
+		responses []int // 0 is a network error
+		body      bool  // send a body that cannot be replayed
+		wantCalls int
+		wantCode  int
+	}{
+		{name: "success", responses: []int{200}, wantCalls: 1, wantCode: 200},
+		{name: "retried until success", responses: []int{502, 0, 200}, wantCalls: 3, wantCode: 200},
+		{name: "gives up after max attempts", responses: []int{503, 503, 503, 503}, wantCalls: 3, wantCode: 503},
+		{name: "client error not retried", responses: []int{404, 200}, wantCalls: 1, wantCode: 404},
+		{name: "unreplayable body sent once", responses: []int{502, 200}, body: true, wantCalls: 1, wantCode: 502},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				code := tt.responses[calls]
+				calls++
+				if code == 0 {
+					return nil, errors.New("connection reset")
+				}
+				return statusResponse(code), nil
+			})
+
+			var delays []time.Duration
+			rt := newRetryTransport(base, policy)
+			rt.sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			req, _ := http.NewRequest("GET", "https://api.github.com/repos/o/r", nil)
+			if tt.body {
+				req, _ = http.NewRequest("POST", "https://uploads.github.com/x", io.NopCloser(strings.NewReader("data")))
+			}
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() unexpected error: %v", err)
+			}
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			for i, d := range delays {
+				if want := policy.Delay(i + 1); d != want {
+					t.Errorf("delay %d = %s, want %s", i+1, d, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	var bodies []string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			return statusResponse(503), nil
+		}
+		return statusResponse(201), nil
+	})
+	rt := newRetryTransport(base, retry.Default)
+	rt.sleep = func(context.Context, time.Duration) error { return nil }
+
+	req, _ := http.NewRequest("POST", "https://api.github.com/repos/o/r/releases", strings.NewReader(`{"tag_name":"v1"}`))
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() unexpected error: %v", err)
+	}
+	if resp.StatusCode != 201 {
+		t.Errorf("status = %d, want 201", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"tag_name":"v1"}` {
+		t.Errorf("bodies = %q, want the request body sent twice", bodies)
+	}
+}
+
+func TestRetryTransportPost(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	resetErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	tests := []struct {
+		name      string
+		err       error // returned by the first attempt instead of a response
+		status    int   // returned by the first attempt
+		wantCalls int
+	}{
+		{name: "rate limited", status: 429, wantCalls: 2},
+		{name: "service unavailable", status: 503, wantCalls: 2},
+		{name: "bad gateway not retried", status: 502, wantCalls: 1},
+		{name: "gateway timeout not retried", status: 504, wantCalls: 1},
+		{name: "connection refused", err: dialErr, wantCalls: 2},
+		{name: "connection reset not retried", err: resetErr, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				if calls > 1 {
+					return statusResponse(201), nil
+				}
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return statusResponse(tt.status), nil
+			})
+			rt := newRetryTransport(base, retry.Default)
+			rt.sleep = func(context.Context, time.Duration) error { return nil }
+
+			req, _ := http.NewRequest("POST", "https://api.github.com/repos/o/r/releases", strings.NewReader(`{"tag_name":"v1"}`))
+			_, _ = rt.RoundTrip(req)
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// ResolveGitHubToken returns a GitHub token and a description of where it
+// came from. Sources are tried in order:
+//
+//  1. the GITHUB_TOKEN environment variable
+//  2. `gh auth token` from the GitHub CLI
+//  3. the git credential helper configured for github.com
+//
+// Returns empty strings if no source provides a token. gh and git run with
+// the Runner carried by ctx.
+func ResolveGitHubToken(ctx context.Context) (token, source string) {
+	return resolveGitHubToken(ctx, os.Getenv)
+}
+
+func resolveGitHubToken(ctx context.Context, getenv func(string) string) (string, string) {
+	if token := getenv("GITHUB_TOKEN"); token != "" {
+		return token, "GITHUB_TOKEN environment variable"
+	}
+
+	if out, err := command.Output(ctx, "", "gh", "auth", "token"); err == nil {
+		if token := strings.TrimSpace(out); token != "" {
+			return token, "gh auth token"
+		}
+	}
+
+	out, err := command.Output(ctx, "protocol=https\nhost=github.com\n\n", "git", "credential", "fill")
+	if err == nil {
+		if token := parseCredentialPassword(out); token != "" {
+			return token, "git credential helper"
+		}
+	}
+
+	return "", ""
+}
+
+// ResolveToken returns the token the release is published with and a
+// description of where it came from. A configured release.github.token
+// takes precedence; without one, the sources of ResolveGitHubToken are
+// tried. Homebrew taps never use this token — each tap commits with the
+// token configured on it.
+func ResolveToken(ctx context.Context, configured string) (token, source string) {
+	return resolveToken(ctx, configured, os.Getenv)
+}
+
+func resolveToken(ctx context.Context, configured string, getenv func(string) string) (string, string) {
+	if configured != "" {
+		return configured, "release.github.token"
+	}
+	return resolveGitHubToken(ctx, getenv)
+}
+
+// parseCredentialPassword extracts the password field from
+// `git credential fill` output (key=value lines).
+func parseCredentialPassword(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if value, ok := strings.CutPrefix(line, "password="); ok {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
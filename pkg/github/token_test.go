@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// fakeCommands returns a context whose Runner answers each command with its
+// canned stdout in outputs, keyed by command name, and reports any other
+// command as not installed.
+func fakeCommands(outputs map[string]string) (context.Context, *command.Fake) {
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		out, ok := outputs[name]
+		if !ok {
+			return "", &command.NotFoundError{Name: name}
+		}
+		return out, nil
+	}}
+	return command.WithRunner(context.Background(), fake), fake
+}
+
+func TestResolveGitHubToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        string
+		outputs    map[string]string
+		wantToken  string
+		wantSource string
+		wantCalls  int
+	}{
+		{
+			name: "env var wins over gh and git",
+			env:  "env-token",
+			outputs: map[string]string{
+				"gh":  "gh-token",
+				"git": "protocol=https\nhost=github.com\nusername=me\npassword=git-token",
+			},
+			wantToken:  "env-token",
+			wantSource: "GITHUB_TOKEN environment variable",
+			wantCalls:  0,
+		},
+		{
+			name: "gh wins over git when env unset",
+			outputs: map[string]string{
+				"gh":  "gh-token",
+				"git": "protocol=https\nhost=github.com\nusername=me\npassword=git-token",
+			},
+			wantToken:  "gh-token",
+			wantSource: "gh auth token",
+			wantCalls:  1,
+		},
+		{
+			name: "git credential helper as last resort",
+			outputs: map[string]string{
+				"git": "protocol=https\nhost=github.com\nusername=me\npassword=git-token",
+			},
+			wantToken:  "git-token",
+			wantSource: "git credential helper",
+			wantCalls:  2,
+		},
+		{
+			name: "empty gh output falls through",
+			outputs: map[string]string{
+				"gh":  "",
+				"git": "password=git-token",
+			},
+			wantToken:  "git-token",
+			wantSource: "git credential helper",
+			wantCalls:  2,
+		},
+		{
+			name: "no source available",
+			outputs: map[string]string{
+				"git": "protocol=https\nhost=github.com",
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, runner := fakeCommands(tt.outputs)
+			getenv := func(key string) string {
+				if key == "GITHUB_TOKEN" {
+					return tt.env
+				}
+				return ""
+			}
+
+			token, source := resolveGitHubToken(ctx, getenv)
+			if token != tt.wantToken {
+				t.Errorf("token = %q, want %q", token, tt.wantToken)
+			}
+			if source != tt.wantSource {
+				t.Errorf("source = %q, want %q", source, tt.wantSource)
+			}
+			if calls := runner.Commands(); len(calls) != tt.wantCalls {
+				t.Errorf("ran %d commands %v, want %d", len(calls), calls, tt.wantCalls)
+			}
+			for _, call := range runner.Calls() {
+				if call.Name == "git" && call.Stdin != "protocol=https\nhost=github.com\n\n" {
+					t.Errorf("git credential fill stdin = %q, want a github.com request", call.Stdin)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	ctx, runner := fakeCommands(map[string]string{"gh": "gh-token\n"})
+	getenv := func(key string) string {
+		if key == "GITHUB_TOKEN" {
+			return "env-token"
+		}
+		return ""
+	}
+
+	token, source := resolveToken(ctx, "config-token", getenv)
+	if token != "config-token" || source != "release.github.token" {
+		t.Errorf("resolveToken() = %q, %q, want the configured token", token, source)
+	}
+	if calls := runner.Commands(); len(calls) != 0 {
+		t.Errorf("ran %v, want no commands when a token is configured", calls)
+	}
+
+	token, source = resolveToken(ctx, "", getenv)
+	if token != "env-token" || source != "GITHUB_TOKEN environment variable" {
+		t.Errorf("resolveToken() = %q, %q, want GITHUB_TOKEN as the fallback", token, source)
+	}
+
+	token, source = resolveToken(ctx, "", func(string) string { return "" })
+	if token != "gh-token" || source != "gh auth token" {
+		t.Errorf("resolveToken() = %q, %q, want gh auth token without GITHUB_TOKEN", token, source)
+	}
+}
@@ -11,26 +11,54 @@ import (
 
 // CaskData contains all fields needed to render a Homebrew cask file.
 type CaskData struct {
-	Token    string // cask token/identifier (e.g., "myapp")
-	Version  string // bare version without v prefix (e.g., "1.2.3")
-	SHA256   string // hex-encoded SHA256 hash
-	URL      string // direct download URL for the archive
-	Name     string // human-readable app name (e.g., "MyApp")
-	Desc     string // short description
-	Homepage string // homepage URL
-	AppName  string // .app bundle name (e.g., "MyApp.app")
+	Token       string // cask token/identifier (e.g., "myapp")
+	Version     string // bare version without v prefix (e.g., "1.2.3")
+	SHA256      string // hex-encoded SHA256 hash
+	URL         string // direct download URL for the archive
+	Name        string // human-readable app name (e.g., "MyApp")
+	Desc        string // short description
+	Homepage    string // homepage URL
+	AutoUpdates bool   // app updates itself (e.g., via Sparkle)
+	AppName     string // .app bundle name (e.g., "MyApp.app")
+	AppTarget   string // optional name the app is installed as (e.g., "My App.app")
+	Caveats     string // optional post-install note, may span multiple lines
+	Rolling     bool   // render version :latest and sha256 :no_check instead of Version and SHA256
+
+	ConflictsWithCasks    []string // casks that cannot be installed alongside this one
+	ConflictsWithFormulae []string // formulae that cannot be installed alongside this one
 }
 
 const caskTemplate = `cask "{{.Token}}" do
+{{- if .Rolling}}
+  version :latest
+  sha256 :no_check
+{{- else}}
   version "{{.Version}}"
   sha256 "{{.SHA256}}"
+{{- end}}
 
   url "{{.URL}}"
   name "{{.Name}}"
   desc "{{.Desc}}"
   homepage "{{.Homepage}}"
+{{- if .AutoUpdates}}
+
+  auto_updates true
+{{- end}}
+{{- if or .ConflictsWithCasks .ConflictsWithFormulae}}
+
+  conflicts_with {{with .ConflictsWithCasks}}cask: {{rubyList .}}{{end}}
+{{- if and .ConflictsWithCasks .ConflictsWithFormulae}}, {{end}}
+{{- with .ConflictsWithFormulae}}formula: {{rubyList .}}{{end}}
+{{- end}}
 
-  app "{{.AppName}}"
+  app "{{.AppName}}"{{if .AppTarget}}, target: "{{.AppTarget}}"{{end}}
+{{- if .Caveats}}
+
+  caveats <<~EOS
+{{indent .Caveats}}
+  EOS
+{{- end}}
 end
 `
 
@@ -48,6 +76,87 @@ func validateCaskField(name, value string) error {
 	return nil
 }
 
+// ValidateConflicts checks the cask and formula names of a conflicts_with
+// stanza, which are embedded as Ruby string literals.
+func ValidateConflicts(casks, formulae []string) error {
+	if err := validateConflictNames("conflicts_with.cask", casks); err != nil {
+		return err
+	}
+	return validateConflictNames("conflicts_with.formula", formulae)
+}
+
+// validateConflictNames checks the entries of one conflicts_with list.
+func validateConflictNames(field string, names []string) error {
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid %s: entries must not be empty", field)
+		}
+		if err := validateCaskField(field, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAppTarget checks the name the app is installed as, rendered as the
+// target of the cask's app stanza. An empty target is valid.
+func ValidateAppTarget(target string) error {
+	if target == "" {
+		return nil
+	}
+	if err := validateCaskField("app_target", target); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(target, ".app") {
+		return fmt.Errorf("invalid app_target %q: must end in .app", target)
+	}
+	return nil
+}
+
+// rubyList renders values as a Ruby array of string literals.
+func rubyList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = `"` + v + `"`
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// caveatsTerminator ends the caveats heredoc in the rendered cask.
+const caveatsTerminator = "EOS"
+
+// validateCaveats checks that caveats text is safe for embedding in a
+// squiggly heredoc. Newlines and double quotes are allowed, but heredocs
+// still interpolate, so backslashes and #{} are rejected. A line consisting
+// only of the terminator would end the heredoc early.
+func validateCaveats(value string) error {
+	if strings.Contains(value, "\\") {
+		return fmt.Errorf("invalid caveats: must not contain backslashes")
+	}
+	if strings.Contains(value, "#{") {
+		return fmt.Errorf("invalid caveats: must not contain Ruby interpolation sequences")
+	}
+	for _, line := range strings.Split(value, "\n") {
+		if strings.TrimSpace(line) == caveatsTerminator {
+			return fmt.Errorf("invalid caveats: must not contain a line consisting of %q", caveatsTerminator)
+		}
+	}
+	return nil
+}
+
+// indentCaveats trims surrounding blank lines and indents each line to sit
+// inside the caveats heredoc.
+func indentCaveats(value string) string {
+	value = strings.Trim(strings.ReplaceAll(value, "\r\n", "\n"), "\n")
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "    " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // RenderCask renders a Homebrew cask Ruby file from the given data.
 func RenderCask(data CaskData) (string, error) {
 	fields := map[string]string{
@@ -64,7 +173,22 @@ func RenderCask(data CaskData) (string, error) {
 			return "", err
 		}
 	}
-	tmpl, err := template.New("cask").Parse(caskTemplate)
+	if err := ValidateAppTarget(data.AppTarget); err != nil {
+		return "", err
+	}
+	if err := validateCaveats(data.Caveats); err != nil {
+		return "", err
+	}
+	if err := ValidateConflicts(data.ConflictsWithCasks, data.ConflictsWithFormulae); err != nil {
+		return "", err
+	}
+	// A rolling cask is never pinned, so a computed hash would be silently dropped
+	if data.Rolling && data.SHA256 != "" {
+		return "", fmt.Errorf("a rolling cask uses sha256 :no_check and must not be given a SHA256")
+	}
+	tmpl, err := template.New("cask").
+		Funcs(template.FuncMap{"indent": indentCaveats, "rubyList": rubyList}).
+		Parse(caskTemplate)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse cask template: %w", err)
 	}
@@ -83,11 +207,19 @@ func BuildAssetURL(owner, repo, tag, filename string) string {
 		owner, repo, tag, url.PathEscape(filename))
 }
 
+// BuildLatestAssetURL constructs the GitHub download URL that always serves
+// the named asset of the latest release.
+func BuildLatestAssetURL(owner, repo, filename string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/releases/latest/download/%s",
+		owner, repo, url.PathEscape(filename))
+}
+
 // SelectPackage selects the preferred archive from the package list for
-// use in the Homebrew cask. Prefers .zip, falls back to .dmg.
+// use in the Homebrew cask. Prefers .zip, falls back to .dmg. Debug symbol
+// ZIPs (.dSYM.zip) are never selected.
 func SelectPackage(packages []string) (string, error) {
 	for _, p := range packages {
-		if filepath.Ext(p) == ".zip" {
+		if filepath.Ext(p) == ".zip" && !strings.HasSuffix(p, ".dSYM.zip") {
 			return p, nil
 		}
 	}
@@ -239,6 +239,11 @@ func TestSelectPackage(t *testing.T) {
 			packages: []string{"/path/to/App.dmg", "/path/to/App.zip"},
 			wantExt:  ".zip",
 		},
+		{
+			name:     "dSYM zip is never selected",
+			packages: []string{"/path/to/App.dSYM.zip", "/path/to/App.dmg"},
+			wantExt:  ".dmg",
+		},
 		{
 			name:     "no zip or dmg",
 			packages: []string{"/path/to/App.app"},
@@ -269,3 +274,273 @@ func TestSelectPackage(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderCaskAutoUpdates(t *testing.T) {
+	data := CaskData{
+		Token:    "myapp",
+		Version:  "1.0.0",
+		SHA256:   "abc123",
+		URL:      "https://example.com/myapp.zip",
+		Name:     "MyApp",
+		Desc:     "An app",
+		Homepage: "https://example.com",
+		AppName:  "MyApp.app",
+	}
+
+	got, err := RenderCask(data)
+	if err != nil {
+		t.Fatalf("RenderCask() unexpected error: %v", err)
+	}
+	if strings.Contains(got, "auto_updates") {
+		t.Errorf("RenderCask() rendered auto_updates when disabled\ngot:\n%s", got)
+	}
+
+	data.AutoUpdates = true
+	got, err = RenderCask(data)
+	if err != nil {
+		t.Fatalf("RenderCask() unexpected error: %v", err)
+	}
+	want := `  homepage "https://example.com"
+
+  auto_updates true
+
+  app "MyApp.app"
+`
+	if !strings.Contains(got, want) {
+		t.Errorf("RenderCask() missing auto_updates stanza\ngot:\n%s\nwant to contain:\n%s", got, want)
+	}
+}
+
+func TestRenderCaskAppTarget(t *testing.T) {
+	data := CaskData{
+		Token:    "myapp",
+		Version:  "1.0.0",
+		SHA256:   "abc123",
+		URL:      "https://example.com/myapp.zip",
+		Name:     "MyApp",
+		Desc:     "An app",
+		Homepage: "https://example.com",
+		AppName:  "MyApp.app",
+	}
+
+	got, err := RenderCask(data)
+	if err != nil {
+		t.Fatalf("RenderCask() unexpected error: %v", err)
+	}
+	if !strings.Contains(got, "  app \"MyApp.app\"\n") {
+		t.Errorf("RenderCask() rendered a target when unset\ngot:\n%s", got)
+	}
+
+	data.AppTarget = "My App.app"
+	got, err = RenderCask(data)
+	if err != nil {
+		t.Fatalf("RenderCask() unexpected error: %v", err)
+	}
+	want := `  app "MyApp.app", target: "My App.app"` + "\n"
+	if !strings.Contains(got, want) {
+		t.Errorf("RenderCask() missing app target\ngot:\n%s\nwant to contain:\n%s", got, want)
+	}
+
+	for _, target := range []string{`My "App".app`, "#{system}.app", "MyApp"} {
+		data.AppTarget = target
+		if _, err := RenderCask(data); err == nil || !strings.Contains(err.Error(), "invalid app_target") {
+			t.Errorf("RenderCask() with target %q error = %v, want invalid app_target", target, err)
+		}
+	}
+}
+
+func TestRenderCaskCaveats(t *testing.T) {
+	base := CaskData{
+		Token:    "myapp",
+		Version:  "1.0.0",
+		SHA256:   "abc123",
+		URL:      "https://example.com/myapp.zip",
+		Name:     "MyApp",
+		Desc:     "An app",
+		Homepage: "https://example.com",
+		AppName:  "MyApp.app",
+	}
+
+	t.Run("omitted when empty", func(t *testing.T) {
+		got, err := RenderCask(base)
+		if err != nil {
+			t.Fatalf("RenderCask() unexpected error: %v", err)
+		}
+		if strings.Contains(got, "caveats") {
+			t.Errorf("RenderCask() rendered caveats stanza without caveats\ngot:\n%s", got)
+		}
+	})
+
+	t.Run("multi-line heredoc", func(t *testing.T) {
+		data := base
+		data.Caveats = "Grant Accessibility permission in System Settings.\n\nThen restart \"MyApp\".\n"
+
+		got, err := RenderCask(data)
+		if err != nil {
+			t.Fatalf("RenderCask() unexpected error: %v", err)
+		}
+
+		want := `  app "MyApp.app"
+
+  caveats <<~EOS
+    Grant Accessibility permission in System Settings.
+
+    Then restart "MyApp".
+  EOS
+end
+`
+		if !strings.HasSuffix(got, want) {
+			t.Errorf("RenderCask() caveats mismatch\ngot:\n%s\nwant suffix:\n%s", got, want)
+		}
+	})
+
+	rejects := []struct {
+		name    string
+		caveats string
+	}{
+		{"interpolation", "Run #{system('id')} first"},
+		{"backslash", `Path is C:\Users`},
+		{"heredoc terminator", "Line one\n  EOS\nsystem('id')"},
+	}
+
+	for _, tt := range rejects {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			data := base
+			data.Caveats = tt.caveats
+			_, err := RenderCask(data)
+			if err == nil {
+				t.Fatalf("RenderCask() expected error for %s in caveats, got nil", tt.name)
+			}
+			if !strings.Contains(err.Error(), "must not contain") {
+				t.Errorf("RenderCask() error = %q, want error containing %q", err.Error(), "must not contain")
+			}
+		})
+	}
+}
+
+func TestRenderCaskRolling(t *testing.T) {
+	data := CaskData{
+		Token:    "myapp",
+		Version:  "1.2.3",
+		URL:      "https://github.com/owner/repo/releases/latest/download/MyApp.zip",
+		Name:     "MyApp",
+		Desc:     "A great macOS application",
+		Homepage: "https://example.com",
+		AppName:  "MyApp.app",
+		Rolling:  true,
+	}
+
+	got, err := RenderCask(data)
+	if err != nil {
+		t.Fatalf("RenderCask() unexpected error: %v", err)
+	}
+
+	want := "cask \"myapp\" do\n  version :latest\n  sha256 :no_check\n\n  url \"https://github.com/owner/repo/releases/latest/download/MyApp.zip\"\n"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("RenderCask() output does not start with\n%s\ngot:\n%s", want, got)
+	}
+	if strings.Contains(got, `version "`) || strings.Contains(got, `sha256 "`) {
+		t.Errorf("RenderCask() rolling cask contains a quoted version or sha256\ngot:\n%s", got)
+	}
+
+	data.SHA256 = "abc123"
+	if _, err := RenderCask(data); err == nil {
+		t.Error("RenderCask() expected error for a rolling cask with a SHA256, got nil")
+	}
+}
+
+func TestBuildLatestAssetURL(t *testing.T) {
+	got := BuildLatestAssetURL("owner", "repo", "My App.zip")
+	want := "https://github.com/owner/repo/releases/latest/download/My%20App.zip"
+	if got != want {
+		t.Errorf("BuildLatestAssetURL() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderCaskConflictsWith(t *testing.T) {
+	base := CaskData{
+		Token:       "myapp",
+		Version:     "1.0.0",
+		SHA256:      "abc123",
+		URL:         "https://example.com/myapp.zip",
+		Name:        "MyApp",
+		Desc:        "An app",
+		Homepage:    "https://example.com",
+		AutoUpdates: true,
+		AppName:     "MyApp.app",
+	}
+
+	tests := []struct {
+		name     string
+		casks    []string
+		formulae []string
+		want     string
+	}{
+		{
+			name:  "cask only",
+			casks: []string{"myapp@beta"},
+			want:  `  conflicts_with cask: ["myapp@beta"]`,
+		},
+		{
+			name:     "formula only",
+			formulae: []string{"myapp-cli"},
+			want:     `  conflicts_with formula: ["myapp-cli"]`,
+		},
+		{
+			name:     "both",
+			casks:    []string{"myapp@beta", "myapp-legacy"},
+			formulae: []string{"myapp-cli"},
+			want:     `  conflicts_with cask: ["myapp@beta", "myapp-legacy"], formula: ["myapp-cli"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base
+			data.ConflictsWithCasks = tt.casks
+			data.ConflictsWithFormulae = tt.formulae
+
+			got, err := RenderCask(data)
+			if err != nil {
+				t.Fatalf("RenderCask() unexpected error: %v", err)
+			}
+			want := "  auto_updates true\n\n" + tt.want + "\n\n  app \"MyApp.app\"\n"
+			if !strings.Contains(got, want) {
+				t.Errorf("RenderCask() missing conflicts_with stanza\ngot:\n%s\nwant to contain:\n%s", got, want)
+			}
+		})
+	}
+
+	t.Run("omitted when empty", func(t *testing.T) {
+		got, err := RenderCask(base)
+		if err != nil {
+			t.Fatalf("RenderCask() unexpected error: %v", err)
+		}
+		if strings.Contains(got, "conflicts_with") {
+			t.Errorf("RenderCask() rendered conflicts_with without conflicts\ngot:\n%s", got)
+		}
+	})
+
+	rejects := []struct {
+		name     string
+		casks    []string
+		formulae []string
+		errMsg   string
+	}{
+		{"quote in cask", []string{`myapp"; system('id'); "`}, nil, "invalid conflicts_with.cask"},
+		{"interpolation in formula", nil, []string{"#{system('id')}"}, "invalid conflicts_with.formula"},
+		{"empty entry", []string{""}, nil, "entries must not be empty"},
+	}
+
+	for _, tt := range rejects {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			data := base
+			data.ConflictsWithCasks = tt.casks
+			data.ConflictsWithFormulae = tt.formulae
+			_, err := RenderCask(data)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("RenderCask() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package homebrew
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxDescLength is the longest cask desc brew audit accepts, in characters.
+const MaxDescLength = 80
+
+// descArticles are the leading words brew audit rejects in a cask desc.
+var descArticles = []string{"a", "an", "the"}
+
+// NormalizeDesc rewrites a cask description the way brew audit expects: a
+// leading "A", "An", or "The" is dropped and the next word capitalized, a
+// trailing period is removed, and the result is cut to MaxDescLength at a
+// word boundary. For example, "A fast editor." becomes "Fast editor".
+func NormalizeDesc(desc string) string {
+	desc = strings.TrimSpace(desc)
+
+	if first, rest, ok := strings.Cut(desc, " "); ok {
+		for _, article := range descArticles {
+			if strings.EqualFold(first, article) {
+				desc = capitalize(strings.TrimSpace(rest))
+				break
+			}
+		}
+	}
+
+	desc = strings.TrimSuffix(desc, ".")
+
+	if utf8.RuneCountInString(desc) > MaxDescLength {
+		runes := []rune(desc)
+		cut := string(runes[:MaxDescLength])
+		// Drop a partial last word unless the cut fell on a word boundary
+		if !unicode.IsSpace(runes[MaxDescLength]) {
+			if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+				cut = cut[:i]
+			}
+		}
+		desc = strings.TrimRightFunc(cut, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.IsPunct(r)
+		})
+	}
+
+	return desc
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
@@ -0,0 +1,42 @@
+package homebrew
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNormalizeDesc(t *testing.T) {
+	long := strings.Repeat("word ", 20) + "end"
+
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		{name: "already valid", desc: "Fast text editor", want: "Fast text editor"},
+		{name: "leading A", desc: "A fast text editor", want: "Fast text editor"},
+		{name: "leading An", desc: "An elegant text editor", want: "Elegant text editor"},
+		{name: "leading The", desc: "the fastest text editor", want: "Fastest text editor"},
+		{name: "article prefix of a word", desc: "Anagram solver", want: "Anagram solver"},
+		{name: "only an article", desc: "A", want: "A"},
+		{name: "trailing period", desc: "Fast text editor.", want: "Fast text editor"},
+		{name: "surrounding whitespace", desc: "  Fast text editor  ", want: "Fast text editor"},
+		{name: "article and period", desc: "An app for notes.", want: "App for notes"},
+		{name: "truncated at a word boundary", desc: long, want: strings.TrimSpace(strings.Repeat("word ", 16))},
+		{name: "cut after a comma", desc: strings.Repeat("x", 70) + " abcdefgh, ijklmnop", want: strings.Repeat("x", 70) + " abcdefgh"},
+		{name: "exactly the limit", desc: strings.Repeat("x", MaxDescLength), want: strings.Repeat("x", MaxDescLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeDesc(tt.desc)
+			if got != tt.want {
+				t.Errorf("NormalizeDesc(%q) = %q, want %q", tt.desc, got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); n > MaxDescLength {
+				t.Errorf("NormalizeDesc(%q) is %d characters, want at most %d", tt.desc, n, MaxDescLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package homebrew
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffEqual(t *testing.T) {
+	if got := Diff("a", "b", "same\n", "same\n"); got != "" {
+		t.Errorf("Diff() of equal texts = %q, want empty", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	oldText := strings.Join([]string{
+		`cask "myapp" do`,
+		`  version "1.0.0"`,
+		`  sha256 "aaa"`,
+		``,
+		`  url "https://example.com/1.0.0.zip"`,
+		`  name "MyApp"`,
+		`  desc "An app"`,
+		`  homepage "https://example.com"`,
+		``,
+		`  app "MyApp.app"`,
+		`end`,
+	}, "\n") + "\n"
+	newText := strings.Replace(strings.Replace(oldText, "1.0.0", "1.1.0", 2), `"aaa"`, `"bbb"`, 1)
+
+	got := Diff("Casks/myapp.rb", "Casks/myapp.rb (new)", oldText, newText)
+	want := `--- Casks/myapp.rb
++++ Casks/myapp.rb (new)
+@@ -1,8 +1,8 @@
+ cask "myapp" do
+-  version "1.0.0"
+-  sha256 "aaa"
++  version "1.1.0"
++  sha256 "bbb"
+ 
+-  url "https://example.com/1.0.0.zip"
++  url "https://example.com/1.1.0.zip"
+   name "MyApp"
+   desc "An app"
+   homepage "https://example.com"
+`
+	if got != want {
+		t.Errorf("Diff() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDiffSeparateHunks(t *testing.T) {
+	var lines []string
+	for i := 0; i < 20; i++ {
+		lines = append(lines, strings.Repeat("x", i+1))
+	}
+	oldText := strings.Join(lines, "\n")
+	lines[0] = "first"
+	lines[19] = "last"
+	newText := strings.Join(lines, "\n")
+
+	got := Diff("old", "new", oldText, newText)
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("Diff() produced %d hunks, want 2:\n%s", n, got)
+	}
+	if !strings.Contains(got, "@@ -17,4 +17,4 @@") {
+		t.Errorf("Diff() missing second hunk header:\n%s", got)
+	}
+}
+
+func TestDiffFromEmpty(t *testing.T) {
+	got := Diff("old", "new", "", "a\nb\n")
+	want := "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n"
+	if got != want {
+		t.Errorf("Diff() = %q, want %q", got, want)
+	}
+}
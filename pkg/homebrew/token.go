@@ -0,0 +1,34 @@
+package homebrew
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tokenPattern is the set of cask tokens Homebrew accepts. Versioned and
+// channel suffixes such as "@beta" are appended after validation.
+var tokenPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// tokenInvalidChars matches runs of characters that are not allowed in a token.
+var tokenInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NormalizeToken derives a cask token from a display name following
+// Homebrew's conventions: lowercase, "+" spelled "plus", "@" spelled "at",
+// and any other run of disallowed characters collapsed to a single hyphen.
+// For example, "My App+" becomes "my-app-plus".
+func NormalizeToken(name string) string {
+	token := strings.ToLower(name)
+	token = strings.ReplaceAll(token, "+", "-plus-")
+	token = strings.ReplaceAll(token, "@", "-at-")
+	token = tokenInvalidChars.ReplaceAllString(token, "-")
+	return strings.Trim(token, "-")
+}
+
+// ValidateToken checks that token matches Homebrew's cask token pattern.
+func ValidateToken(token string) error {
+	if !tokenPattern.MatchString(token) {
+		return fmt.Errorf("invalid cask token %q: must match %s — set homebrew.cask.token to a lowercase, hyphenated name", token, tokenPattern.String())
+	}
+	return nil
+}
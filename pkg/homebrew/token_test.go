@@ -0,0 +1,50 @@
+package homebrew
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeToken(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "myapp", want: "myapp"},
+		{name: "MyApp", want: "myapp"},
+		{name: "My App", want: "my-app"},
+		{name: "My_Cool.App", want: "my-cool-app"},
+		{name: "  Spaced  Out  ", want: "spaced-out"},
+		{name: "Notepad++", want: "notepad-plus-plus"},
+		{name: "App@Work", want: "app-at-work"},
+		{name: "Café 2", want: "caf-2"},
+		{name: "!!!", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeToken(tt.name); got != tt.want {
+			t.Errorf("NormalizeToken(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	valid := []string{"myapp", "my-app", "app2", "2fa-app"}
+	for _, token := range valid {
+		if err := ValidateToken(token); err != nil {
+			t.Errorf("ValidateToken(%q) unexpected error: %v", token, err)
+		}
+	}
+
+	invalid := []string{"", "MyApp", "my_app", "-myapp", "my app", "my.app", "../evil", "myapp@beta"}
+	for _, token := range invalid {
+		err := ValidateToken(token)
+		if err == nil {
+			t.Errorf("ValidateToken(%q) expected error, got nil", token)
+			continue
+		}
+		if !strings.Contains(err.Error(), "invalid cask token") {
+			t.Errorf("ValidateToken(%q) error = %q, want error containing %q", token, err.Error(), "invalid cask token")
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WriterHook is a logrus hook that writes every entry to a separate writer
+// using its own formatter. Combined with LevelFilterFormatter it allows a
+// full debug log on disk while the console stays at a quieter level.
+type WriterHook struct {
+	Writer    io.Writer
+	Formatter logrus.Formatter
+
+	mu sync.Mutex
+}
+
+// Levels returns all levels so the hook receives the complete stream.
+func (h *WriterHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire formats the entry and writes it to the hook's writer.
+func (h *WriterHook) Fire(entry *logrus.Entry) error {
+	out, err := h.Formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = h.Writer.Write(out)
+	return err
+}
+
+// LevelFilterFormatter wraps a formatter and drops entries less severe than
+// Level. Use it on the console when the logger level is lowered to feed a
+// more verbose WriterHook.
+type LevelFilterFormatter struct {
+	Formatter logrus.Formatter
+	Level     logrus.Level
+}
+
+func (f *LevelFilterFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if entry.Level > f.Level {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -28,7 +29,30 @@ import (
 //	  x something failed
 //
 // Key-value fields (excluding "action") are appended as key=value pairs.
-type BulletFormatter struct{}
+//
+// When Colors is true, bullet markers are wrapped in ANSI color codes.
+// The zero value emits plain, ANSI-free output suitable for files and CI logs.
+//
+// When ShowTimings is true, action bullets are prefixed with the entry's
+// wall-clock time and suffixed with the time elapsed since the previous
+// action bullet (i.e. how long the previous step took):
+//
+//	  * 14:02:07 signing application  (+1m2.031s)
+type BulletFormatter struct {
+	Colors      bool // colorize bullet markers with ANSI escape codes
+	ShowTimings bool // annotate action bullets with timestamps and step durations
+
+	// lastAction is the time of the previous action entry. logrus serializes
+	// calls to Format under the logger mutex, so no extra locking is needed.
+	lastAction time.Time
+}
+
+// ANSI color codes used for bullet markers
+const (
+	colorRed    = 31
+	colorYellow = 33
+	colorBlue   = 34
+)
 
 func (f *BulletFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	var buf bytes.Buffer
@@ -39,20 +63,30 @@ func (f *BulletFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	switch {
 	case hasAction:
 		// Top-level action bullet
-		fmt.Fprintf(&buf, "  * %s", action)
+		if f.ShowTimings {
+			fmt.Fprintf(&buf, "  %s %s %s", f.colorize(colorBlue, "*"), entry.Time.Format("15:04:05"), action)
+		} else {
+			fmt.Fprintf(&buf, "  %s %s", f.colorize(colorBlue, "*"), action)
+		}
 		// If there's a message beyond the action, add key-value fields
 		kvs := formatFields(entry.Data, "action")
 		if kvs != "" {
 			fmt.Fprintf(&buf, "%s", kvs)
 		}
+		if f.ShowTimings {
+			if !f.lastAction.IsZero() {
+				fmt.Fprintf(&buf, "  (+%s)", entry.Time.Sub(f.lastAction).Round(time.Millisecond))
+			}
+			f.lastAction = entry.Time
+		}
 	case entry.Level == logrus.ErrorLevel:
-		fmt.Fprintf(&buf, "  x %s", entry.Message)
+		fmt.Fprintf(&buf, "  %s %s", f.colorize(colorRed, "x"), entry.Message)
 		kvs := formatFields(entry.Data)
 		if kvs != "" {
 			fmt.Fprintf(&buf, "%s", kvs)
 		}
 	case entry.Level == logrus.WarnLevel:
-		fmt.Fprintf(&buf, "    ! %s", entry.Message)
+		fmt.Fprintf(&buf, "    %s %s", f.colorize(colorYellow, "!"), entry.Message)
 		kvs := formatFields(entry.Data)
 		if kvs != "" {
 			fmt.Fprintf(&buf, "%s", kvs)
@@ -73,6 +107,14 @@ func (f *BulletFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// colorize wraps s in the given ANSI color when colors are enabled.
+func (f *BulletFormatter) colorize(color int, s string) string {
+	if !f.Colors {
+		return s
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", color, s)
+}
+
 // formatFields returns a formatted string of key=value pairs, excluding
 // the specified skip keys. Returns empty string if no fields remain.
 func formatFields(fields logrus.Fields, skip ...string) string {
@@ -1,7 +1,9 @@
 package logging
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -92,3 +94,81 @@ func TestBulletFormatterError(t *testing.T) {
 		t.Errorf("got %q, want %q", string(out), want)
 	}
 }
+
+func TestBulletFormatterColors(t *testing.T) {
+	entries := []*logrus.Entry{
+		{Level: logrus.InfoLevel, Data: logrus.Fields{"action": "building project"}},
+		{Level: logrus.InfoLevel, Message: "sub step", Data: logrus.Fields{}},
+		{Level: logrus.WarnLevel, Message: "some warning", Data: logrus.Fields{}},
+		{Level: logrus.ErrorLevel, Message: "build failed", Data: logrus.Fields{}},
+	}
+
+	plain := &BulletFormatter{}
+	colored := &BulletFormatter{Colors: true}
+
+	for _, entry := range entries {
+		out, err := plain.Format(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(out), "\x1b[") {
+			t.Errorf("plain output contains ANSI escape: %q", string(out))
+		}
+	}
+
+	out, err := colored.Format(entries[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "  \x1b[31mx\x1b[0m build failed\n"
+	if string(out) != want {
+		t.Errorf("got %q, want %q", string(out), want)
+	}
+}
+
+func TestBulletFormatterShowTimings(t *testing.T) {
+	f := &BulletFormatter{ShowTimings: true}
+	start := time.Date(2024, 5, 1, 14, 2, 5, 0, time.UTC)
+
+	entries := []struct {
+		entry *logrus.Entry
+		want  string
+	}{
+		{
+			entry: &logrus.Entry{
+				Time:  start,
+				Level: logrus.InfoLevel,
+				Data:  logrus.Fields{"action": "building project"},
+			},
+			want: "  * 14:02:05 building project\n",
+		},
+		{
+			// Sub-bullets are not annotated and don't reset the step clock
+			entry: &logrus.Entry{
+				Time:    start.Add(time.Second),
+				Level:   logrus.InfoLevel,
+				Message: "archive path: dist/MyApp.xcarchive",
+				Data:    logrus.Fields{},
+			},
+			want: "    * archive path: dist/MyApp.xcarchive\n",
+		},
+		{
+			entry: &logrus.Entry{
+				Time:  start.Add(62*time.Second + 31*time.Millisecond),
+				Level: logrus.InfoLevel,
+				Data:  logrus.Fields{"action": "signing application"},
+			},
+			want: "  * 14:03:07 signing application  (+1m2.031s)\n",
+		},
+	}
+
+	for _, tt := range entries {
+		out, err := f.Format(tt.entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != tt.want {
+			t.Errorf("got %q, want %q", string(out), tt.want)
+		}
+	}
+}
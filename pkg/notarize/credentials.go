@@ -0,0 +1,92 @@
+package notarize
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Credentials authenticate notarytool, either with an Apple ID, team ID,
+// and app-specific password, with an App Store Connect API key when KeyPath
+// is set, or with a keychain profile when KeychainProfile is set.
+type Credentials struct {
+	AppleID  string
+	TeamID   string
+	Password string
+
+	KeyPath  string // .p8 private key of the App Store Connect API key
+	KeyID    string
+	IssuerID string
+
+	KeychainProfile string // profile saved with notarytool store-credentials
+}
+
+// usesAPIKey reports whether c authenticates with an App Store Connect API key.
+func (c Credentials) usesAPIKey() bool {
+	return c.KeyPath != ""
+}
+
+// authArgs returns the notarytool flags that authenticate with c.
+func (c Credentials) authArgs() []string {
+	if c.KeychainProfile != "" {
+		return []string{"--keychain-profile", c.KeychainProfile}
+	}
+	if c.usesAPIKey() {
+		return []string{
+			"--key", c.KeyPath,
+			"--key-id", c.KeyID,
+			"--issuer", c.IssuerID,
+		}
+	}
+	return []string{
+		"--apple-id", c.AppleID,
+		"--team-id", c.TeamID,
+		"--password", c.Password,
+	}
+}
+
+// ascKeyFile is the JSON format of notarize.asc_key_file.
+type ascKeyFile struct {
+	KeyID    string `json:"key_id"`
+	IssuerID string `json:"issuer_id"`
+	KeyPath  string `json:"key_path"`
+}
+
+// LoadASCKeyFile reads App Store Connect API key credentials from the JSON
+// file at path, which must hold key_id, issuer_id, and key_path. A relative
+// key_path is resolved against the directory containing the file.
+func LoadASCKeyFile(path string) (Credentials, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("notarize.asc_key_file %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return Credentials{}, fmt.Errorf("notarize.asc_key_file %s is not a regular file", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read notarize.asc_key_file %s: %w", path, err)
+	}
+	var key ascKeyFile
+	if err := json.Unmarshal(data, &key); err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse notarize.asc_key_file %s: %w", path, err)
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"key_id", key.KeyID},
+		{"issuer_id", key.IssuerID},
+		{"key_path", key.KeyPath},
+	} {
+		if field.value == "" {
+			return Credentials{}, fmt.Errorf("notarize.asc_key_file %s is missing %s", path, field.name)
+		}
+	}
+
+	keyPath := key.KeyPath
+	if !filepath.IsAbs(keyPath) {
+		keyPath = filepath.Join(filepath.Dir(path), keyPath)
+	}
+	return Credentials{KeyPath: keyPath, KeyID: key.KeyID, IssuerID: key.IssuerID}, nil
+}
@@ -0,0 +1,63 @@
+package notarize
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadASCKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "asc.json")
+	content := `{"key_id": "ABC123", "issuer_id": "69a6de70-0000-47e3-e053-5b8c7c11a4d1", "key_path": "AuthKey_ABC123.p8"}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := LoadASCKeyFile(path)
+	if err != nil {
+		t.Fatalf("LoadASCKeyFile() unexpected error: %v", err)
+	}
+	if creds.KeyID != "ABC123" || creds.IssuerID != "69a6de70-0000-47e3-e053-5b8c7c11a4d1" {
+		t.Errorf("LoadASCKeyFile() = %+v, want key and issuer IDs from the file", creds)
+	}
+	if want := filepath.Join(dir, "AuthKey_ABC123.p8"); creds.KeyPath != want {
+		t.Errorf("KeyPath = %q, want %q (relative to the key file)", creds.KeyPath, want)
+	}
+}
+
+func TestLoadASCKeyFileErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+		path    string
+		errMsg  string
+	}{
+		{name: "missing key_id", content: `{"issuer_id": "issuer", "key_path": "key.p8"}`, errMsg: "missing key_id"},
+		{name: "missing issuer_id", content: `{"key_id": "ABC123", "key_path": "key.p8"}`, errMsg: "missing issuer_id"},
+		{name: "missing key_path", content: `{"key_id": "ABC123", "issuer_id": "issuer"}`, errMsg: "missing key_path"},
+		{name: "malformed JSON", content: `{"key_id": `, errMsg: "failed to parse"},
+		{name: "nonexistent file", path: filepath.Join(dir, "missing.json"), errMsg: "no such file"},
+		{name: "directory", path: dir, errMsg: "not a regular file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "-")+".json")
+				if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := LoadASCKeyFile(path)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("LoadASCKeyFile() error = %v, want error containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
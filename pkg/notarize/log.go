@@ -0,0 +1,91 @@
+package notarize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// NotarizationLog is the developer log Apple keeps for a submission, as
+// printed by notarytool log.
+type NotarizationLog struct {
+	JobID         string     `json:"jobId"`
+	Status        string     `json:"status"`
+	StatusSummary string     `json:"statusSummary"`
+	Archive       string     `json:"archiveFilename"`
+	Issues        []LogIssue `json:"issues"`
+}
+
+// LogIssue is a single problem Apple found in a submission.
+type LogIssue struct {
+	Severity     string `json:"severity"`
+	Path         string `json:"path"`
+	Message      string `json:"message"`
+	Architecture string `json:"architecture"`
+	DocURL       string `json:"docUrl"`
+}
+
+// BuildLogArgs returns the argument list for xcrun notarytool log.
+func BuildLogArgs(id string, creds Credentials) []string {
+	return append([]string{"notarytool", "log", id}, creds.authArgs()...)
+}
+
+// RunLog fetches the developer log of submission id with notarytool log.
+func RunLog(ctx context.Context, xcrun, id string, creds Credentials) (string, error) {
+	output, err := command.Run(ctx, xcrunCommand(xcrun), BuildLogArgs(id, creds)...)
+	if command.IsNotFound(err) {
+		return "", xcrunNotFound(xcrun)
+	}
+	if err != nil {
+		return output, fmt.Errorf("notarytool log failed: %s: %w", output, err)
+	}
+	return output, nil
+}
+
+// ParseNotarizationLog parses the JSON log printed by notarytool log. Any
+// text notarytool prints before the JSON document is ignored.
+func ParseNotarizationLog(output string) (NotarizationLog, error) {
+	start := strings.Index(output, "{")
+	if start < 0 {
+		return NotarizationLog{}, fmt.Errorf("notarytool log printed no log")
+	}
+	var log NotarizationLog
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&log); err != nil {
+		return NotarizationLog{}, fmt.Errorf("failed to parse notarization log: %w", err)
+	}
+	return log, nil
+}
+
+// SubmissionInfo is the summary of a submission printed by notarytool info.
+type SubmissionInfo struct {
+	ID          string
+	Name        string
+	CreatedDate string
+	Status      string
+}
+
+// ParseInfo extracts the submission summary from notarytool info output,
+// reading the status with ParseStatus. Fields missing from the output are
+// left empty.
+func ParseInfo(output string) SubmissionInfo {
+	info := SubmissionInfo{Status: ParseStatus(output)}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "id":
+			info.ID = value
+		case "name":
+			info.Name = value
+		case "createdDate":
+			info.CreatedDate = value
+		}
+	}
+	return info
+}
@@ -0,0 +1,86 @@
+package notarize
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testLogOutput = `{
+  "logFormatVersion": 1,
+  "jobId": "2efe2717-52ef-43a5-96dc-0797e4ca1041",
+  "status": "Invalid",
+  "statusSummary": "Archive contains critical validation errors",
+  "statusCode": 4000,
+  "archiveFilename": "App.zip",
+  "ticketContents": null,
+  "issues": [
+    {
+      "severity": "error",
+      "code": null,
+      "path": "App.zip/App.app/Contents/MacOS/App",
+      "message": "The binary is not signed with a valid Developer ID certificate.",
+      "docUrl": "https://developer.apple.com/documentation/security/notarizing_macos_software_before_distribution/resolving_common_notarization_issues",
+      "architecture": "arm64"
+    }
+  ]
+}`
+
+func TestParseNotarizationLog(t *testing.T) {
+	got, err := ParseNotarizationLog("Successfully downloaded submission log\n" + testLogOutput + "\n")
+	if err != nil {
+		t.Fatalf("ParseNotarizationLog() error = %v", err)
+	}
+	want := NotarizationLog{
+		JobID:         testSubmissionID,
+		Status:        StatusInvalid,
+		StatusSummary: "Archive contains critical validation errors",
+		Archive:       "App.zip",
+		Issues: []LogIssue{{
+			Severity:     "error",
+			Path:         "App.zip/App.app/Contents/MacOS/App",
+			Message:      "The binary is not signed with a valid Developer ID certificate.",
+			Architecture: "arm64",
+			DocURL:       "https://developer.apple.com/documentation/security/notarizing_macos_software_before_distribution/resolving_common_notarization_issues",
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseNotarizationLog() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseNotarizationLogErrors(t *testing.T) {
+	for output, errMsg := range map[string]string{
+		"Error: submission not found": "notarytool log printed no log",
+		`{"status": `:                 "failed to parse notarization log",
+	} {
+		if _, err := ParseNotarizationLog(output); err == nil || !strings.Contains(err.Error(), errMsg) {
+			t.Errorf("ParseNotarizationLog(%q) error = %v, want error containing %q", output, err, errMsg)
+		}
+	}
+}
+
+func TestParseInfo(t *testing.T) {
+	got := ParseInfo(infoOutput(StatusInProgress))
+	want := SubmissionInfo{
+		ID:          testSubmissionID,
+		Name:        "App.zip",
+		CreatedDate: "2026-10-16T09:00:00.000Z",
+		Status:      StatusInProgress,
+	}
+	if got != want {
+		t.Errorf("ParseInfo() = %+v, want %+v", got, want)
+	}
+
+	if got := ParseInfo("Error: HTTP status code: 404"); got != (SubmissionInfo{}) {
+		t.Errorf("ParseInfo() of an error = %+v, want no fields", got)
+	}
+}
+
+func TestBuildLogArgs(t *testing.T) {
+	got := strings.Join(BuildLogArgs(testSubmissionID, Credentials{KeychainProfile: "notary"}), " ")
+	want := "notarytool log " + testSubmissionID + " --keychain-profile notary"
+	if got != want {
+		t.Errorf("BuildLogArgs() = %q, want %q", got, want)
+	}
+}
@@ -1,40 +1,45 @@
 package notarize
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+	"github.com/macreleaser/macreleaser/pkg/retry"
 )
 
 var submissionIDRe = regexp.MustCompile(`id:\s*([0-9a-fA-F-]{36})`)
 
 // BuildSubmitArgs returns the argument list for xcrun notarytool submit.
-func BuildSubmitArgs(zipPath, appleID, teamID, password string) []string {
-	return []string{
-		"notarytool", "submit", zipPath,
-		"--apple-id", appleID,
-		"--team-id", teamID,
-		"--password", password,
-		"--wait",
-	}
+// path is a .zip of the app or a .pkg installer, passed through unchanged.
+func BuildSubmitArgs(path string, creds Credentials) []string {
+	return append(BuildSubmitNoWaitArgs(path, creds), "--wait")
 }
 
-// RunSubmit submits the ZIP at zipPath to Apple's notary service using
-// notarytool and waits for the result. Returns combined output and any error.
-func RunSubmit(zipPath, appleID, teamID, password string) (string, error) {
-	if _, err := exec.LookPath("xcrun"); err != nil {
-		return "", fmt.Errorf("xcrun not found — install Xcode Command Line Tools with: xcode-select --install")
-	}
-
-	args := BuildSubmitArgs(zipPath, appleID, teamID, password)
-	cmd := exec.Command("xcrun", args...)
+// RunSubmit submits the ZIP or .pkg at path to Apple's notary service using
+// notarytool and waits for the result. xcrun is the xcrun binary to run, or
+// empty for the one on PATH. Returns combined output and any error.
+func RunSubmit(ctx context.Context, xcrun, path string, creds Credentials) (string, error) {
+	return runSubmitArgs(ctx, xcrun, BuildSubmitArgs(path, creds), creds)
+}
 
-	out, err := cmd.CombinedOutput()
-	output := string(out)
+// runSubmitArgs runs notarytool submit with args and turns a failure into
+// an error that explains it.
+func runSubmitArgs(ctx context.Context, xcrun string, args []string, creds Credentials) (string, error) {
+	output, err := command.Run(ctx, xcrunCommand(xcrun), args...)
+	if command.IsNotFound(err) {
+		return "", xcrunNotFound(xcrun)
+	}
 
 	if err != nil {
 		if strings.Contains(output, "Unable to authenticate") {
+			if creds.usesAPIKey() {
+				return output, fmt.Errorf("notarytool authentication failed — verify key_id, issuer_id, and key_path in notarize.asc_key_file")
+			}
 			return output, fmt.Errorf("notarytool authentication failed — verify apple_id, team_id, and password (use an app-specific password from appleid.apple.com)")
 		}
 		if strings.Contains(output, "Invalid") || strings.Contains(output, "status: Invalid") {
@@ -45,12 +50,129 @@ func RunSubmit(zipPath, appleID, teamID, password string) (string, error) {
 			}
 			return output, fmt.Errorf("Apple rejected the submission%s", hint) //nolint:staticcheck // proper noun
 		}
+		if classifyNotaryError(output) == notaryErrorTransient {
+			return output, &transientError{err: fmt.Errorf("notarytool submit failed: %s: %w", output, err)}
+		}
 		return output, fmt.Errorf("notarytool submit failed: %s: %w", output, err)
 	}
 
 	return output, nil
 }
 
+// notaryErrorClass categorizes a failed notarytool invocation.
+type notaryErrorClass int
+
+const (
+	notaryErrorTerminal  notaryErrorClass = iota // rejection, bad credentials, or unknown failure
+	notaryErrorTransient                         // network failure worth retrying
+)
+
+// transientMarkers are substrings of notarytool output that indicate a
+// network-level failure rather than a problem with the submission itself.
+var transientMarkers = []string{
+	"The network connection was lost",
+	"The Internet connection appears to be offline",
+	"The request timed out",
+	"Could not connect to the server",
+	"A server with the specified hostname could not be found",
+	"NSURLErrorDomain",
+	"Connection reset by peer",
+	"HTTP status code: 500",
+	"HTTP status code: 502",
+	"HTTP status code: 503",
+	"HTTP status code: 504",
+}
+
+// classifyNotaryError reports whether notarytool output describes a transient
+// network failure. Authentication failures and rejections are terminal, and
+// anything unrecognized is treated as terminal so it is not retried blindly.
+func classifyNotaryError(output string) notaryErrorClass {
+	if strings.Contains(output, "Unable to authenticate") || strings.Contains(output, "status: Invalid") {
+		return notaryErrorTerminal
+	}
+	lower := strings.ToLower(output)
+	for _, marker := range transientMarkers {
+		if strings.Contains(lower, strings.ToLower(marker)) {
+			return notaryErrorTransient
+		}
+	}
+	return notaryErrorTerminal
+}
+
+// transientError marks a submission failure caused by a network error.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string { return e.err.Error() }
+func (e *transientError) Unwrap() error { return e.err }
+
+// IsTransient returns true if err is a notarytool failure that is worth retrying.
+func IsTransient(err error) bool {
+	var te *transientError
+	return errors.As(err, &te)
+}
+
+// SubmitAttempts is the maximum number of times RunSubmitWithRetry submits.
+const SubmitAttempts = 3
+
+// submitBackoff is the delay before the first retry; it doubles each attempt.
+const submitBackoff = 15 * time.Second
+
+// Indirections replaced in tests.
+var (
+	runSubmit       = RunSubmit
+	runSubmitNoWait = RunSubmitNoWait
+	sleep           = retry.Sleep
+)
+
+// RunSubmitWithRetry calls RunSubmit, retrying with exponential backoff when
+// the upload fails with a transient network error. A failure after Apple has
+// assigned a submission ID is not retried, since the upload already succeeded
+// and resubmitting would create a duplicate. onRetry, if non-nil, is called
+// before each retry with the attempt that failed. Cancelling ctx ends the wait
+// between attempts.
+func RunSubmitWithRetry(ctx context.Context, xcrun, path string, creds Credentials, onRetry func(attempt int, err error)) (string, error) {
+	return submitWithRetry(ctx, runSubmit, xcrun, path, creds, onRetry)
+}
+
+// submitFunc is RunSubmit or RunSubmitNoWait.
+type submitFunc func(ctx context.Context, xcrun, path string, creds Credentials) (string, error)
+
+func submitWithRetry(ctx context.Context, submit submitFunc, xcrun, path string, creds Credentials, onRetry func(attempt int, err error)) (string, error) {
+	backoff := submitBackoff
+	for attempt := 1; ; attempt++ {
+		output, err := submit(ctx, xcrun, path, creds)
+		if err == nil || !IsTransient(err) || ParseSubmissionID(output) != "" || attempt == SubmitAttempts {
+			return output, err
+		}
+		if onRetry != nil {
+			onRetry(attempt, err)
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return output, err
+		}
+		backoff *= 2
+	}
+}
+
+// xcrunCommand returns the configured xcrun binary, or xcrun from PATH when
+// none is configured.
+func xcrunCommand(xcrun string) string {
+	if xcrun == "" {
+		return "xcrun"
+	}
+	return xcrun
+}
+
+// xcrunNotFound returns the error for a missing xcrun binary.
+func xcrunNotFound(xcrun string) error {
+	if xcrun != "" {
+		return fmt.Errorf("notarize.xcrun_path %s not found", xcrun)
+	}
+	return fmt.Errorf("xcrun not found — install Xcode Command Line Tools with: xcode-select --install")
+}
+
 // ParseSubmissionID extracts the submission UUID from notarytool output.
 // Returns an empty string if no UUID is found.
 func ParseSubmissionID(output string) string {
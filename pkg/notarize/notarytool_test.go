@@ -1,7 +1,13 @@
 package notarize
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 func TestBuildSubmitArgs(t *testing.T) {
@@ -41,11 +47,25 @@ func TestBuildSubmitArgs(t *testing.T) {
 				"--wait",
 			},
 		},
+		{
+			name:     "pkg installer passed through unchanged",
+			zipPath:  "/dist/MyApp-1.0.0.pkg",
+			appleID:  "dev@example.com",
+			teamID:   "TEAM123",
+			password: "xxxx-xxxx-xxxx-xxxx",
+			want: []string{
+				"notarytool", "submit", "/dist/MyApp-1.0.0.pkg",
+				"--apple-id", "dev@example.com",
+				"--team-id", "TEAM123",
+				"--password", "xxxx-xxxx-xxxx-xxxx",
+				"--wait",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := BuildSubmitArgs(tt.zipPath, tt.appleID, tt.teamID, tt.password)
+			got := BuildSubmitArgs(tt.zipPath, Credentials{AppleID: tt.appleID, TeamID: tt.teamID, Password: tt.password})
 			if len(got) != len(tt.want) {
 				t.Fatalf("BuildSubmitArgs() returned %d args, want %d", len(got), len(tt.want))
 			}
@@ -58,8 +78,26 @@ func TestBuildSubmitArgs(t *testing.T) {
 	}
 }
 
+func TestBuildSubmitArgsAPIKey(t *testing.T) {
+	creds := Credentials{KeyPath: "/keys/AuthKey_ABC123.p8", KeyID: "ABC123", IssuerID: "69a6de70-0000-47e3-e053-5b8c7c11a4d1"}
+	got := strings.Join(BuildSubmitArgs("/dist/App.zip", creds), " ")
+	want := "notarytool submit /dist/App.zip --key /keys/AuthKey_ABC123.p8 --key-id ABC123 --issuer 69a6de70-0000-47e3-e053-5b8c7c11a4d1 --wait"
+	if got != want {
+		t.Errorf("BuildSubmitArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestBuildSubmitArgsKeychainProfile(t *testing.T) {
+	creds := Credentials{KeychainProfile: "notary", AppleID: "ignored@example.com"}
+	got := strings.Join(BuildSubmitArgs("/dist/App.zip", creds), " ")
+	want := "notarytool submit /dist/App.zip --keychain-profile notary --wait"
+	if got != want {
+		t.Errorf("BuildSubmitArgs() = %q, want %q", got, want)
+	}
+}
+
 func TestBuildSubmitArgsAlwaysIncludesWait(t *testing.T) {
-	args := BuildSubmitArgs("/path/to/app.zip", "id@example.com", "TEAM", "pass")
+	args := BuildSubmitArgs("/path/to/app.zip", Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"})
 	last := args[len(args)-1]
 	if last != "--wait" {
 		t.Errorf("BuildSubmitArgs() last arg = %q, want %q", last, "--wait")
@@ -67,7 +105,7 @@ func TestBuildSubmitArgsAlwaysIncludesWait(t *testing.T) {
 }
 
 func TestBuildSubmitArgsPasswordPosition(t *testing.T) {
-	args := BuildSubmitArgs("/app.zip", "id@test.com", "T1", "secret123")
+	args := BuildSubmitArgs("/app.zip", Credentials{AppleID: "id@test.com", TeamID: "T1", Password: "secret123"})
 	for i, arg := range args {
 		if arg == "--password" {
 			if i+1 >= len(args) {
@@ -125,3 +163,254 @@ Current status: Accepted`,
 		})
 	}
 }
+
+func TestClassifyNotaryError(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   notaryErrorClass
+	}{
+		{
+			name:   "network connection lost",
+			output: "Error: The network connection was lost.",
+			want:   notaryErrorTransient,
+		},
+		{
+			name:   "offline",
+			output: `Error: Error Domain=NSURLErrorDomain Code=-1009 "The Internet connection appears to be offline."`,
+			want:   notaryErrorTransient,
+		},
+		{
+			name:   "request timed out",
+			output: "Error: The request timed out.",
+			want:   notaryErrorTransient,
+		},
+		{
+			name:   "server error",
+			output: "Error: HTTP status code: 503. Service Unavailable",
+			want:   notaryErrorTransient,
+		},
+		{
+			name:   "authentication failure",
+			output: "Error: HTTP status code: 401. Unable to authenticate. Invalid credentials.",
+			want:   notaryErrorTerminal,
+		},
+		{
+			name: "rejected submission",
+			output: `  id: abcdef01-2345-6789-abcd-ef0123456789
+  status: Invalid`,
+			want: notaryErrorTerminal,
+		},
+		{
+			name:   "unknown failure",
+			output: "Error: something unexpected happened",
+			want:   notaryErrorTerminal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyNotaryError(tt.output); got != tt.want {
+				t.Errorf("classifyNotaryError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunSubmitWithRetry(t *testing.T) {
+	origSubmit, origSleep := runSubmit, sleep
+	t.Cleanup(func() { runSubmit, sleep = origSubmit, origSleep })
+
+	networkErr := &transientError{err: errors.New("notarytool submit failed: The network connection was lost.")}
+
+	tests := []struct {
+		name      string
+		results   []error
+		outputs   []string
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "succeeds after transient failures",
+			results:   []error{networkErr, networkErr, nil},
+			wantCalls: 3,
+		},
+		{
+			name:      "gives up after max attempts",
+			results:   []error{networkErr, networkErr, networkErr, nil},
+			wantCalls: SubmitAttempts,
+			wantErr:   true,
+		},
+		{
+			name:      "terminal failure is not retried",
+			results:   []error{errors.New("Apple rejected the submission"), nil},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "failure after upload is not retried",
+			results:   []error{networkErr, nil},
+			outputs:   []string{"  id: 2efe2717-52ef-43a5-96dc-0797e4ca1041\nThe network connection was lost."},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			runSubmit = func(_ context.Context, _, zipPath string, creds Credentials) (string, error) {
+				output := ""
+				if calls < len(tt.outputs) {
+					output = tt.outputs[calls]
+				}
+				err := tt.results[calls]
+				calls++
+				return output, err
+			}
+			var slept []time.Duration
+			sleep = func(_ context.Context, d time.Duration) error {
+				slept = append(slept, d)
+				return nil
+			}
+
+			retries := 0
+			_, err := RunSubmitWithRetry(context.Background(), "", "/tmp/App.zip", Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"}, func(int, error) { retries++ })
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunSubmitWithRetry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("submit called %d times, want %d", calls, tt.wantCalls)
+			}
+			if retries != tt.wantCalls-1 || len(slept) != tt.wantCalls-1 {
+				t.Errorf("retries = %d, sleeps = %d, want %d", retries, len(slept), tt.wantCalls-1)
+			}
+			for i := 1; i < len(slept); i++ {
+				if slept[i] != 2*slept[i-1] {
+					t.Errorf("backoff %v after %v, want doubling", slept[i], slept[i-1])
+				}
+			}
+		})
+	}
+}
+
+func TestRunSubmitWithRetryCancelled(t *testing.T) {
+	origSubmit := runSubmit
+	t.Cleanup(func() { runSubmit = origSubmit })
+
+	calls := 0
+	runSubmit = func(context.Context, string, string, Credentials) (string, error) {
+		calls++
+		return "", &transientError{err: errors.New("notarytool submit failed: The network connection was lost.")}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := RunSubmitWithRetry(ctx, "", "/tmp/App.zip", Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"}, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunSubmitWithRetry() error = %v, want context.Canceled", err)
+	}
+	if calls != 1 {
+		t.Errorf("submit called %d times, want 1", calls)
+	}
+}
+
+func TestRunSubmit(t *testing.T) {
+	creds := Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"}
+	exitErr := errors.New("exit status 69")
+
+	tests := []struct {
+		name          string
+		output        string
+		runErr        error
+		wantErr       string
+		wantTransient bool
+	}{
+		{
+			name:   "accepted",
+			output: "  id: 2efe2717-52ef-43a5-96dc-0797e4ca1041\n  status: Accepted\n",
+		},
+		{
+			name:    "bad credentials",
+			output:  "Error: HTTP status code: 401. Unable to authenticate.",
+			runErr:  exitErr,
+			wantErr: "notarytool authentication failed",
+		},
+		{
+			name:    "rejected",
+			output:  "  id: 2efe2717-52ef-43a5-96dc-0797e4ca1041\n  status: Invalid\n",
+			runErr:  exitErr,
+			wantErr: "xcrun notarytool log 2efe2717-52ef-43a5-96dc-0797e4ca1041",
+		},
+		{
+			name:          "network failure",
+			output:        "Error: The network connection was lost.",
+			runErr:        exitErr,
+			wantErr:       "notarytool submit failed",
+			wantTransient: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+				return tt.output, tt.runErr
+			}}
+			ctx := command.WithRunner(context.Background(), fake)
+
+			_, err := RunSubmit(ctx, "", "/tmp/App.zip", creds)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("RunSubmit() unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RunSubmit() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			if IsTransient(err) != tt.wantTransient {
+				t.Errorf("IsTransient() = %v, want %v", IsTransient(err), tt.wantTransient)
+			}
+
+			want := "xcrun " + strings.Join(BuildSubmitArgs("/tmp/App.zip", creds), " ")
+			if got := fake.Commands(); len(got) != 1 || got[0] != want {
+				t.Errorf("RunSubmit() ran %v, want [%s]", got, want)
+			}
+		})
+	}
+}
+
+func TestXcrunPath(t *testing.T) {
+	const xcrun = "/Applications/Xcode-15.4.app/Contents/Developer/usr/bin/xcrun"
+	creds := Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"}
+
+	tests := []struct {
+		name  string
+		xcrun string
+		want  string
+	}{
+		{name: "default", want: "xcrun"},
+		{name: "configured", xcrun: xcrun, want: xcrun},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &command.Fake{}
+			ctx := command.WithRunner(context.Background(), fake)
+
+			if _, err := RunSubmit(ctx, tt.xcrun, "/tmp/App.zip", creds); err != nil {
+				t.Fatalf("RunSubmit() unexpected error: %v", err)
+			}
+			if _, err := RunStaple(ctx, tt.xcrun, "/tmp/MyApp.app"); err != nil {
+				t.Fatalf("RunStaple() unexpected error: %v", err)
+			}
+
+			calls := fake.Calls()
+			if len(calls) != 2 || calls[0].Name != tt.want || calls[1].Name != tt.want {
+				t.Errorf("ran %v, want both commands run with %s", fake.Commands(), tt.want)
+			}
+			if calls[0].Args[0] != "notarytool" || calls[1].Args[0] != "stapler" {
+				t.Errorf("ran %v, want notarytool then stapler", fake.Commands())
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package notarize
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+	"github.com/macreleaser/macreleaser/pkg/retry"
+)
+
+// Submission statuses reported by notarytool info.
+const (
+	StatusInProgress = "In Progress"
+	StatusAccepted   = "Accepted"
+	StatusInvalid    = "Invalid"
+	StatusRejected   = "Rejected"
+)
+
+var statusRe = regexp.MustCompile(`(?m)^\s*status:\s*(.+?)\s*$`)
+
+// DefaultPollInterval is how often an async submission is polled when
+// notarize.poll_interval is not set.
+const DefaultPollInterval = 30 * time.Second
+
+// PollOptions controls how PollSubmission waits for a submission.
+type PollOptions struct {
+	Interval time.Duration // delay between status checks; DefaultPollInterval when zero
+	Timeout  time.Duration // give up after this long; zero waits indefinitely
+
+	// OnStatus, if non-nil, is called with each status that differs from
+	// the previous one, starting with the first.
+	OnStatus func(status string)
+}
+
+// ParsePollOptions builds PollOptions from the notarize.poll_interval and
+// notarize.timeout values. An empty interval yields DefaultPollInterval and
+// an empty timeout waits indefinitely.
+func ParsePollOptions(interval, timeout string) (PollOptions, error) {
+	opts := PollOptions{Interval: DefaultPollInterval}
+	var err error
+	if interval != "" {
+		if opts.Interval, err = parsePositiveDuration(interval, "notarize.poll_interval"); err != nil {
+			return PollOptions{}, err
+		}
+	}
+	if timeout != "" {
+		if opts.Timeout, err = parsePositiveDuration(timeout, "notarize.timeout"); err != nil {
+			return PollOptions{}, err
+		}
+	}
+	return opts, nil
+}
+
+func parsePositiveDuration(value, field string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", field, value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be positive", field, value)
+	}
+	return d, nil
+}
+
+// BuildSubmitNoWaitArgs returns the argument list for xcrun notarytool
+// submit without --wait, so it returns as soon as the upload is accepted.
+func BuildSubmitNoWaitArgs(path string, creds Credentials) []string {
+	return append([]string{"notarytool", "submit", path}, creds.authArgs()...)
+}
+
+// RunSubmitNoWait uploads the ZIP or .pkg at path to Apple's notary service
+// without waiting for the result. The submission ID is in the output.
+func RunSubmitNoWait(ctx context.Context, xcrun, path string, creds Credentials) (string, error) {
+	return runSubmitArgs(ctx, xcrun, BuildSubmitNoWaitArgs(path, creds), creds)
+}
+
+// BuildInfoArgs returns the argument list for xcrun notarytool info.
+func BuildInfoArgs(id string, creds Credentials) []string {
+	return append([]string{"notarytool", "info", id}, creds.authArgs()...)
+}
+
+// RunInfo fetches the status of submission id with notarytool info.
+func RunInfo(ctx context.Context, xcrun, id string, creds Credentials) (string, error) {
+	output, err := command.Run(ctx, xcrunCommand(xcrun), BuildInfoArgs(id, creds)...)
+	if command.IsNotFound(err) {
+		return "", xcrunNotFound(xcrun)
+	}
+	if err != nil {
+		if classifyNotaryError(output) == notaryErrorTransient {
+			return output, &transientError{err: fmt.Errorf("notarytool info failed: %s: %w", output, err)}
+		}
+		return output, fmt.Errorf("notarytool info failed: %s: %w", output, err)
+	}
+	return output, nil
+}
+
+// ParseStatus extracts the submission status from notarytool info output.
+// Returns an empty string if no status is found.
+func ParseStatus(output string) string {
+	matches := statusRe.FindStringSubmatch(output)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// Indirections replaced in tests.
+var (
+	now      = time.Now
+	waitPoll = retry.Sleep
+)
+
+// PollSubmission checks the status of submission id every opts.Interval
+// until Apple accepts or rejects it, or opts.Timeout passes. A status check
+// that fails with a network error is retried at the next interval. Returns
+// the output of the last status check.
+func PollSubmission(ctx context.Context, xcrun, id string, creds Credentials, opts PollOptions) (string, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	start := now()
+	last := ""
+	for {
+		output, err := RunInfo(ctx, xcrun, id, creds)
+		if err != nil && !IsTransient(err) {
+			return output, err
+		}
+
+		if status := ParseStatus(output); err == nil && status != last {
+			last = status
+			if opts.OnStatus != nil {
+				opts.OnStatus(status)
+			}
+		}
+
+		switch last {
+		case StatusAccepted:
+			return output, nil
+		case StatusInvalid, StatusRejected:
+			return output, fmt.Errorf("Apple rejected the submission — run: xcrun notarytool log %s to view details", id) //nolint:staticcheck // proper noun
+		}
+
+		if opts.Timeout > 0 && now().Sub(start)+interval > opts.Timeout {
+			return output, fmt.Errorf("submission %s is still %s after %s — check on it later with: xcrun notarytool info %s", id, strings.ToLower(statusOrUnknown(last)), opts.Timeout, id)
+		}
+		if err := waitPoll(ctx, interval); err != nil {
+			return output, err
+		}
+	}
+}
+
+// statusOrUnknown returns status, or "unknown" before any was reported.
+func statusOrUnknown(status string) string {
+	if status == "" {
+		return "unknown"
+	}
+	return status
+}
@@ -0,0 +1,229 @@
+package notarize
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+const testSubmissionID = "2efe2717-52ef-43a5-96dc-0797e4ca1041"
+
+// infoOutput formats notarytool info output for status.
+func infoOutput(status string) string {
+	return "Successfully received submission info\n  createdDate: 2026-10-16T09:00:00.000Z\n  id: " + testSubmissionID + "\n  name: App.zip\n  status: " + status + "\n"
+}
+
+// fakeClock replaces now and waitPoll so each wait advances the clock
+// instead of sleeping.
+func fakeClock(t *testing.T) *[]time.Duration {
+	t.Helper()
+	origNow, origWait := now, waitPoll
+	t.Cleanup(func() { now, waitPoll = origNow, origWait })
+
+	current := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	var waits []time.Duration
+	now = func() time.Time { return current }
+	waitPoll = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		current = current.Add(d)
+		return nil
+	}
+	return &waits
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{infoOutput("In Progress"), StatusInProgress},
+		{infoOutput("Accepted"), StatusAccepted},
+		{infoOutput("Invalid"), StatusInvalid},
+		{"Successfully received submission info\n", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ParseStatus(tt.output); got != tt.want {
+			t.Errorf("ParseStatus(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestPollSubmission(t *testing.T) {
+	network := errors.New("exit status 69")
+	creds := Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"}
+
+	tests := []struct {
+		name         string
+		responses    []string // notarytool info output per call; "" is a network failure
+		timeout      time.Duration
+		wantStatuses []string
+		wantCalls    int
+		errMsg       string
+	}{
+		{
+			name:         "accepted after polling",
+			responses:    []string{infoOutput("In Progress"), infoOutput("In Progress"), infoOutput("Accepted")},
+			wantStatuses: []string{StatusInProgress, StatusAccepted},
+			wantCalls:    3,
+		},
+		{
+			name:         "rejected",
+			responses:    []string{infoOutput("In Progress"), infoOutput("Invalid")},
+			wantStatuses: []string{StatusInProgress, StatusInvalid},
+			wantCalls:    2,
+			errMsg:       "xcrun notarytool log " + testSubmissionID,
+		},
+		{
+			name:         "network failure retried at the next interval",
+			responses:    []string{infoOutput("In Progress"), "", infoOutput("Accepted")},
+			wantStatuses: []string{StatusInProgress, StatusAccepted},
+			wantCalls:    3,
+		},
+		{
+			name:         "timeout",
+			responses:    []string{infoOutput("In Progress"), infoOutput("In Progress"), infoOutput("In Progress"), infoOutput("Accepted")},
+			timeout:      80 * time.Second,
+			wantStatuses: []string{StatusInProgress},
+			wantCalls:    3,
+			errMsg:       "is still in progress after 1m20s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waits := fakeClock(t)
+
+			calls := 0
+			fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+				output := tt.responses[calls]
+				calls++
+				if output == "" {
+					return "Error: The network connection was lost.", network
+				}
+				return output, nil
+			}}
+			ctx := command.WithRunner(context.Background(), fake)
+
+			var statuses []string
+			opts := PollOptions{
+				Interval: 30 * time.Second,
+				Timeout:  tt.timeout,
+				OnStatus: func(status string) { statuses = append(statuses, status) },
+			}
+			_, err := PollSubmission(ctx, "", testSubmissionID, creds, opts)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("PollSubmission() error = %v, want error containing %q", err, tt.errMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("PollSubmission() unexpected error: %v", err)
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("notarytool info called %d times, want %d", calls, tt.wantCalls)
+			}
+			if len(*waits) != tt.wantCalls-1 {
+				t.Errorf("waited %d times, want %d", len(*waits), tt.wantCalls-1)
+			}
+			if strings.Join(statuses, ",") != strings.Join(tt.wantStatuses, ",") {
+				t.Errorf("statuses = %q, want %q", statuses, tt.wantStatuses)
+			}
+			if want := "xcrun " + strings.Join(BuildInfoArgs(testSubmissionID, creds), " "); fake.Commands()[0] != want {
+				t.Errorf("ran %q, want %q", fake.Commands()[0], want)
+			}
+		})
+	}
+}
+
+func TestPollSubmissionAuthFailure(t *testing.T) {
+	fakeClock(t)
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		return "Error: HTTP status code: 401. Unable to authenticate.", errors.New("exit status 69")
+	}}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	_, err := PollSubmission(ctx, "", testSubmissionID, Credentials{}, PollOptions{})
+	if err == nil || !strings.Contains(err.Error(), "notarytool info failed") {
+		t.Errorf("PollSubmission() error = %v, want notarytool info failure", err)
+	}
+	if len(fake.Calls()) != 1 {
+		t.Errorf("notarytool info called %d times, want 1", len(fake.Calls()))
+	}
+}
+
+func TestQueueSubmitAndPoll(t *testing.T) {
+	fakeClock(t)
+	creds := Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"}
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		if args[1] == "submit" {
+			return "Submission ID received\n  id: " + testSubmissionID + "\n", nil
+		}
+		return infoOutput("Accepted"), nil
+	}}
+	ctx := command.WithRunner(context.Background(), fake)
+
+	if _, err := NewQueue(1).SubmitAndPoll(ctx, "", "/tmp/App.zip", creds, nil, PollOptions{}); err != nil {
+		t.Fatalf("SubmitAndPoll() unexpected error: %v", err)
+	}
+
+	want := []string{
+		"xcrun " + strings.Join(BuildSubmitNoWaitArgs("/tmp/App.zip", creds), " "),
+		"xcrun " + strings.Join(BuildInfoArgs(testSubmissionID, creds), " "),
+	}
+	if got := fake.Commands(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ran %q, want %q", got, want)
+	}
+	if strings.Contains(want[0], "--wait") {
+		t.Errorf("async submit should not pass --wait: %s", want[0])
+	}
+}
+
+func TestBuildSubmitNoWaitArgs(t *testing.T) {
+	creds := Credentials{KeychainProfile: "notary"}
+	got := strings.Join(BuildSubmitNoWaitArgs("/tmp/App.zip", creds), " ")
+	want := "notarytool submit /tmp/App.zip --keychain-profile notary"
+	if got != want {
+		t.Errorf("BuildSubmitNoWaitArgs() = %q, want %q", got, want)
+	}
+	if waiting := strings.Join(BuildSubmitArgs("/tmp/App.zip", creds), " "); waiting != want+" --wait" {
+		t.Errorf("BuildSubmitArgs() = %q, want %q", waiting, want+" --wait")
+	}
+}
+
+func TestParsePollOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval string
+		timeout  string
+		want     PollOptions
+		errMsg   string
+	}{
+		{name: "defaults", want: PollOptions{Interval: DefaultPollInterval}},
+		{name: "set", interval: "1m", timeout: "2h", want: PollOptions{Interval: time.Minute, Timeout: 2 * time.Hour}},
+		{name: "invalid interval", interval: "often", errMsg: `invalid notarize.poll_interval "often"`},
+		{name: "zero timeout", timeout: "0s", errMsg: `invalid notarize.timeout "0s": must be positive`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePollOptions(tt.interval, tt.timeout)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("ParsePollOptions() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePollOptions() unexpected error: %v", err)
+			}
+			if got.Interval != tt.want.Interval || got.Timeout != tt.want.Timeout {
+				t.Errorf("ParsePollOptions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package notarize
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Queue caps how many notarization submissions run at once, since Apple
+// throttles accounts that submit many at the same time. Submissions beyond
+// the cap wait for a slot.
+type Queue struct {
+	slots chan struct{}
+}
+
+// NewQueue returns a Queue that lets up to maxConcurrent submissions run at
+// once. A value below 1 allows a single submission at a time.
+func NewQueue(maxConcurrent int) *Queue {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &Queue{slots: make(chan struct{}, maxConcurrent)}
+}
+
+// Submit waits for a free slot and then calls RunSubmitWithRetry, holding
+// the slot until the submission and its retries finish. It returns the
+// context's error if ctx is cancelled while waiting.
+func (q *Queue) Submit(ctx context.Context, xcrun, path string, creds Credentials, onRetry func(attempt int, err error)) (string, error) {
+	select {
+	case q.slots <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-q.slots }()
+
+	return RunSubmitWithRetry(ctx, xcrun, path, creds, onRetry)
+}
+
+// SubmitAndPoll waits for a free slot, uploads the submission without
+// waiting for Apple's verdict (retrying network failures as Submit does),
+// and then polls its status with PollSubmission. The slot is held until
+// polling finishes. Returns the output of the last status check.
+func (q *Queue) SubmitAndPoll(ctx context.Context, xcrun, path string, creds Credentials, onRetry func(attempt int, err error), poll PollOptions) (string, error) {
+	select {
+	case q.slots <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-q.slots }()
+
+	output, err := submitWithRetry(ctx, runSubmitNoWait, xcrun, path, creds, onRetry)
+	if err != nil {
+		return output, err
+	}
+	id := ParseSubmissionID(output)
+	if id == "" {
+		return output, fmt.Errorf("notarytool submit did not report a submission ID: %s", output)
+	}
+	return PollSubmission(ctx, xcrun, id, creds, poll)
+}
+
+var (
+	sharedQueuesMu sync.Mutex
+	sharedQueues   = make(map[int]*Queue)
+)
+
+// SharedQueue returns the process-wide Queue for maxConcurrent, so that
+// pipelines running in parallel with the same notarize.max_concurrent share
+// its slots.
+func SharedQueue(maxConcurrent int) *Queue {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	sharedQueuesMu.Lock()
+	defer sharedQueuesMu.Unlock()
+	q, ok := sharedQueues[maxConcurrent]
+	if !ok {
+		q = NewQueue(maxConcurrent)
+		sharedQueues[maxConcurrent] = q
+	}
+	return q
+}
@@ -0,0 +1,99 @@
+package notarize
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+func TestQueueCapsConcurrentSubmissions(t *testing.T) {
+	creds := Credentials{AppleID: "id@example.com", TeamID: "TEAM", Password: "pass"}
+
+	for _, maxConcurrent := range []int{0, 1, 2, 3} {
+		t.Run(fmt.Sprintf("max_concurrent=%d", maxConcurrent), func(t *testing.T) {
+			var running, peak atomic.Int32
+			fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				running.Add(-1)
+				return "  status: Accepted", nil
+			}}
+			ctx := command.WithRunner(context.Background(), fake)
+
+			q := NewQueue(maxConcurrent)
+			const submissions = 8
+			var wg sync.WaitGroup
+			for i := 0; i < submissions; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					if _, err := q.Submit(ctx, "", fmt.Sprintf("/tmp/App%d.zip", i), creds, nil); err != nil {
+						t.Errorf("Submit() unexpected error: %v", err)
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			limit := int32(max(maxConcurrent, 1))
+			if got := peak.Load(); got > limit {
+				t.Errorf("%d submissions ran at once, want at most %d", got, limit)
+			}
+			if got := len(fake.Calls()); got != submissions {
+				t.Errorf("ran %d submissions, want %d", got, submissions)
+			}
+		})
+	}
+}
+
+func TestQueueSubmitCancelledWhileWaiting(t *testing.T) {
+	release := make(chan struct{})
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		<-release
+		return "", nil
+	}}
+	q := NewQueue(1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = q.Submit(command.WithRunner(context.Background(), fake), "", "/tmp/First.zip", Credentials{}, nil)
+	}()
+	for len(fake.Calls()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(command.WithRunner(context.Background(), fake))
+	cancel()
+	if _, err := q.Submit(ctx, "", "/tmp/Second.zip", Credentials{}, nil); err != context.Canceled {
+		t.Errorf("Submit() error = %v, want %v", err, context.Canceled)
+	}
+	if got := len(fake.Calls()); got != 1 {
+		t.Errorf("ran %d submissions, want 1", got)
+	}
+
+	close(release)
+	<-done
+}
+
+func TestSharedQueue(t *testing.T) {
+	if SharedQueue(2) != SharedQueue(2) {
+		t.Error("SharedQueue(2) returned different queues")
+	}
+	if SharedQueue(0) != SharedQueue(1) {
+		t.Error("SharedQueue(0) and SharedQueue(1) should share the single-slot queue")
+	}
+	if SharedQueue(1) == SharedQueue(2) {
+		t.Error("SharedQueue(1) and SharedQueue(2) should be different queues")
+	}
+}
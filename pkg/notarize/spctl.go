@@ -1,29 +1,92 @@
 package notarize
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
+	"path/filepath"
 	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// AssessType is the spctl assessment type used to evaluate an artifact.
+type AssessType string
+
+const (
+	AssessExecute AssessType = "execute" // launching an .app
+	AssessOpen    AssessType = "open"    // opening a downloaded .dmg
+	AssessInstall AssessType = "install" // installing a .pkg
 )
 
-// RunAssess verifies the app at appPath passes Gatekeeper assessment
-// using spctl --assess. Returns combined output and any error.
-func RunAssess(appPath string) (string, error) {
-	if _, err := exec.LookPath("spctl"); err != nil {
+// AssessTypeFor returns the assessment type matching the artifact at path:
+// open for .dmg, install for .pkg, and execute for anything else.
+func AssessTypeFor(path string) AssessType {
+	switch filepath.Ext(path) {
+	case ".dmg":
+		return AssessOpen
+	case ".pkg":
+		return AssessInstall
+	default:
+		return AssessExecute
+	}
+}
+
+// RunAssess verifies the artifact at path passes Gatekeeper assessment of
+// the given type using spctl --assess. Returns combined output and any error.
+func RunAssess(ctx context.Context, path string, assessType AssessType) (string, error) {
+	output, err := command.Run(ctx, "spctl", assessArgs(path, assessType)...)
+	if command.IsNotFound(err) {
 		return "", fmt.Errorf("spctl not found — this tool is required for Gatekeeper verification on macOS")
 	}
 
-	cmd := exec.Command("spctl", "--assess", "--type", "execute", "--verbose", appPath)
+	switch assessType {
+	case AssessOpen:
+		return output, checkDMGAssessment(output, err)
+	case AssessInstall:
+		return output, checkAssessment(output, err, "installer")
+	default:
+		return output, checkAssessment(output, err, "app")
+	}
+}
+
+// assessArgs returns the spctl arguments assessing path as assessType.
+// Disk images are assessed in the primary-signature context that applies
+// to downloaded disk images.
+func assessArgs(path string, assessType AssessType) []string {
+	args := []string{"--assess", "--type", string(assessType)}
+	if assessType == AssessOpen {
+		args = append(args, "--context", "context:primary-signature")
+	}
+	return append(args, "--verbose", path)
+}
 
-	out, err := cmd.CombinedOutput()
-	output := string(out)
+// checkAssessment interprets spctl output for an app or installer
+// assessment; kind names the artifact in the rejection error.
+func checkAssessment(output string, runErr error, kind string) error {
+	if runErr == nil {
+		return nil
+	}
+	if strings.Contains(output, "rejected") {
+		return fmt.Errorf("Gatekeeper rejected the %s — it may not be properly signed or notarized", kind) //nolint:staticcheck // proper noun
+	}
+	return fmt.Errorf("spctl assess failed: %s: %w", output, runErr)
+}
 
-	if err != nil {
-		if strings.Contains(output, "rejected") {
-			return output, fmt.Errorf("Gatekeeper rejected the app — it may not be properly signed or notarized") //nolint:staticcheck // proper noun
-		}
-		return output, fmt.Errorf("spctl assess failed: %s: %w", output, err)
+// checkDMGAssessment interprets spctl output for a DMG assessment, turning
+// common rejection reasons into actionable errors.
+func checkDMGAssessment(output string, runErr error) error {
+	if runErr == nil && !strings.Contains(output, "rejected") {
+		return nil
 	}
 
-	return output, nil
+	switch {
+	case strings.Contains(output, "no usable signature"):
+		return fmt.Errorf("Gatekeeper rejected the DMG: it is not signed — sign it with codesign --sign \"Developer ID Application: ...\" before notarizing") //nolint:staticcheck // proper noun
+	case strings.Contains(output, "Unnotarized Developer ID"):
+		return fmt.Errorf("Gatekeeper rejected the DMG: it is signed but not notarized — submit the DMG to notarytool and staple it") //nolint:staticcheck // proper noun
+	case strings.Contains(output, "rejected"):
+		return fmt.Errorf("Gatekeeper rejected the DMG — users downloading it will be blocked: %s", strings.TrimSpace(output)) //nolint:staticcheck // proper noun
+	default:
+		return fmt.Errorf("spctl DMG assessment failed: %s: %w", output, runErr)
+	}
 }
@@ -0,0 +1,125 @@
+package notarize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckDMGAssessment(t *testing.T) {
+	exitErr := errors.New("exit status 3")
+
+	tests := []struct {
+		name    string
+		output  string
+		runErr  error
+		wantErr string
+	}{
+		{
+			name:   "notarized and stapled",
+			output: "dist/MyApp-1.0.0.dmg: accepted\nsource=Notarized Developer ID\n",
+		},
+		{
+			name:    "unsigned dmg",
+			output:  "dist/MyApp-1.0.0.dmg: rejected\nsource=no usable signature\n",
+			runErr:  exitErr,
+			wantErr: "it is not signed",
+		},
+		{
+			name:    "signed but not notarized",
+			output:  "dist/MyApp-1.0.0.dmg: rejected\nsource=Unnotarized Developer ID\n",
+			runErr:  exitErr,
+			wantErr: "signed but not notarized",
+		},
+		{
+			name:    "other rejection",
+			output:  "dist/MyApp-1.0.0.dmg: rejected\nsource=Developer ID\norigin=Developer ID Application: Someone Else (ZZZ)\n",
+			runErr:  exitErr,
+			wantErr: "users downloading it will be blocked",
+		},
+		{
+			name:    "tool failure",
+			output:  "spctl: unable to open dist/missing.dmg",
+			runErr:  exitErr,
+			wantErr: "spctl DMG assessment failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDMGAssessment(tt.output, tt.runErr)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkDMGAssessment() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkDMGAssessment() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAssessTypeFor(t *testing.T) {
+	tests := map[string]AssessType{
+		"dist/MyApp.app":       AssessExecute,
+		"dist/MyApp-1.0.0.dmg": AssessOpen,
+		"dist/MyApp-1.0.0.pkg": AssessInstall,
+	}
+	for path, want := range tests {
+		if got := AssessTypeFor(path); got != want {
+			t.Errorf("AssessTypeFor(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestAssessArgs(t *testing.T) {
+	tests := []struct {
+		path       string
+		assessType AssessType
+		want       string
+	}{
+		{
+			path:       "dist/My App.app",
+			assessType: AssessExecute,
+			want:       "--assess --type execute --verbose|dist/My App.app",
+		},
+		{
+			path:       "dist/MyApp-1.0.0.dmg",
+			assessType: AssessOpen,
+			want:       "--assess --type open --context context:primary-signature --verbose|dist/MyApp-1.0.0.dmg",
+		},
+		{
+			path:       "dist/MyApp-1.0.0.pkg",
+			assessType: AssessInstall,
+			want:       "--assess --type install --verbose|dist/MyApp-1.0.0.pkg",
+		},
+	}
+
+	for _, tt := range tests {
+		args := assessArgs(tt.path, tt.assessType)
+		got := strings.Join(args[:len(args)-1], " ") + "|" + args[len(args)-1]
+		if got != tt.want {
+			t.Errorf("assessArgs(%q, %q) = %q, want %q", tt.path, tt.assessType, got, tt.want)
+		}
+	}
+}
+
+func TestCheckAssessment(t *testing.T) {
+	exitErr := errors.New("exit status 3")
+
+	if err := checkAssessment("dist/MyApp.app: accepted\nsource=Notarized Developer ID\n", nil, "app"); err != nil {
+		t.Errorf("checkAssessment() unexpected error: %v", err)
+	}
+
+	err := checkAssessment("dist/MyApp-1.0.0.pkg: rejected\nsource=Unnotarized Developer ID\n", exitErr, "installer")
+	if err == nil || !strings.Contains(err.Error(), "Gatekeeper rejected the installer") {
+		t.Errorf("checkAssessment() error = %v, want installer rejection", err)
+	}
+
+	err = checkAssessment("spctl: unable to open dist/missing.app", exitErr, "app")
+	if err == nil || !strings.Contains(err.Error(), "spctl assess failed") {
+		t.Errorf("checkAssessment() error = %v, want tool failure", err)
+	}
+}
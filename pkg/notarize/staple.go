@@ -1,23 +1,22 @@
 package notarize
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
-// RunStaple staples the notarization ticket to the .app at appPath
-// using xcrun stapler. Returns combined output and any error.
-func RunStaple(appPath string) (string, error) {
-	if _, err := exec.LookPath("xcrun"); err != nil {
-		return "", fmt.Errorf("xcrun not found — install Xcode Command Line Tools with: xcode-select --install")
+// RunStaple staples the notarization ticket to the .app or .pkg at path
+// using xcrun stapler. xcrun is the xcrun binary to run, or empty for the
+// one on PATH. Returns combined output and any error.
+func RunStaple(ctx context.Context, xcrun, path string) (string, error) {
+	output, err := command.Run(ctx, xcrunCommand(xcrun), "stapler", "staple", path)
+	if command.IsNotFound(err) {
+		return "", xcrunNotFound(xcrun)
 	}
 
-	cmd := exec.Command("xcrun", "stapler", "staple", appPath)
-
-	out, err := cmd.CombinedOutput()
-	output := string(out)
-
 	if err != nil {
 		if strings.Contains(output, "Could not find ticket") {
 			return output, fmt.Errorf("stapling failed — the notarization ticket was not found; ensure notarytool submission succeeded")
@@ -27,3 +26,25 @@ func RunStaple(appPath string) (string, error) {
 
 	return output, nil
 }
+
+// StaplerValidate checks that a notarization ticket is stapled to the .app,
+// .dmg, or .pkg at path using xcrun stapler validate. Returns combined
+// output and any error.
+func StaplerValidate(ctx context.Context, path string) (string, error) {
+	output, err := command.Run(ctx, "xcrun", "stapler", "validate", path)
+	if command.IsNotFound(err) {
+		return "", xcrunNotFound("")
+	}
+	return output, checkStaplerValidate(output, err)
+}
+
+// checkStaplerValidate interprets xcrun stapler validate output.
+func checkStaplerValidate(output string, runErr error) error {
+	if runErr == nil {
+		return nil
+	}
+	if strings.Contains(output, "does not have a ticket stapled to it") {
+		return fmt.Errorf("no notarization ticket is stapled — notarize it and run: xcrun stapler staple")
+	}
+	return fmt.Errorf("stapler validate failed: %s: %w", strings.TrimSpace(output), runErr)
+}
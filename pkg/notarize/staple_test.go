@@ -0,0 +1,58 @@
+package notarize
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+func TestStaplerValidate(t *testing.T) {
+	exitErr := errors.New("exit status 65")
+
+	tests := []struct {
+		name    string
+		output  string
+		runErr  error
+		wantErr string
+	}{
+		{
+			name:   "ticket stapled",
+			output: "Processing: /tmp/MyApp.dmg\nThe validate action worked!\n",
+		},
+		{
+			name:    "no ticket",
+			output:  "Processing: /tmp/MyApp.dmg\nMyApp.dmg does not have a ticket stapled to it.\n",
+			runErr:  exitErr,
+			wantErr: "no notarization ticket is stapled",
+		},
+		{
+			name:    "tool failure",
+			output:  "Processing: /tmp/MyApp.dmg\nCloudKit query for MyApp.dmg failed due to \"Network unavailable\".\n",
+			runErr:  exitErr,
+			wantErr: "stapler validate failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+				return tt.output, tt.runErr
+			}}
+			_, err := StaplerValidate(command.WithRunner(context.Background(), fake), "/tmp/MyApp.dmg")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("StaplerValidate() unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("StaplerValidate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+
+			if got := fake.Commands(); len(got) != 1 || got[0] != "xcrun stapler validate /tmp/MyApp.dmg" {
+				t.Errorf("StaplerValidate() ran %v, want [xcrun stapler validate /tmp/MyApp.dmg]", got)
+			}
+		})
+	}
+}
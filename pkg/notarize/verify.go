@@ -0,0 +1,39 @@
+package notarize
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// RunVerifyStapled re-verifies the code signature of the app at appPath with
+// codesign --verify --deep --strict after its ticket was stapled. Returns
+// combined output and any error.
+func RunVerifyStapled(ctx context.Context, appPath string) (string, error) {
+	output, err := command.Run(ctx, "codesign", "--verify", "--deep", "--strict", appPath)
+	if command.IsNotFound(err) {
+		return "", fmt.Errorf("codesign not found — install Xcode Command Line Tools with: xcode-select --install")
+	}
+	return output, checkStapledSignature(output, err)
+}
+
+// checkStapledSignature interprets codesign --verify output for a stapled
+// app. A signature that no longer verifies means stapling altered the
+// bundle, which must not be shipped.
+func checkStapledSignature(output string, runErr error) error {
+	if runErr == nil {
+		return nil
+	}
+
+	switch {
+	case strings.Contains(output, "a sealed resource is missing or invalid"),
+		strings.Contains(output, "file added"),
+		strings.Contains(output, "file modified"),
+		strings.Contains(output, "invalid signature (code or signature have been modified)"):
+		return fmt.Errorf("the signature no longer verifies after stapling — the bundle changed after it was signed; do not ship it, and re-sign and notarize again: %s", strings.TrimSpace(output))
+	default:
+		return fmt.Errorf("signature verification after stapling failed: %s: %w", strings.TrimSpace(output), runErr)
+	}
+}
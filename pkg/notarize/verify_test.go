@@ -0,0 +1,62 @@
+package notarize
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckStapledSignature(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	tests := []struct {
+		name    string
+		output  string
+		runErr  error
+		wantErr string
+	}{
+		{
+			name:   "signature intact",
+			output: "",
+		},
+		{
+			name:    "sealed resource modified",
+			output:  "dist/MyApp.app: a sealed resource is missing or invalid\nfile modified: dist/MyApp.app/Contents/Resources/Info.plist\n",
+			runErr:  exitErr,
+			wantErr: "no longer verifies after stapling",
+		},
+		{
+			name:    "file added to bundle",
+			output:  "dist/MyApp.app: a sealed resource is missing or invalid\nfile added: dist/MyApp.app/Contents/CodeResources.bak\n",
+			runErr:  exitErr,
+			wantErr: "do not ship it",
+		},
+		{
+			name:    "signature modified",
+			output:  "dist/MyApp.app: invalid signature (code or signature have been modified)\n",
+			runErr:  exitErr,
+			wantErr: "no longer verifies after stapling",
+		},
+		{
+			name:    "tool failure",
+			output:  "dist/MyApp.app: No such file or directory",
+			runErr:  exitErr,
+			wantErr: "signature verification after stapling failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStapledSignature(tt.output, tt.runErr)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkStapledSignature() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkStapledSignature() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
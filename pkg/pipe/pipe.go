@@ -25,6 +25,22 @@ type IsSkip interface {
 	IsSkip() bool
 }
 
+// SoftFailer is implemented by pipes whose failure does not undo what
+// earlier pipes published, such as committing the Homebrew cask once the
+// GitHub release exists. With ContinueOnError set on the context, the
+// pipeline records their failures and goes on with the remaining pipes.
+type SoftFailer interface {
+	SoftFail() bool
+}
+
+// Skipper is implemented by pipes that decide whether to skip from flags
+// and configuration alone, before running. Skip returns the reason the pipe
+// will be skipped, or an empty string if it runs. It lets the pipes command
+// report skips without running anything.
+type Skipper interface {
+	Skip(ctx *context.Context) string
+}
+
 // SkipError represents an intentional skip of a pipeline step.
 // Unlike regular errors, skips do not fail the pipeline but instead
 // cause the pipeline to continue with the next pipe.
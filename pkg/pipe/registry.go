@@ -1,6 +1,7 @@
 package pipe
 
 import (
+	"github.com/macreleaser/macreleaser/internal/pipe/announce"
 	"github.com/macreleaser/macreleaser/internal/pipe/archive"
 	"github.com/macreleaser/macreleaser/internal/pipe/build"
 	"github.com/macreleaser/macreleaser/internal/pipe/changelog"
@@ -22,6 +23,7 @@ var ValidationPipes = []Piper{
 	changelog.CheckPipe{}, // Validate changelog config
 	release.CheckPipe{},   // Validate release config
 	homebrew.CheckPipe{}, // Validate homebrew config
+	announce.CheckPipe{}, // Validate announce config
 }
 
 // ExecutionPipes contains all execution pipes, run after validation
@@ -31,7 +33,16 @@ var ExecutionPipes = []Piper{
 	sign.Pipe{},       // Code sign with Hardened Runtime
 	notarize.Pipe{},   // Submit, wait, staple .app
 	archive.Pipe{},    // Package stapled .app into zip/dmg
+	notarize.DMGPipe{}, // Sign, notarize, staple, and assess .dmg
 	changelog.Pipe{},  // Generate changelog from git history
 	release.Pipe{},    // Create GitHub release and upload assets
 	homebrew.Pipe{},   // Generate cask and commit to tap
+	announce.Pipe{},   // Write the Atom feed of releases
+}
+
+// NotarizePipes contains the pipes run by the notarize command against an
+// already-built .app, .dmg, or .pkg.
+var NotarizePipes = []Piper{
+	notarize.CheckPipe{}, // Validate notarization config
+	notarize.Pipe{},      // Submit, wait, staple, and assess
 }
@@ -37,40 +37,83 @@ func RunExecution(ctx *context.Context) error {
 }
 
 // RunAll executes validation pipes first, then execution pipes.
-// Used by build, release, and snapshot commands.
+// Used by build, release, and snapshot commands. When ctx.SkipValidation
+// is set the validation stage is skipped with a warning.
 func RunAll(ctx *context.Context) error {
-	if err := RunValidation(ctx); err != nil {
+	if ctx.SkipValidation {
+		ctx.Logger.Warn("SKIPPING VALIDATION (--skip-validation) — the configuration has not been checked, and a bad setting may only fail partway through the release")
+	} else if err := RunValidation(ctx); err != nil {
 		return err
 	}
 	return RunExecution(ctx)
 }
 
-// runPipes executes a slice of pipes in sequence.
+// RunPipes executes the given pipes in sequence with the same logging,
+// skip handling, and timing as the standard stages. Used by commands that
+// run a subset of the pipeline, such as notarize.
+func RunPipes(ctx *context.Context, pipes []Piper) error {
+	return runPipes(ctx, pipes)
+}
+
+// runPipes executes a slice of pipes in sequence. With ctx.ContinueOnError,
+// a failing pipe that implements pipe.SoftFailer is logged and the rest
+// still run; its error is returned, with any others, once all are done.
 func runPipes(ctx *context.Context, pipes []Piper) error {
+	var softErrs []error
 	for _, p := range pipes {
 		ctx.Logger.WithField("action", p.String()).Info()
 		start := time.Now()
 
-		if err := p.Run(ctx); err != nil {
+		err := p.Run(ctx)
+		duration := time.Since(start)
+		if err != nil {
 			if isSkip(err) {
+				ctx.Timings = append(ctx.Timings, context.StepTiming{Name: p.String(), Duration: duration, Skipped: true})
 				ctx.Logger.Warnf("skipped: %v", err)
 				continue
 			}
-			return fmt.Errorf("%s: %w", p.String(), err)
+			err = fmt.Errorf("%s: %w", p.String(), err)
+			if ctx.ContinueOnError && isSoftFail(p) {
+				ctx.Timings = append(ctx.Timings, context.StepTiming{Name: p.String(), Duration: duration, Failed: true})
+				ctx.Logger.Errorf("%v — continuing (--continue-on-error)", err)
+				softErrs = append(softErrs, err)
+				continue
+			}
+			if len(softErrs) > 0 {
+				return errors.Join(append(softErrs, err)...)
+			}
+			return err
 		}
 
-		duration := time.Since(start)
+		ctx.Timings = append(ctx.Timings, context.StepTiming{Name: p.String(), Duration: duration})
 		if duration >= time.Second {
 			ctx.Logger.Infof("took: %s", duration.Round(time.Millisecond))
 		}
 	}
+	if len(softErrs) > 0 {
+		return fmt.Errorf("%d step(s) failed: %w", len(softErrs), errors.Join(softErrs...))
+	}
 	return nil
 }
 
+// SkipReason returns why p will be skipped for ctx, or an empty string if it
+// runs or only decides once it runs.
+func SkipReason(ctx *context.Context, p Piper) string {
+	if s, ok := p.(pipe.Skipper); ok {
+		return s.Skip(ctx)
+	}
+	return ""
+}
+
 func isSkip(err error) bool {
 	var s pipe.IsSkip
 	return errors.As(err, &s) && s.IsSkip()
 }
 
+func isSoftFail(p Piper) bool {
+	s, ok := p.(pipe.SoftFailer)
+	return ok && s.SoftFail()
+}
+
 // Piper is re-exported for convenience within the pipeline package.
 type Piper = pipe.Piper
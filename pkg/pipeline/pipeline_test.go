@@ -3,10 +3,16 @@ package pipeline
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/macreleaser/macreleaser/internal/pipe/release"
+	"github.com/macreleaser/macreleaser/pkg/command"
 	"github.com/macreleaser/macreleaser/pkg/config"
 	macContext "github.com/macreleaser/macreleaser/pkg/context"
+	"github.com/macreleaser/macreleaser/pkg/github"
 	"github.com/macreleaser/macreleaser/pkg/pipe"
 	"github.com/sirupsen/logrus"
 )
@@ -19,6 +25,11 @@ type mockPipe struct {
 func (m mockPipe) String() string                     { return m.name }
 func (m mockPipe) Run(ctx *macContext.Context) error { return m.err }
 
+// softPipe is a mockPipe that opts in to soft failure.
+type softPipe struct{ mockPipe }
+
+func (softPipe) SoftFail() bool { return true }
+
 func newContext() *macContext.Context {
 	logger := logrus.New()
 	logger.SetLevel(logrus.DebugLevel)
@@ -67,6 +78,34 @@ func TestRunPipesSkip(t *testing.T) {
 	}
 }
 
+func TestRunPipesRecordsTimings(t *testing.T) {
+	pipes := []Piper{
+		mockPipe{name: "step1"},
+		mockPipe{name: "step2", err: pipe.Skip("not needed")},
+		mockPipe{name: "step3"},
+	}
+
+	ctx := newContext()
+	if err := runPipes(ctx, pipes); err != nil {
+		t.Fatalf("runPipes() error = %v", err)
+	}
+
+	if len(ctx.Timings) != 3 {
+		t.Fatalf("recorded %d timings, want 3", len(ctx.Timings))
+	}
+	for i, want := range []string{"step1", "step2", "step3"} {
+		if ctx.Timings[i].Name != want {
+			t.Errorf("Timings[%d].Name = %q, want %q", i, ctx.Timings[i].Name, want)
+		}
+	}
+	if !ctx.Timings[1].Skipped {
+		t.Error("Timings[1].Skipped = false, want true")
+	}
+	if ctx.Timings[0].Skipped || ctx.Timings[2].Skipped {
+		t.Error("non-skipped pipes recorded as skipped")
+	}
+}
+
 func TestRunValidation(t *testing.T) {
 	// Just verify RunValidation doesn't panic when called
 	// Full validation requires a real config, so we test the wiring here
@@ -86,3 +125,274 @@ func TestRunAllStopsOnValidationFailure(t *testing.T) {
 		t.Fatal("expected error with empty config")
 	}
 }
+
+func TestRunAllSkipValidation(t *testing.T) {
+	original := pipe.ExecutionPipes
+	pipe.ExecutionPipes = []Piper{mockPipe{name: "execution"}}
+	t.Cleanup(func() { pipe.ExecutionPipes = original })
+
+	ctx := newContext()
+	ctx.SkipValidation = true
+	// The empty config fails validation, so execution only runs if it is skipped
+	if err := RunAll(ctx); err != nil {
+		t.Fatalf("RunAll() unexpected error: %v", err)
+	}
+	if len(ctx.Timings) != 1 || ctx.Timings[0].Name != "execution" {
+		t.Errorf("Timings = %+v, want only the execution pipe", ctx.Timings)
+	}
+}
+
+func TestRunAllSkipValidationDetectsRepository(t *testing.T) {
+	original := pipe.ExecutionPipes
+	pipe.ExecutionPipes = []Piper{release.Pipe{}}
+	t.Cleanup(func() { pipe.ExecutionPipes = original })
+	t.Setenv("GITHUB_REPOSITORY", "acme/myapp")
+
+	zipPath := filepath.Join(t.TempDir(), "MyApp-1.0.0.zip")
+	if err := os.WriteFile(zipPath, []byte("zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// release.github.owner and repo are unset, and left to be detected
+	ctx := newContext()
+	ctx.Config.Project.Name = "MyApp"
+	ctx.Version = "v1.0.0"
+	ctx.SkipValidation = true
+	ctx.Artifacts.Packages = []string{zipPath}
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	if err := RunAll(ctx); err != nil {
+		t.Fatalf("RunAll() unexpected error: %v", err)
+	}
+	if got := ctx.Config.Release.GitHub.Owner + "/" + ctx.Config.Release.GitHub.Repo; got != "acme/myapp" {
+		t.Errorf("release.github owner/repo = %q, want acme/myapp", got)
+	}
+	if len(mock.Releases["acme/myapp"]) != 1 {
+		t.Errorf("Releases = %v, want one release in acme/myapp", mock.Releases)
+	}
+}
+
+func TestRunAllSkipValidationChecksBranch(t *testing.T) {
+	original := pipe.ExecutionPipes
+	pipe.ExecutionPipes = []Piper{release.Pipe{}}
+	t.Cleanup(func() { pipe.ExecutionPipes = original })
+
+	zipPath := filepath.Join(t.TempDir(), "MyApp-1.0.0.zip")
+	if err := os.WriteFile(zipPath, []byte("zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := newContext()
+	ctx.Config.Project.Name = "MyApp"
+	ctx.Config.Release.GitHub.Owner = "acme"
+	ctx.Config.Release.GitHub.Repo = "myapp"
+	ctx.Config.Release.AllowedBranches = []string{"main"}
+	ctx.Git.Commit = "abc123"
+	ctx.Git.Branch = "feature/x"
+	ctx.Version = "v1.0.0"
+	ctx.SkipValidation = true
+	ctx.Artifacts.Packages = []string{zipPath}
+	mock := github.NewMockClient()
+	ctx.GitHubClient = mock
+
+	err := RunAll(ctx)
+	if err == nil || !strings.Contains(err.Error(), "not in release.allowed_branches") {
+		t.Fatalf("RunAll() error = %v, want the branch to be rejected", err)
+	}
+	if len(mock.ReleaseRequests) != 0 {
+		t.Errorf("created %d releases from a disallowed branch, want none", len(mock.ReleaseRequests))
+	}
+}
+
+func TestRunPipesContinueOnError(t *testing.T) {
+	ran := false
+	pipes := []Piper{
+		mockPipe{name: "release"},
+		softPipe{mockPipe{name: "homebrew", err: errors.New("tap push rejected")}},
+		softPipe{mockPipe{name: "feed", err: errors.New("disk full")}},
+		funcPipe{name: "last", run: func() { ran = true }},
+	}
+
+	ctx := newContext()
+	ctx.ContinueOnError = true
+	err := runPipes(ctx, pipes)
+	if err == nil {
+		t.Fatal("expected an aggregated error")
+	}
+	for _, want := range []string{"2 step(s) failed", "homebrew: tap push rejected", "feed: disk full"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to contain %q", err.Error(), want)
+		}
+	}
+	if !ran {
+		t.Error("pipes after a soft failure should still run")
+	}
+	if len(ctx.Timings) != 4 || !ctx.Timings[1].Failed || !ctx.Timings[2].Failed || ctx.Timings[3].Failed {
+		t.Errorf("Timings = %+v, want the two soft failures marked Failed", ctx.Timings)
+	}
+}
+
+func TestRunPipesSoftFailureStopsWithoutContinueOnError(t *testing.T) {
+	pipes := []Piper{
+		softPipe{mockPipe{name: "homebrew", err: errors.New("tap push rejected")}},
+		mockPipe{name: "after", err: errors.New("should not run")},
+	}
+
+	err := runPipes(newContext(), pipes)
+	if err == nil || err.Error() != "homebrew: tap push rejected" {
+		t.Errorf("error = %v, want the soft pipe's error to stop the pipeline", err)
+	}
+}
+
+func TestRunPipesHardFailureAfterSoftFailure(t *testing.T) {
+	pipes := []Piper{
+		softPipe{mockPipe{name: "homebrew", err: errors.New("tap push rejected")}},
+		mockPipe{name: "critical", err: errors.New("broken")},
+		mockPipe{name: "after", err: errors.New("should not run")},
+	}
+
+	ctx := newContext()
+	ctx.ContinueOnError = true
+	err := runPipes(ctx, pipes)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "homebrew: tap push rejected") || !strings.Contains(err.Error(), "critical: broken") {
+		t.Errorf("error = %q, want both failures reported", err.Error())
+	}
+	if strings.Contains(err.Error(), "should not run") {
+		t.Errorf("a hard failure should stop the pipeline, got %q", err.Error())
+	}
+}
+
+// funcPipe calls run when it runs.
+type funcPipe struct {
+	name string
+	run  func()
+}
+
+func (f funcPipe) String() string { return f.name }
+func (f funcPipe) Run(*macContext.Context) error {
+	f.run()
+	return nil
+}
+
+func TestRunAllAppOnly(t *testing.T) {
+	dir := t.TempDir()
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	// xcodebuild lists the scheme and archives the app; nothing else may run
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		switch {
+		case name == "xcodebuild" && len(args) > 0 && args[len(args)-1] == "-json":
+			return `{"project": {"schemes": ["MyApp"]}}`, nil
+		case name == "xcodebuild":
+			return "", os.MkdirAll(filepath.Join("dist", "MyApp.xcarchive", "Products", "Applications", "MyApp.app"), 0755)
+		}
+		t.Errorf("unexpected command %s %v", name, args)
+		return "", nil
+	}}
+
+	logger := logrus.New()
+	cfg := &config.Config{
+		Project: config.ProjectConfig{Name: "MyApp", Scheme: "MyApp", Workspace: "MyApp.xcodeproj"},
+		Build:   config.BuildConfig{Configuration: "Debug"},
+	}
+	ctx := macContext.NewContext(command.WithRunner(context.Background(), fake), cfg, logger)
+	ctx.Version = "v1.0.0"
+	ctx.SkipPublish = true
+	ctx.AppOnly = true
+
+	if err := RunAll(ctx); err != nil {
+		t.Fatalf("RunAll() unexpected error: %v", err)
+	}
+
+	if want := filepath.Join("dist", "MyApp.app"); ctx.Artifacts.AppPath != want {
+		t.Errorf("AppPath = %q, want %q", ctx.Artifacts.AppPath, want)
+	}
+	if len(ctx.Artifacts.Packages) != 0 {
+		t.Errorf("Packages = %v, want none", ctx.Artifacts.Packages)
+	}
+	for _, timing := range ctx.Timings {
+		ran := !timing.Skipped
+		wantRun := timing.Name == "validating project configuration" ||
+			timing.Name == "validating build configuration" ||
+			timing.Name == "building project"
+		if ran != wantRun {
+			t.Errorf("step %q ran = %v, want %v", timing.Name, ran, wantRun)
+		}
+	}
+}
+
+func TestRunAllAssessesEachArtifact(t *testing.T) {
+	dir := t.TempDir()
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(origDir) }()
+
+	fake := &command.Fake{Handler: func(name string, args []string) (string, error) {
+		switch {
+		case name == "xcodebuild" && len(args) > 0 && args[len(args)-1] == "-json":
+			return `{"project": {"schemes": ["MyApp"]}}`, nil
+		case name == "xcodebuild":
+			return "", os.MkdirAll(filepath.Join("dist", "MyApp.xcarchive", "Products", "Applications", "MyApp.app", "Contents", "MacOS"), 0755)
+		case name == "security" && len(args) > 0 && args[0] == "find-identity":
+			return `  1) AABBCCDDEE1234567890AABBCCDDEE12345678 "Developer ID Application: John Doe (TEAM123)"
+     1 valid identities found`, nil
+		case name == "hdiutil" && len(args) > 0 && args[0] == "create":
+			return "", os.WriteFile(args[len(args)-1], []byte("dmg"), 0644)
+		}
+		return "", nil
+	}}
+
+	cfg := &config.Config{
+		Project: config.ProjectConfig{Name: "MyApp", Scheme: "MyApp", Workspace: "MyApp.xcodeproj"},
+		Build:   config.BuildConfig{Configuration: "Release"},
+		Sign:    config.SignConfig{Identity: "Developer ID Application: John Doe (TEAM123)"},
+		Notarize: config.NotarizeConfig{
+			AppleID:  "dev@example.com",
+			TeamID:   "TEAM123",
+			Password: "abcd-efgh-ijkl-mnop",
+		},
+		Archive:   config.ArchiveConfig{Formats: config.ArchiveFormats{{Type: "dmg"}}},
+		Changelog: config.ChangelogConfig{Disable: true},
+	}
+	ctx := macContext.NewContext(command.WithRunner(context.Background(), fake), cfg, logrus.New())
+	ctx.Version = "v1.0.0"
+	ctx.SkipPublish = true
+
+	if err := RunAll(ctx); err != nil {
+		t.Fatalf("RunAll() unexpected error: %v\n%s", err, strings.Join(fake.Commands(), "\n"))
+	}
+
+	// The app is assessed as an executable once notarized, and the DMG as a
+	// disk image once it has been built, signed, and notarized
+	dmg := filepath.Join("dist", "MyApp-v1.0.0.dmg")
+	var assessed []string
+	created := false
+	for _, c := range fake.Calls() {
+		switch c.Name {
+		case "hdiutil":
+			created = true
+		case "spctl":
+			if c.Args[len(c.Args)-1] == dmg && !created {
+				t.Errorf("%s assessed before the DMG was created", c)
+			}
+			assessed = append(assessed, c.String())
+		}
+	}
+	want := []string{
+		"spctl --assess --type execute --verbose " + filepath.Join("dist", "MyApp.app"),
+		"spctl --assess --type open --context context:primary-signature --verbose " + dmg,
+	}
+	if strings.Join(assessed, "\n") != strings.Join(want, "\n") {
+		t.Errorf("assessments =\n%s\nwant:\n%s", strings.Join(assessed, "\n"), strings.Join(want, "\n"))
+	}
+}
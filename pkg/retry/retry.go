@@ -0,0 +1,100 @@
+// Package retry holds the retry policy shared by networked operations such
+// as GitHub API requests.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Policy describes how often a failed operation is attempted and how long
+// to wait between attempts. The delay starts at BaseDelay and doubles with
+// each retry, never exceeding MaxDelay.
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Default is the policy used when retry is not configured.
+var Default = Policy{
+	MaxAttempts: 3,
+	BaseDelay:   time.Second,
+	MaxDelay:    30 * time.Second,
+}
+
+// Parse builds a Policy from the retry config values. Zero or empty values
+// fall back to Default.
+func Parse(maxAttempts int, baseDelay, maxDelay string) (Policy, error) {
+	p := Default
+
+	if maxAttempts < 0 {
+		return Policy{}, fmt.Errorf("retry.max_attempts must not be negative, got %d", maxAttempts)
+	}
+	if maxAttempts > 0 {
+		p.MaxAttempts = maxAttempts
+	}
+
+	var err error
+	if p.BaseDelay, err = parseDelay(baseDelay, "retry.base_delay", p.BaseDelay); err != nil {
+		return Policy{}, err
+	}
+	if p.MaxDelay, err = parseDelay(maxDelay, "retry.max_delay", p.MaxDelay); err != nil {
+		return Policy{}, err
+	}
+	if p.BaseDelay > p.MaxDelay {
+		return Policy{}, fmt.Errorf("retry.base_delay (%s) must not be greater than retry.max_delay (%s)", p.BaseDelay, p.MaxDelay)
+	}
+
+	return p, nil
+}
+
+// parseDelay parses a duration config value, returning def when it is empty.
+func parseDelay(value, field string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", field, value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be positive", field, value)
+	}
+	return d, nil
+}
+
+// Delay returns how long to wait after the given failed attempt, counting
+// from 1, before trying again.
+func (p Policy) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		// Stop doubling once the cap is reached, which also keeps d from
+		// overflowing on large attempt counts
+		if d >= p.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// Sleep waits for d between attempts, returning early with the context's
+// error if it is cancelled.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	p := Policy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{6, 10 * time.Second},
+		{1000, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := p.Delay(tt.attempt); got != tt.want {
+			t.Errorf("Delay(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		baseDelay   string
+		maxDelay    string
+		want        Policy
+		errMsg      string
+	}{
+		{
+			name: "defaults",
+			want: Default,
+		},
+		{
+			name:        "all set",
+			maxAttempts: 5,
+			baseDelay:   "500ms",
+			maxDelay:    "1m",
+			want:        Policy{MaxAttempts: 5, BaseDelay: 500 * time.Millisecond, MaxDelay: time.Minute},
+		},
+		{
+			name:        "negative attempts",
+			maxAttempts: -1,
+			errMsg:      "retry.max_attempts must not be negative",
+		},
+		{
+			name:      "invalid base delay",
+			baseDelay: "soon",
+			errMsg:    `invalid retry.base_delay "soon"`,
+		},
+		{
+			name:     "zero max delay",
+			maxDelay: "0s",
+			errMsg:   `invalid retry.max_delay "0s": must be positive`,
+		},
+		{
+			name:      "base above max",
+			baseDelay: "1m",
+			maxDelay:  "10s",
+			errMsg:    "retry.base_delay (1m0s) must not be greater than retry.max_delay (10s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.maxAttempts, tt.baseDelay, tt.maxDelay)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("expected error containing %q, got %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSleep(t *testing.T) {
+	if err := Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("Sleep() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	if err := Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sleep() error = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Sleep() took %s after cancellation, want an early return", elapsed)
+	}
+}
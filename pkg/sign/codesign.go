@@ -1,32 +1,39 @@
 package sign
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 // RunCodesign signs the app bundle at appPath with the given identity
 // using --deep --force flags. When hardenedRuntime is true, --options runtime
-// is included (required for notarization). Returns combined output and any error.
-func RunCodesign(identity, appPath string, hardenedRuntime bool) (string, error) {
-	if _, err := exec.LookPath("codesign"); err != nil {
-		return "", fmt.Errorf("codesign not found — install Xcode Command Line Tools with: xcode-select --install")
-	}
+// is included (required for notarization). A non-empty keychain makes
+// codesign look the identity up in that keychain file only. Returns combined
+// output and any error.
+func RunCodesign(ctx context.Context, identity, appPath string, hardenedRuntime bool, keychain string) (string, error) {
+	return runCodesign(ctx, appPath, codesignArgs(identity, appPath, hardenedRuntime, keychain, true))
+}
 
-	args := []string{"--deep", "--force"}
-	if hardenedRuntime {
-		args = append(args, "--options", "runtime")
-	}
-	args = append(args, "--sign", identity, appPath)
-	cmd := exec.Command("codesign", args...)
+// RunCodesignItem signs the single bundle or library at path like
+// RunCodesign, but without --deep, so nested code keeps the signature it
+// already has. Used to sign a bundle item by item in BuildSignPlan order.
+func RunCodesignItem(ctx context.Context, identity, path string, hardenedRuntime bool, keychain string) (string, error) {
+	return runCodesign(ctx, path, codesignArgs(identity, path, hardenedRuntime, keychain, false))
+}
 
-	out, err := cmd.CombinedOutput()
-	output := string(out)
+// runCodesign runs codesign with args to sign path.
+func runCodesign(ctx context.Context, path string, args []string) (string, error) {
+	output, err := command.Run(ctx, "codesign", args...)
+	if command.IsNotFound(err) {
+		return "", fmt.Errorf("codesign not found — install Xcode Command Line Tools with: xcode-select --install")
+	}
 
 	if err != nil {
 		if strings.Contains(output, "resource fork, Finder information, or similar detritus") {
-			return output, fmt.Errorf("codesign failed due to extended attributes — remove them with: xattr -cr %s", appPath)
+			return output, fmt.Errorf("codesign failed due to extended attributes — remove them with: xattr -cr %s", path)
 		}
 		return output, fmt.Errorf("codesign failed: %s: %w", output, err)
 	}
@@ -34,18 +41,31 @@ func RunCodesign(identity, appPath string, hardenedRuntime bool) (string, error)
 	return output, nil
 }
 
+// codesignArgs builds the codesign arguments used by RunCodesign and
+// RunCodesignItem.
+func codesignArgs(identity, path string, hardenedRuntime bool, keychain string, deep bool) []string {
+	var args []string
+	if deep {
+		args = append(args, "--deep")
+	}
+	args = append(args, "--force")
+	if hardenedRuntime {
+		args = append(args, "--options", "runtime")
+	}
+	if keychain != "" {
+		args = append(args, "--keychain", keychain)
+	}
+	return append(args, "--sign", identity, path)
+}
+
 // RunVerify verifies the code signature of the app bundle at appPath
 // using --deep --strict flags. Returns combined output and any error.
-func RunVerify(appPath string) (string, error) {
-	if _, err := exec.LookPath("codesign"); err != nil {
+func RunVerify(ctx context.Context, appPath string) (string, error) {
+	output, err := command.Run(ctx, "codesign", "--verify", "--deep", "--strict", appPath)
+	if command.IsNotFound(err) {
 		return "", fmt.Errorf("codesign not found — install Xcode Command Line Tools with: xcode-select --install")
 	}
 
-	cmd := exec.Command("codesign", "--verify", "--deep", "--strict", appPath)
-
-	out, err := cmd.CombinedOutput()
-	output := string(out)
-
 	if err != nil {
 		return output, fmt.Errorf("signature verification failed for %s: %s: %w", appPath, output, err)
 	}
@@ -0,0 +1,49 @@
+package sign
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodesignArgs(t *testing.T) {
+	identity := "Developer ID Application: John Doe (TEAM123)"
+
+	tests := []struct {
+		name            string
+		hardenedRuntime bool
+		keychain        string
+		shallow         bool
+		want            []string
+	}{
+		{
+			name: "default keychain search list",
+			want: []string{"--deep", "--force", "--sign", identity, "MyApp.app"},
+		},
+		{
+			name:            "hardened runtime",
+			hardenedRuntime: true,
+			want:            []string{"--deep", "--force", "--options", "runtime", "--sign", identity, "MyApp.app"},
+		},
+		{
+			name:            "specific keychain",
+			hardenedRuntime: true,
+			keychain:        "/tmp/build.keychain-db",
+			want:            []string{"--deep", "--force", "--options", "runtime", "--keychain", "/tmp/build.keychain-db", "--sign", identity, "MyApp.app"},
+		},
+		{
+			name:            "single item without --deep",
+			hardenedRuntime: true,
+			shallow:         true,
+			want:            []string{"--force", "--options", "runtime", "--sign", identity, "MyApp.app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := codesignArgs(identity, "MyApp.app", tt.hardenedRuntime, tt.keychain, !tt.shallow)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("codesignArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
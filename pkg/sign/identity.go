@@ -1,10 +1,12 @@
 package sign
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"regexp"
 	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
 )
 
 // identityPattern matches lines from `security find-identity -v -p codesigning` output.
@@ -54,21 +56,56 @@ func ValidateIdentity(configuredIdentity string, availableIdentities []string) e
 	return fmt.Errorf("%s", b.String())
 }
 
-// CheckIdentityInKeychain runs `security find-identity -v -p codesigning`,
-// parses the output, and validates that the configured identity is present.
-func CheckIdentityInKeychain(configuredIdentity string) error {
-	if _, err := exec.LookPath("security"); err != nil {
-		return fmt.Errorf("security command not found — this tool requires macOS")
+// SelectIdentity returns the identity at the given 1-based choice, as shown
+// to the user in a numbered list. Returns an error if choice is out of range.
+func SelectIdentity(identities []string, choice int) (string, error) {
+	if len(identities) == 0 {
+		return "", fmt.Errorf("no valid signing identities are installed")
+	}
+	if choice < 1 || choice > len(identities) {
+		return "", fmt.Errorf("invalid selection %d: choose a number between 1 and %d", choice, len(identities))
 	}
+	return identities[choice-1], nil
+}
 
-	cmd := exec.Command("security", "find-identity", "-v", "-p", "codesigning")
-	out, err := cmd.CombinedOutput()
-	output := string(out)
+// ListIdentities runs `security find-identity -v -p codesigning` and returns
+// the valid code signing identities in the keychain search list.
+func ListIdentities(ctx context.Context) ([]string, error) {
+	return ListKeychainIdentities(ctx, "")
+}
+
+// ListKeychainIdentities returns the valid code signing identities in the
+// given keychain file, or in the keychain search list when keychain is empty.
+func ListKeychainIdentities(ctx context.Context, keychain string) ([]string, error) {
+	output, err := command.Run(ctx, "security", findIdentityArgs(keychain)...)
+	if command.IsNotFound(err) {
+		return nil, fmt.Errorf("security command not found — this tool requires macOS")
+	}
 
 	if err != nil {
-		return fmt.Errorf("failed to list signing identities: %s: %w", output, err)
+		return nil, fmt.Errorf("failed to list signing identities: %s: %w", output, err)
 	}
 
-	identities := ParseIdentityOutput(output)
+	return ParseIdentityOutput(output), nil
+}
+
+// findIdentityArgs builds the `security` arguments that list code signing
+// identities, limited to keychain when it is set.
+func findIdentityArgs(keychain string) []string {
+	args := []string{"find-identity", "-v", "-p", "codesigning"}
+	if keychain != "" {
+		args = append(args, keychain)
+	}
+	return args
+}
+
+// CheckIdentityInKeychain lists the signing identities in keychain (or the
+// keychain search list when empty) and validates that the configured
+// identity is present.
+func CheckIdentityInKeychain(ctx context.Context, configuredIdentity, keychain string) error {
+	identities, err := ListKeychainIdentities(ctx, keychain)
+	if err != nil {
+		return err
+	}
 	return ValidateIdentity(configuredIdentity, identities)
 }
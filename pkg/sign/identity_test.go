@@ -1,6 +1,7 @@
 package sign
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -130,3 +131,56 @@ func TestValidateIdentity(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectIdentity(t *testing.T) {
+	identities := []string{
+		"Developer ID Application: John Doe (TEAM123)",
+		"Apple Development: john@example.com (PERSONAL)",
+		"Developer ID Application: Other Co (OTHER99)",
+	}
+
+	tests := []struct {
+		name       string
+		identities []string
+		choice     int
+		want       string
+		wantErr    bool
+		errContain string
+	}{
+		{name: "first", identities: identities, choice: 1, want: identities[0]},
+		{name: "last", identities: identities, choice: 3, want: identities[2]},
+		{name: "zero", identities: identities, choice: 0, wantErr: true, errContain: "between 1 and 3"},
+		{name: "past end", identities: identities, choice: 4, wantErr: true, errContain: "between 1 and 3"},
+		{name: "negative", identities: identities, choice: -1, wantErr: true, errContain: "invalid selection -1"},
+		{name: "no identities", identities: nil, choice: 1, wantErr: true, errContain: "no valid signing identities"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectIdentity(tt.identities, tt.choice)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectIdentity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errContain) {
+					t.Errorf("SelectIdentity() error = %v, want error containing %q", err, tt.errContain)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SelectIdentity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindIdentityArgs(t *testing.T) {
+	if got, want := findIdentityArgs(""), []string{"find-identity", "-v", "-p", "codesigning"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("findIdentityArgs(\"\") = %v, want %v", got, want)
+	}
+
+	want := []string{"find-identity", "-v", "-p", "codesigning", "/tmp/build.keychain-db"}
+	if got := findIdentityArgs("/tmp/build.keychain-db"); !reflect.DeepEqual(got, want) {
+		t.Errorf("findIdentityArgs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package sign
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/macreleaser/macreleaser/pkg/command"
+)
+
+// passwordMask replaces the keychain password wherever a command line or
+// its output is reported
+const passwordMask = "********"
+
+// UnlockKeychain runs `security unlock-keychain` on keychain, or on the
+// default keychain when keychain is empty. The password is never included
+// in the returned error.
+func UnlockKeychain(ctx context.Context, password, keychain string) error {
+	return runSecurity(ctx, unlockKeychainArgs(password, keychain), password)
+}
+
+// unlockKeychainArgs builds the `security` arguments that unlock keychain
+// with password.
+func unlockKeychainArgs(password, keychain string) []string {
+	args := []string{"unlock-keychain", "-p", password}
+	if keychain != "" {
+		args = append(args, keychain)
+	}
+	return args
+}
+
+// DisableKeychainAutoLock runs `security set-keychain-settings` on keychain
+// without a timeout or lock-on-sleep option, so it stays unlocked for the
+// rest of a long notarization.
+func DisableKeychainAutoLock(ctx context.Context, keychain string) error {
+	return runSecurity(ctx, keychainSettingsArgs(keychain), "")
+}
+
+// keychainSettingsArgs builds the `security` arguments that clear the
+// auto-lock settings of keychain.
+func keychainSettingsArgs(keychain string) []string {
+	return []string{"set-keychain-settings", keychain}
+}
+
+// runSecurity runs the security command with args. Any occurrence of secret
+// in the reported command line or output is masked.
+func runSecurity(ctx context.Context, args []string, secret string) error {
+	out, err := command.Run(ctx, "security", args...)
+	if command.IsNotFound(err) {
+		return fmt.Errorf("security command not found — this tool requires macOS")
+	}
+	if err != nil {
+		return fmt.Errorf("%s failed: %s: %w", maskSecret(securityCommandLine(args), secret), maskSecret(strings.TrimSpace(out), secret), err)
+	}
+	return nil
+}
+
+// securityCommandLine formats args as a security command line for messages
+func securityCommandLine(args []string) string {
+	return "security " + strings.Join(args, " ")
+}
+
+// maskSecret replaces every occurrence of secret in s with passwordMask
+func maskSecret(s, secret string) string {
+	if secret == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, secret, passwordMask)
+}
@@ -0,0 +1,34 @@
+package sign
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnlockKeychainArgs(t *testing.T) {
+	want := []string{"unlock-keychain", "-p", "hunter2", "/tmp/build.keychain-db"}
+	if got := unlockKeychainArgs("hunter2", "/tmp/build.keychain-db"); !reflect.DeepEqual(got, want) {
+		t.Errorf("unlockKeychainArgs() = %v, want %v", got, want)
+	}
+
+	want = []string{"unlock-keychain", "-p", "hunter2"}
+	if got := unlockKeychainArgs("hunter2", ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("unlockKeychainArgs() without keychain = %v, want %v", got, want)
+	}
+
+	want = []string{"set-keychain-settings", "/tmp/build.keychain-db"}
+	if got := keychainSettingsArgs("/tmp/build.keychain-db"); !reflect.DeepEqual(got, want) {
+		t.Errorf("keychainSettingsArgs() = %v, want %v", got, want)
+	}
+}
+
+func TestUnlockKeychainCommandLineMasksPassword(t *testing.T) {
+	line := maskSecret(securityCommandLine(unlockKeychainArgs("hunter2", "/tmp/build.keychain-db")), "hunter2")
+	if strings.Contains(line, "hunter2") {
+		t.Errorf("command line reveals the password: %s", line)
+	}
+	if want := "security unlock-keychain -p ******** /tmp/build.keychain-db"; line != want {
+		t.Errorf("command line = %q, want %q", line, want)
+	}
+}
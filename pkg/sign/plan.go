@@ -0,0 +1,156 @@
+package sign
+
+import (
+	"debug/macho"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// nestedCodeExts are the extensions of nested bundles and libraries that
+// are signed individually before the bundle containing them.
+var nestedCodeExts = map[string]bool{
+	".app":             true,
+	".appex":           true,
+	".bundle":          true,
+	".dylib":           true,
+	".framework":       true,
+	".plugin":          true,
+	".systemextension": true,
+	".xpc":             true,
+}
+
+// BuildSignPlan returns the paths to sign for the bundle at appPath, in
+// signing order: nested code (frameworks, app extensions, XPC services,
+// plug-ins, dylibs, and any other Mach-O file, such as a helper tool without
+// an extension) deepest first, then the bundle itself. A bundle's main
+// executable is signed with its bundle and is not listed. Entries whose
+// path relative to the bundle matches one of the skip globs are left out
+// together with everything inside them, so already-signed third-party code
+// keeps its signature. Symlinks are not followed.
+func BuildSignPlan(appPath string, skip []string) ([]string, error) {
+	var nested []string
+	err := filepath.WalkDir(appPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == appPath || d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(appPath, path)
+		if err != nil {
+			return err
+		}
+		skipped, err := matchesAny(skip, filepath.ToSlash(rel))
+		if err != nil {
+			return err
+		}
+		if skipped {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if nestedCodeExts[filepath.Ext(path)] {
+			nested = append(nested, path)
+			return nil
+		}
+		if !d.Type().IsRegular() || isMainExecutable(path) {
+			return nil
+		}
+		machO, err := isMachO(path)
+		if err != nil {
+			return err
+		}
+		if machO {
+			nested = append(nested, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", appPath, err)
+	}
+
+	// Inner code must be signed before the code containing it
+	sort.SliceStable(nested, func(i, j int) bool {
+		return depth(nested[i]) > depth(nested[j])
+	})
+	return append(nested, appPath), nil
+}
+
+// isMachO reports whether the file at path starts with a Mach-O header, thin
+// or universal.
+func isMachO(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var header [8]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	switch binary.BigEndian.Uint32(header[:4]) {
+	case macho.Magic32, macho.Magic64, 0xcefaedfe, 0xcffaedfe:
+		return true, nil
+	case macho.MagicFat, 0xcafebabf:
+		// Java class files share the universal magic; there the next word
+		// is the class file version, which is far above any architecture
+		// count
+		return binary.BigEndian.Uint32(header[4:]) < 45, nil
+	}
+	return false, nil
+}
+
+// isMainExecutable reports whether path is the main executable of the
+// bundle containing it, by the usual naming: Contents/MacOS/<name> in
+// <name>.app and its kin, or Versions/<v>/<name> in <name>.framework.
+func isMainExecutable(path string) bool {
+	name := filepath.Base(path)
+	dir := filepath.Dir(path)
+	parent := filepath.Dir(dir)
+
+	var bundle string
+	switch {
+	case filepath.Base(dir) == "MacOS" && filepath.Base(parent) == "Contents":
+		bundle = filepath.Dir(parent)
+	case filepath.Base(parent) == "Versions" && filepath.Ext(filepath.Dir(parent)) == ".framework":
+		bundle = filepath.Dir(parent)
+	default:
+		return false
+	}
+	base := filepath.Base(bundle)
+	return strings.TrimSuffix(base, filepath.Ext(base)) == name
+}
+
+// matchesAny reports whether rel matches one of the globs.
+func matchesAny(globs []string, rel string) (bool, error) {
+	for _, glob := range globs {
+		matched, err := filepath.Match(glob, rel)
+		if err != nil {
+			return false, fmt.Errorf("invalid sign.skip_paths entry %q: %w", glob, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// depth returns the number of path elements in path.
+func depth(path string) int {
+	return strings.Count(filepath.Clean(path), string(filepath.Separator))
+}
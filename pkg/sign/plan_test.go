@@ -0,0 +1,162 @@
+package sign
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// makeBundle creates the given files (and their directories) under root.
+func makeBundle(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, f := range files {
+		path := filepath.Join(root, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("binary"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBuildSignPlan(t *testing.T) {
+	app := filepath.Join(t.TempDir(), "MyApp.app")
+	makeBundle(t, app,
+		"Contents/MacOS/MyApp",
+		"Contents/Info.plist",
+		"Contents/Frameworks/Core.framework/Versions/A/Core",
+		"Contents/Frameworks/Core.framework/Versions/A/Frameworks/Inner.framework/Versions/A/Inner",
+		"Contents/Frameworks/Sparkle.framework/Versions/B/Sparkle",
+		"Contents/Frameworks/Sparkle.framework/Versions/B/XPCServices/Downloader.xpc/Contents/MacOS/Downloader",
+		"Contents/Frameworks/libswift.dylib",
+		"Contents/PlugIns/Share.appex/Contents/MacOS/Share",
+	)
+	// Framework version symlinks are not signed separately
+	if err := os.Symlink("A", filepath.Join(app, "Contents/Frameworks/Core.framework/Versions/Current")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		skip []string
+		want []string
+	}{
+		{
+			name: "no skips",
+			want: []string{
+				"Contents/Frameworks/Core.framework/Versions/A/Frameworks/Inner.framework",
+				"Contents/Frameworks/Sparkle.framework/Versions/B/XPCServices/Downloader.xpc",
+				"Contents/Frameworks/Core.framework",
+				"Contents/Frameworks/Sparkle.framework",
+				"Contents/Frameworks/libswift.dylib",
+				"Contents/PlugIns/Share.appex",
+				"",
+			},
+		},
+		{
+			name: "skip an already-signed framework and its contents",
+			skip: []string{"Contents/Frameworks/Sparkle.framework"},
+			want: []string{
+				"Contents/Frameworks/Core.framework/Versions/A/Frameworks/Inner.framework",
+				"Contents/Frameworks/Core.framework",
+				"Contents/Frameworks/libswift.dylib",
+				"Contents/PlugIns/Share.appex",
+				"",
+			},
+		},
+		{
+			name: "skip by glob",
+			skip: []string{"Contents/Frameworks/*.dylib", "Contents/PlugIns/*"},
+			want: []string{
+				"Contents/Frameworks/Core.framework/Versions/A/Frameworks/Inner.framework",
+				"Contents/Frameworks/Sparkle.framework/Versions/B/XPCServices/Downloader.xpc",
+				"Contents/Frameworks/Core.framework",
+				"Contents/Frameworks/Sparkle.framework",
+				"",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := BuildSignPlan(app, tt.skip)
+			if err != nil {
+				t.Fatalf("BuildSignPlan() unexpected error: %v", err)
+			}
+			var got []string
+			for _, path := range plan {
+				rel, err := filepath.Rel(app, path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if rel == "." {
+					rel = ""
+				}
+				got = append(got, rel)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildSignPlan() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// machOHeader is the start of a 64-bit arm64 Mach-O executable, as written
+// on disk.
+var machOHeader = []byte{0xcf, 0xfa, 0xed, 0xfe, 0x0c, 0x00, 0x00, 0x01}
+
+func TestBuildSignPlanExtensionlessMachO(t *testing.T) {
+	app := filepath.Join(t.TempDir(), "MyApp.app")
+	makeBundle(t, app, "Contents/Info.plist", "Contents/Resources/run.sh")
+
+	files := map[string][]byte{
+		"Contents/MacOS/MyApp":                               machOHeader,
+		"Contents/MacOS/crashpad_handler":                    machOHeader,
+		"Contents/Helpers/tool":                              machOHeader,
+		"Contents/Frameworks/Core.framework/Versions/A/Core": machOHeader,
+		// A universal binary, and a Java class file sharing its magic
+		"Contents/Resources/fat-tool":   {0xca, 0xfe, 0xba, 0xbe, 0x00, 0x00, 0x00, 0x02},
+		"Contents/Resources/Main.class": {0xca, 0xfe, 0xba, 0xbe, 0x00, 0x00, 0x00, 0x34},
+		"Contents/Resources/empty":      {},
+	}
+	for name, data := range files {
+		path := filepath.Join(app, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plan, err := BuildSignPlan(app, nil)
+	if err != nil {
+		t.Fatalf("BuildSignPlan() unexpected error: %v", err)
+	}
+	var got []string
+	for _, path := range plan {
+		rel, _ := filepath.Rel(app, path)
+		got = append(got, rel)
+	}
+	want := []string{
+		"Contents/Frameworks/Core.framework",
+		"Contents/Helpers/tool",
+		"Contents/MacOS/crashpad_handler",
+		"Contents/Resources/fat-tool",
+		".",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildSignPlan() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestBuildSignPlanInvalidGlob(t *testing.T) {
+	app := filepath.Join(t.TempDir(), "MyApp.app")
+	makeBundle(t, app, "Contents/MacOS/MyApp")
+
+	if _, err := BuildSignPlan(app, []string{"[abc"}); err == nil {
+		t.Error("BuildSignPlan() expected error for a malformed glob, got nil")
+	}
+}
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"fmt"
+	"os"
 )
 
 // RequiredString validates that a string field is not empty
@@ -45,3 +46,28 @@ func AllOneOf(values []string, allowed []string, field string) error {
 	}
 	return nil
 }
+
+// Executable validates that path names an executable file on the local
+// filesystem
+func Executable(path, field string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", field, err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("invalid %s: %s is not an executable file", field, path)
+	}
+	return nil
+}
+
+// ContainsAny reports whether values contains at least one of the candidates
+func ContainsAny(values []string, candidates ...string) bool {
+	for _, v := range values {
+		for _, c := range candidates {
+			if v == c {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -29,3 +29,9 @@ func VersionInfo() string {
 func ShortVersion() string {
 	return fmt.Sprintf("%s %s", Name, version)
 }
+
+// UserAgent returns the User-Agent sent with HTTP requests, e.g.
+// macreleaser/1.2.3
+func UserAgent() string {
+	return fmt.Sprintf("%s/%s", Name, version)
+}
@@ -0,0 +1,92 @@
+// Package xattr reads and writes the extended attributes of files. On macOS
+// they hold the code signatures of files that are not Mach-O, such as
+// scripts, along with Finder info and resource forks.
+package xattr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sys/unix"
+)
+
+// List returns the names of the extended attributes of path, sorted. A
+// symlink's own attributes are listed, not its target's. A filesystem
+// without extended attributes has none.
+func List(path string) ([]string, error) {
+	var buf []byte
+	for {
+		size, err := unix.Llistxattr(path, nil)
+		if errors.Is(err, unix.ENOTSUP) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list extended attributes of %s: %w", path, err)
+		}
+		if size == 0 {
+			return nil, nil
+		}
+		buf = make([]byte, size)
+		size, err = unix.Llistxattr(path, buf)
+		if errors.Is(err, unix.ERANGE) {
+			// An attribute was added since the size was read
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list extended attributes of %s: %w", path, err)
+		}
+		buf = buf[:size]
+		break
+	}
+
+	var names []string
+	for _, name := range bytes.Split(buf, []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Get returns the value of the extended attribute name of path, without
+// following a symlink.
+func Get(path, name string) ([]byte, error) {
+	for {
+		size, err := unix.Lgetxattr(path, name, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read extended attribute %s of %s: %w", name, path, err)
+		}
+		value := make([]byte, size)
+		size, err = unix.Lgetxattr(path, name, value)
+		if errors.Is(err, unix.ERANGE) {
+			// The value grew since its size was read
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read extended attribute %s of %s: %w", name, path, err)
+		}
+		return value[:size], nil
+	}
+}
+
+// Copy sets every extended attribute of src on dst, without following
+// symlinks on either side.
+func Copy(src, dst string) error {
+	names, err := List(src)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		value, err := Get(src, name)
+		if err != nil {
+			return err
+		}
+		if err := unix.Lsetxattr(dst, name, value, 0); err != nil {
+			return fmt.Errorf("failed to set extended attribute %s on %s: %w", name, dst, err)
+		}
+	}
+	return nil
+}